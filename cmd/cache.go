@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// cacheCmd executes 'atmos cache' CLI commands
+var cacheCmd = &cobra.Command{
+	Use:                "cache",
+	Short:              "Manage the persistent cache of Terraform state and outputs",
+	Long:               `This command manages the persistent cache of Terraform state and outputs configured in the 'components.terraform.state_cache' section of 'atmos.yaml'.`,
+	FParseErrWhitelist: struct{ UnknownFlags bool }{UnknownFlags: false},
+	Args:               cobra.NoArgs,
+}
+
+func init() {
+	RootCmd.AddCommand(cacheCmd)
+}
@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	e "github.com/cloudposse/atmos/internal/exec"
+)
+
+// cacheCleanCmd executes 'cache clean' CLI command
+var cacheCleanCmd = &cobra.Command{
+	Use:                "clean",
+	Short:              "Remove all entries from the persistent cache of Terraform state and outputs",
+	Long:               `This command removes all the cached Terraform state and outputs used by the '!terraform.state' and '!terraform.output' YAML functions.`,
+	Example:            "atmos cache clean",
+	FParseErrWhitelist: struct{ UnknownFlags bool }{UnknownFlags: false},
+	Args:               cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Check Atmos configuration
+		checkAtmosConfig()
+
+		err := e.ExecuteCacheCleanCmd(cmd, args)
+		return err
+	},
+}
+
+func init() {
+	cacheCmd.AddCommand(cacheCleanCmd)
+}
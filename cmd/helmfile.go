@@ -30,6 +30,5 @@ func helmfileRun(cmd *cobra.Command, commandName string, args []string) error {
 	diffArgs = append(diffArgs, args...)
 	info := getConfigAndStacksInfo("helmfile", cmd, diffArgs)
 	info.CliArgs = []string{"helmfile", commandName}
-	err := e.ExecuteHelmfile(info)
-	return err
+	return e.ExecuteHelmfileWithHooks(info, componentHooks(cmd, args))
 }
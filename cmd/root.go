@@ -209,6 +209,8 @@ func init() {
 	RootCmd.PersistentFlags().StringSlice("config", []string{}, "Paths to configuration files (comma-separated or repeated flag)")
 	RootCmd.PersistentFlags().StringSlice("config-path", []string{}, "Paths to configuration directories (comma-separated or repeated flag)")
 	RootCmd.PersistentFlags().Bool("no-color", false, "Disable color output")
+	RootCmd.PersistentFlags().Bool("refresh-stores", false, "Bypass the store caches and refresh them with the values read from the stores")
+	RootCmd.PersistentFlags().Bool("skip-cache", false, "Bypass the persistent cache of Terraform state and outputs, and refresh it")
 	// Set custom usage template
 	err := templates.SetCustomUsageFunc(RootCmd)
 	if err != nil {
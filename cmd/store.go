@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// storeCmd executes 'atmos store' CLI commands
+var storeCmd = &cobra.Command{
+	Use:                "store",
+	Short:              "Manage values in the stores configured in atmos.yaml",
+	Long:               `This command reads, writes, lists, deletes and copies values in the stores configured in the 'stores' section of 'atmos.yaml'.`,
+	FParseErrWhitelist: struct{ UnknownFlags bool }{UnknownFlags: false},
+	Args:               cobra.NoArgs,
+}
+
+// addStoreFlags adds the flags that identify a store, stack and component to a store command.
+func addStoreFlags(cmd *cobra.Command, storeFlag string, storeFlagUsage string) {
+	cmd.PersistentFlags().String(storeFlag, "", storeFlagUsage)
+	cmd.PersistentFlags().StringP("component", "c", "", "Specify the Atmos component")
+	AddStackCompletion(cmd)
+}
+
+func init() {
+	RootCmd.AddCommand(storeCmd)
+}
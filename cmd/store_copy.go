@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	e "github.com/cloudposse/atmos/internal/exec"
+)
+
+// storeCopyCmd executes 'store copy' CLI command
+var storeCopyCmd = &cobra.Command{
+	Use:   "copy [keys...]",
+	Short: "Copy values between stores",
+	Long: `This command copies keys for a stack and component from one store to another.
+If no keys are given, all keys of the stack and component in the source store are copied.`,
+	Example:            "atmos store copy --from dev/ssm --to prod/ssm -s plat-ue2-prod -c vpc vpc_id subnet_ids",
+	FParseErrWhitelist: struct{ UnknownFlags bool }{UnknownFlags: false},
+	Args:               cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Check Atmos configuration
+		checkAtmosConfig()
+
+		err := e.ExecuteStoreCopyCmd(cmd, args)
+		return err
+	},
+}
+
+func init() {
+	addStoreFlags(storeCopyCmd, "from", "Specify the name of the store to copy from")
+	storeCopyCmd.PersistentFlags().String("to", "", "Specify the name of the store to copy to")
+	storeCopyCmd.PersistentFlags().String("to-stack", "", "Specify the destination stack. Defaults to the source stack")
+	storeCopyCmd.PersistentFlags().String("to-component", "", "Specify the destination component. Defaults to the source component")
+
+	storeCmd.AddCommand(storeCopyCmd)
+}
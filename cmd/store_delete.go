@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	e "github.com/cloudposse/atmos/internal/exec"
+)
+
+// storeDeleteCmd executes 'store delete' CLI command
+var storeDeleteCmd = &cobra.Command{
+	Use:                "delete <key>",
+	Short:              "Delete a value from a store",
+	Long:               `This command deletes a key for a stack and component from a store.`,
+	Example:            "atmos store delete vpc_id --store prod/ssm -s plat-ue2-prod -c vpc",
+	FParseErrWhitelist: struct{ UnknownFlags bool }{UnknownFlags: false},
+	Args:               cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Check Atmos configuration
+		checkAtmosConfig()
+
+		err := e.ExecuteStoreDeleteCmd(cmd, args)
+		return err
+	},
+}
+
+func init() {
+	addStoreFlags(storeDeleteCmd, "store", "Specify the name of the store to delete from")
+
+	storeCmd.AddCommand(storeDeleteCmd)
+}
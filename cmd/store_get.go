@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	e "github.com/cloudposse/atmos/internal/exec"
+)
+
+// storeGetCmd executes 'store get' CLI command
+var storeGetCmd = &cobra.Command{
+	Use:                "get <key>",
+	Short:              "Get a value from a store",
+	Long:               `This command reads the value of a key for a stack and component from a store and prints it in JSON or YAML format.`,
+	Example:            "atmos store get vpc_id --store prod/ssm -s plat-ue2-prod -c vpc",
+	FParseErrWhitelist: struct{ UnknownFlags bool }{UnknownFlags: false},
+	Args:               cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Check Atmos configuration
+		checkAtmosConfig()
+
+		err := e.ExecuteStoreGetCmd(cmd, args)
+		return err
+	},
+}
+
+func init() {
+	addStoreFlags(storeGetCmd, "store", "Specify the name of the store to read from")
+	storeGetCmd.PersistentFlags().StringP("format", "f", "json", "Specify the output format (`json` or `yaml`)")
+
+	storeCmd.AddCommand(storeGetCmd)
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	e "github.com/cloudposse/atmos/internal/exec"
+)
+
+// storeListCmd executes 'store list' CLI command
+var storeListCmd = &cobra.Command{
+	Use:                "list",
+	Short:              "List the keys in a store",
+	Long:               `This command lists the keys stored for a stack and component in a store and prints them in JSON or YAML format.`,
+	Example:            "atmos store list --store prod/ssm -s plat-ue2-prod -c vpc",
+	FParseErrWhitelist: struct{ UnknownFlags bool }{UnknownFlags: false},
+	Args:               cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Check Atmos configuration
+		checkAtmosConfig()
+
+		err := e.ExecuteStoreListCmd(cmd, args)
+		return err
+	},
+}
+
+func init() {
+	addStoreFlags(storeListCmd, "store", "Specify the name of the store to list")
+	storeListCmd.PersistentFlags().StringP("format", "f", "json", "Specify the output format (`json` or `yaml`)")
+
+	storeCmd.AddCommand(storeListCmd)
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	e "github.com/cloudposse/atmos/internal/exec"
+)
+
+// storeSetCmd executes 'store set' CLI command
+var storeSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Set a value in a store",
+	Long: `This command writes the value of a key for a stack and component to a store.
+The value is parsed as YAML (or JSON), so maps, lists, numbers and booleans keep their types. Use '--raw' to store the value as a string.`,
+	Example:            "atmos store set vpc_id vpc-0123456789 --store prod/ssm -s plat-ue2-prod -c vpc",
+	FParseErrWhitelist: struct{ UnknownFlags bool }{UnknownFlags: false},
+	Args:               cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Check Atmos configuration
+		checkAtmosConfig()
+
+		err := e.ExecuteStoreSetCmd(cmd, args)
+		return err
+	},
+}
+
+func init() {
+	addStoreFlags(storeSetCmd, "store", "Specify the name of the store to write to")
+	storeSetCmd.PersistentFlags().Bool("raw", false, "Store the value as a string without parsing it as YAML or JSON")
+
+	storeCmd.AddCommand(storeSetCmd)
+}
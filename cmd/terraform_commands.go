@@ -7,7 +7,6 @@ import (
 	"github.com/spf13/cobra"
 
 	errUtils "github.com/cloudposse/atmos/errors"
-	h "github.com/cloudposse/atmos/pkg/hooks"
 	"github.com/cloudposse/atmos/pkg/version"
 )
 
@@ -44,9 +43,6 @@ Example usage:
 			Annotations: map[string]string{
 				"nativeCommand": "true",
 			},
-			PostRunE: func(cmd *cobra.Command, args []string) error {
-				return runHooks(h.AfterTerraformApply, cmd, args)
-			},
 		},
 		{
 			Use:   "workspace",
@@ -77,9 +73,6 @@ Common use cases:
 			Use:   "deploy",
 			Short: "Deploy the specified infrastructure using Terraform",
 			Long:  `Deploys infrastructure by running the Terraform apply command with automatic approval. This ensures that the changes defined in your Terraform configuration are applied without requiring manual confirmation, streamlining the deployment process.`,
-			PostRunE: func(cmd *cobra.Command, args []string) error {
-				return runHooks(h.AfterTerraformApply, cmd, args)
-			},
 		},
 		{
 			Use:   "shell",
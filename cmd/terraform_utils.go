@@ -3,6 +3,8 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"os/exec"
+	"time"
 
 	log "github.com/charmbracelet/log"
 	"github.com/spf13/cobra"
@@ -14,26 +16,59 @@ import (
 	"github.com/cloudposse/atmos/pkg/schema"
 )
 
-func runHooks(event h.HookEvent, cmd *cobra.Command, args []string) error {
-	info := getConfigAndStacksInfo("terraform", cmd, append([]string{cmd.Name()}, args...))
+// componentHooks returns the function that runs the `before` hooks of the component for the sub-command, executes the
+// sub-command, and then runs the `after` hooks with the exit code of the sub-command. The hooks are read from the component
+// processed by the sub-command, so the stacks are processed only once.
+func componentHooks(cmd *cobra.Command, args []string) e.ComponentHooks {
+	return func(atmosConfig *schema.AtmosConfiguration, info *schema.ConfigAndStacksInfo, execute func() error) error {
+		beforeEvent, afterEvent, ok := h.GetHookEvents(info.ComponentType, info.SubCommand)
+		if !ok || info.ComponentFromArg == "" || info.Stack == "" {
+			return execute()
+		}
 
-	// Initialize the CLI config
-	atmosConfig, err := cfg.InitCliConfig(info, true)
-	if err != nil {
-		return fmt.Errorf("error initializing CLI config: %w", err)
-	}
+		hooks, err := h.NewHooks(atmosConfig, info)
+		if err != nil {
+			return fmt.Errorf("error getting hooks: %w", err)
+		}
 
-	hooks, err := h.GetHooks(&atmosConfig, &info)
-	if err != nil {
-		return fmt.Errorf("error getting hooks: %w", err)
-	}
+		if !hooks.HasHooks() {
+			return execute()
+		}
 
-	if hooks != nil && hooks.HasHooks() {
-		log.Info("Running hooks", "event", event)
-		return hooks.RunAll(event, &atmosConfig, &info, cmd, args)
+		log.Info("Running hooks", "event", beforeEvent)
+		if err := hooks.RunAll(hooks.NewEventPayload(beforeEvent, 0), atmosConfig, info, cmd, args); err != nil {
+			return err
+		}
+
+		start := time.Now()
+		err = execute()
+
+		payload := hooks.NewEventPayload(afterEvent, getExitCode(err))
+		payload.Duration = time.Since(start)
+
+		log.Info("Running hooks", "event", afterEvent)
+		if hooksErr := hooks.RunAll(payload, atmosConfig, info, cmd, args); hooksErr != nil {
+			// The error of the command takes precedence over the errors of the hooks
+			if err == nil {
+				return hooksErr
+			}
+			log.Error("Failed to run hooks", "event", afterEvent, "error", hooksErr)
+		}
+
+		return err
 	}
+}
 
-	return nil
+// getExitCode returns the exit code of the command that returned the error.
+func getExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitError *exec.ExitError
+	if errors.As(err, &exitError) {
+		return exitError.ExitCode()
+	}
+	return 1
 }
 
 func terraformRun(cmd *cobra.Command, actualCmd *cobra.Command, args []string) error {
@@ -108,8 +143,8 @@ func terraformRun(cmd *cobra.Command, actualCmd *cobra.Command, args []string) e
 		return nil
 	}
 
-	// Execute `atmos terraform <sub-command> <component> --stack <stack>`
-	err = e.ExecuteTerraform(info)
+	// Execute `atmos terraform <sub-command> <component> --stack <stack>` and the hooks of the component
+	err = e.ExecuteTerraformWithHooks(info, componentHooks(cmd, args))
 	// For plan-diff, ExecuteTerraform will call OsExit directly if there are differences
 	// So if we get here, it means there were no differences or there was an error
 	if err != nil {
@@ -61,6 +61,12 @@ func init() {
 	workflowCmd.PersistentFlags().Bool("dry-run", false, "Simulate the workflow without making any changes")
 	AddStackCompletion(workflowCmd)
 	workflowCmd.PersistentFlags().String("from-step", "", "Resume the workflow from the specified step")
+	workflowCmd.PersistentFlags().Bool("resume", false, "Resume the last run of the workflow from its failed steps")
+	workflowCmd.PersistentFlags().Int("max-parallel", 0, "Maximum number of workflow steps with dependencies or for_each to run concurrently")
+	workflowCmd.PersistentFlags().StringArray("input", nil, "Set the value of a workflow input (can be used multiple times): --input key=value")
+	workflowCmd.PersistentFlags().String("report-file", "", "Write a report of the workflow run with the result of each step to the file")
+	workflowCmd.PersistentFlags().String("report-format", "json", "Format of the workflow run report (`json` or `junit`)")
+	workflowCmd.PersistentFlags().Bool("auto-approve-gates", false, "Approve the workflow steps of type approval without prompting for confirmation")
 
 	RootCmd.AddCommand(workflowCmd)
 }
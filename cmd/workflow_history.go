@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	e "github.com/cloudposse/atmos/internal/exec"
+)
+
+// workflowHistoryCmd executes 'workflow history' CLI command
+var workflowHistoryCmd = &cobra.Command{
+	Use:                "history [workflow]",
+	Short:              "List the past runs of Atmos workflows",
+	Long:               `This command lists the workflow runs recorded in the workflow journal, the most recent run first, with their status and failed steps.`,
+	Example:            "atmos workflow history deploy -f networking --limit 5",
+	FParseErrWhitelist: struct{ UnknownFlags bool }{UnknownFlags: false},
+	Args:               cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Check Atmos configuration
+		checkAtmosConfig()
+
+		err := e.ExecuteWorkflowHistoryCmd(cmd, args)
+		return err
+	},
+}
+
+func init() {
+	workflowHistoryCmd.Flags().Int("limit", 20, "Maximum number of workflow runs to list (0 lists all the runs)")
+	workflowHistoryCmd.Flags().String("format", "", "Output format (`table`, `json` or `yaml`)")
+
+	workflowCmd.AddCommand(workflowHistoryCmd)
+}
@@ -51,7 +51,32 @@ var (
 	ErrLoadAwsConfig                    = errors.New("failed to load AWS config")
 	ErrGetObjectFromS3                  = errors.New("failed to get object from S3")
 	ErrReadS3ObjectBody                 = errors.New("failed to read S3 object body")
+	ErrCreateGCSClient                  = errors.New("failed to create GCS client")
+	ErrGetObjectFromGCS                 = errors.New("failed to get object from GCS")
+	ErrReadGCSObjectBody                = errors.New("failed to read GCS object body")
+	ErrCreateAzureBlobClient            = errors.New("failed to create Azure Blob Storage client")
+	ErrGetBlobFromAzure                 = errors.New("failed to get blob from Azure Blob Storage")
+	ErrReadAzureBlobBody                = errors.New("failed to read Azure blob body")
+	ErrInvalidBackendReader             = errors.New("invalid Terraform backend reader")
+	ErrGetStateFromHTTP                 = errors.New("failed to get state from HTTP backend")
+	ErrCreateConsulClient               = errors.New("failed to create Consul client")
+	ErrGetStateFromConsul               = errors.New("failed to get state from Consul")
+	ErrCreatePgClient                   = errors.New("failed to create Postgres client")
+	ErrGetStateFromPg                   = errors.New("failed to get state from Postgres")
+	ErrTerraformCloudTokenRequired      = errors.New("HCP Terraform API token is required")
+	ErrGetStateFromTerraformCloud       = errors.New("failed to get state from HCP Terraform")
+	ErrExternalBackendReader            = errors.New("external Terraform backend reader failed")
+	ErrStateCache                       = errors.New("persistent Terraform state cache error")
 
 	ErrReadFile    = errors.New("error reading file")
 	ErrInvalidFlag = errors.New("invalid flag")
+
+	ErrMissingRequiredFlag = errors.New("missing required flag")
+	ErrStoreNotFound       = errors.New("store not found in 'stores' config")
+	ErrNoStoreKeysToCopy   = errors.New("no keys found to copy")
+
+	ErrUnsupportedHookCommand = errors.New("unsupported hook command")
+	ErrInvalidHook            = errors.New("invalid hook")
+	ErrHookCommandFailed      = errors.New("hook command failed")
+	ErrHookTimeout            = errors.New("hook timed out")
 )
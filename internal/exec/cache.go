@@ -0,0 +1,28 @@
+package exec
+
+import (
+	log "github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+
+	tb "github.com/cloudposse/atmos/internal/terraform_backend"
+	cfg "github.com/cloudposse/atmos/pkg/config"
+	"github.com/cloudposse/atmos/pkg/schema"
+)
+
+// ExecuteCacheCleanCmd executes `atmos cache clean` command.
+func ExecuteCacheCleanCmd(cmd *cobra.Command, args []string) error {
+	// InitCliConfig finds and merges CLI configurations in the following order:
+	// system dir, home dir, current dir, ENV vars, command-line arguments
+	atmosConfig, err := cfg.InitCliConfig(schema.ConfigAndStacksInfo{}, false)
+	if err != nil {
+		return err
+	}
+
+	dir, err := tb.CleanStateCache(&atmosConfig)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Cleaned the persistent cache of Terraform state and outputs", "path", dir)
+	return nil
+}
@@ -531,6 +531,13 @@ func processArgsAndFlags(
 			info.All = true
 		}
 
+		// The Atmos cache flags are not passed to the commands, with or without a value (e.g. `--skip-cache=true`)
+		for _, f := range []string{cfg.RefreshStoresFlag, cfg.SkipCacheFlag} {
+			if arg == f || strings.HasPrefix(arg, f+"=") {
+				indexesToRemove = append(indexesToRemove, i)
+			}
+		}
+
 		for _, f := range commonFlags {
 			if arg == f {
 				indexesToRemove = append(indexesToRemove, i)
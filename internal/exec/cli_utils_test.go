@@ -47,6 +47,42 @@ func Test_processArgsAndFlags2(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:              "refresh stores flag",
+			componentType:     "terraform",
+			inputArgsAndFlags: []string{"clean", "--refresh-stores"},
+			want: schema.ArgsAndFlagsInfo{
+				SubCommand: "clean",
+			},
+			wantErr: false,
+		},
+		{
+			name:              "refresh stores flag with value",
+			componentType:     "terraform",
+			inputArgsAndFlags: []string{"clean", "--refresh-stores=true"},
+			want: schema.ArgsAndFlagsInfo{
+				SubCommand: "clean",
+			},
+			wantErr: false,
+		},
+		{
+			name:              "skip cache flag",
+			componentType:     "terraform",
+			inputArgsAndFlags: []string{"clean", "--skip-cache"},
+			want: schema.ArgsAndFlagsInfo{
+				SubCommand: "clean",
+			},
+			wantErr: false,
+		},
+		{
+			name:              "skip cache flag with value",
+			componentType:     "terraform",
+			inputArgsAndFlags: []string{"clean", "--skip-cache=false"},
+			want: schema.ArgsAndFlagsInfo{
+				SubCommand: "clean",
+			},
+			wantErr: false,
+		},
 		{
 			name:              "version command",
 			componentType:     "terraform",
@@ -0,0 +1,9 @@
+package exec
+
+import (
+	"github.com/cloudposse/atmos/pkg/schema"
+)
+
+// ComponentHooks runs the hooks of a component around a command. It's called with the component processed by the command,
+// so that the hooks don't process the stacks again.
+type ComponentHooks func(atmosConfig *schema.AtmosConfiguration, info *schema.ConfigAndStacksInfo, execute func() error) error
@@ -323,7 +323,8 @@ func ExecuteDescribeStacks(
 
 							// Process `Go` templates
 							if processTemplates {
-								componentSectionStr, err := u.ConvertToYAML(componentSection)
+								componentSectionWithoutHookTemplates, restoreHookTemplates := excludeHookTemplates(componentSection)
+								componentSectionStr, err := u.ConvertToYAML(componentSectionWithoutHookTemplates)
 								if err != nil {
 									return nil, err
 								}
@@ -359,6 +360,8 @@ func ExecuteDescribeStacks(
 									errUtils.CheckErrorPrintAndExit(err, "", "")
 								}
 
+								restoreHookTemplates(componentSectionConverted)
+
 								componentSection = componentSectionConverted
 							}
 
@@ -542,7 +545,8 @@ func ExecuteDescribeStacks(
 
 							// Process `Go` templates
 							if processTemplates {
-								componentSectionStr, err := u.ConvertToYAML(componentSection)
+								componentSectionWithoutHookTemplates, restoreHookTemplates := excludeHookTemplates(componentSection)
+								componentSectionStr, err := u.ConvertToYAML(componentSectionWithoutHookTemplates)
 								if err != nil {
 									return nil, err
 								}
@@ -578,6 +582,8 @@ func ExecuteDescribeStacks(
 									errUtils.CheckErrorPrintAndExit(err, "", "")
 								}
 
+								restoreHookTemplates(componentSectionConverted)
+
 								componentSection = componentSectionConverted
 							}
 
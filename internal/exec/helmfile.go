@@ -28,6 +28,11 @@ func ExecuteHelmfileCmd(cmd *cobra.Command, args []string, additionalArgsAndFlag
 
 // ExecuteHelmfile executes helmfile commands
 func ExecuteHelmfile(info schema.ConfigAndStacksInfo) error {
+	return ExecuteHelmfileWithHooks(info, nil)
+}
+
+// ExecuteHelmfileWithHooks executes helmfile commands, and runs the hooks of the component around the command.
+func ExecuteHelmfileWithHooks(info schema.ConfigAndStacksInfo, hooks ComponentHooks) error {
 	atmosConfig, err := cfg.InitCliConfig(info, true)
 	if err != nil {
 		return err
@@ -67,7 +72,17 @@ func ExecuteHelmfile(info schema.ConfigAndStacksInfo) error {
 		return nil
 	}
 
-	err = checkHelmfileConfig(atmosConfig)
+	if hooks == nil {
+		return executeHelmfileComponent(atmosConfig, info)
+	}
+	return hooks(&atmosConfig, &info, func() error {
+		return executeHelmfileComponent(atmosConfig, info)
+	})
+}
+
+// executeHelmfileComponent executes a helmfile command for the component processed from the stacks.
+func executeHelmfileComponent(atmosConfig schema.AtmosConfiguration, info schema.ConfigAndStacksInfo) error {
+	err := checkHelmfileConfig(atmosConfig)
 	if err != nil {
 		return err
 	}
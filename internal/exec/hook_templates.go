@@ -0,0 +1,48 @@
+package exec
+
+import (
+	"github.com/samber/lo"
+
+	cfg "github.com/cloudposse/atmos/pkg/config"
+)
+
+// hookTemplateFields are the fields of the hooks with Go templates rendered by the hooks when they run, with the event
+// and the plan summary in the template data. They are not processed with the Go templates in the stack manifests.
+var hookTemplateFields = []string{"when", "run", "args", "url", "headers", "secret"}
+
+// excludeHookTemplates returns a copy of the component section without the fields of the hooks rendered when the hooks run,
+// and a function that adds the fields back to the component section after its Go templates are processed.
+func excludeHookTemplates(componentSection map[string]any) (map[string]any, func(map[string]any)) {
+	hooksSection, ok := componentSection[cfg.HooksSectionName].(map[string]any)
+	if !ok || len(hooksSection) == 0 {
+		return componentSection, func(map[string]any) {}
+	}
+
+	excluded := map[string]map[string]any{}
+	hooks := make(map[string]any, len(hooksSection))
+	for name, hook := range hooksSection {
+		hookSection, ok := hook.(map[string]any)
+		if !ok {
+			hooks[name] = hook
+			continue
+		}
+		hooks[name] = lo.OmitByKeys(hookSection, hookTemplateFields)
+		excluded[name] = lo.PickByKeys(hookSection, hookTemplateFields)
+	}
+
+	section := lo.Assign(componentSection, map[string]any{cfg.HooksSectionName: hooks})
+
+	restore := func(processed map[string]any) {
+		processedHooks, ok := processed[cfg.HooksSectionName].(map[string]any)
+		if !ok {
+			return
+		}
+		for name, fields := range excluded {
+			if hook, ok := processedHooks[name].(map[string]any); ok {
+				processedHooks[name] = lo.Assign(hook, fields)
+			}
+		}
+	}
+
+	return section, restore
+}
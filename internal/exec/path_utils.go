@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"path/filepath"
 
+	cfg "github.com/cloudposse/atmos/pkg/config"
 	"github.com/cloudposse/atmos/pkg/schema"
 )
 
@@ -85,3 +86,20 @@ func constructHelmfileComponentVarfilePath(atmosConfig schema.AtmosConfiguration
 		constructHelmfileComponentVarfileName(info),
 	)
 }
+
+// GetComponentWorkingDir returns the working dir of a processed Terraform or Helmfile component in a stack.
+func GetComponentWorkingDir(atmosConfig *schema.AtmosConfiguration, info *schema.ConfigAndStacksInfo) string {
+	if info.ComponentType == cfg.HelmfileComponentType {
+		return constructHelmfileComponentWorkingDir(*atmosConfig, *info)
+	}
+	return constructTerraformComponentWorkingDir(*atmosConfig, *info)
+}
+
+// GetTerraformPlanfilePath returns the path to the planfile of a processed Terraform component in a stack.
+// If the planfile is specified on the command line (`--planfile`), it's returned instead.
+func GetTerraformPlanfilePath(atmosConfig *schema.AtmosConfiguration, info *schema.ConfigAndStacksInfo) string {
+	if info.PlanFile != "" {
+		return info.PlanFile
+	}
+	return constructTerraformComponentPlanfilePath(*atmosConfig, *info)
+}
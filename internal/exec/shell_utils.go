@@ -2,7 +2,9 @@ package exec
 
 import (
 	"bytes"
+	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -26,6 +28,23 @@ func ExecuteShellCommand(
 	env []string,
 	dryRun bool,
 	redirectStdError string,
+) error {
+	return ExecuteShellCommandWithContext(context.Background(), atmosConfig, command, args, dir, env, dryRun, redirectStdError, nil)
+}
+
+// ExecuteShellCommandWithContext prints and executes the provided command with args and flags.
+// The command is killed if the context is done before the command completes.
+// The command uses the given standard streams, or the standard streams of the process if streams is nil.
+func ExecuteShellCommandWithContext(
+	ctx context.Context,
+	atmosConfig schema.AtmosConfiguration,
+	command string,
+	args []string,
+	dir string,
+	env []string,
+	dryRun bool,
+	redirectStdError string,
+	streams *ShellIO,
 ) error {
 	newShellLevel, err := u.GetNextShellLevel()
 	if err != nil {
@@ -33,22 +52,24 @@ func ExecuteShellCommand(
 	}
 	updatedEnv := append(env, fmt.Sprintf("ATMOS_SHLVL=%d", newShellLevel))
 
-	cmd := exec.Command(command, args...)
+	cmd := exec.CommandContext(ctx, command, args...)
 	cmd.Env = append(os.Environ(), updatedEnv...)
 	cmd.Dir = dir
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
+
+	streams = getShellIO(streams)
+	cmd.Stdin = streams.Stdin
+	cmd.Stdout = streams.Stdout
 
 	if runtime.GOOS == "windows" && redirectStdError == "/dev/null" {
 		redirectStdError = "NUL"
 	}
 
 	if redirectStdError == "/dev/stderr" {
-		cmd.Stderr = os.Stderr
+		cmd.Stderr = streams.Stderr
 	} else if redirectStdError == "/dev/stdout" {
-		cmd.Stderr = os.Stdout
+		cmd.Stderr = streams.Stdout
 	} else if redirectStdError == "" {
-		cmd.Stderr = os.Stderr
+		cmd.Stderr = streams.Stderr
 	} else {
 		f, err := os.OpenFile(redirectStdError, os.O_WRONLY|os.O_CREATE, 0o644)
 		if err != nil {
@@ -74,6 +95,21 @@ func ExecuteShellCommand(
 	return cmd.Run()
 }
 
+// ShellIO holds the standard streams of the executed commands.
+type ShellIO struct {
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// getShellIO returns the given standard streams, or the standard streams of the process if streams is nil.
+func getShellIO(streams *ShellIO) *ShellIO {
+	if streams != nil {
+		return streams
+	}
+	return &ShellIO{Stdin: os.Stdin, Stdout: os.Stdout, Stderr: os.Stderr}
+}
+
 // ExecuteShell runs a shell script
 func ExecuteShell(
 	atmosConfig schema.AtmosConfiguration,
@@ -82,6 +118,22 @@ func ExecuteShell(
 	dir string,
 	env []string,
 	dryRun bool,
+) error {
+	return ExecuteShellWithContext(context.Background(), atmosConfig, command, name, dir, env, dryRun, nil)
+}
+
+// ExecuteShellWithContext runs a shell script like ExecuteShell. The script is stopped if the context is done
+// before the script completes. The script uses the given standard streams, or the standard streams of the process
+// if streams is nil.
+func ExecuteShellWithContext(
+	ctx context.Context,
+	atmosConfig schema.AtmosConfiguration,
+	command string,
+	name string,
+	dir string,
+	env []string,
+	dryRun bool,
+	streams *ShellIO,
 ) error {
 	newShellLevel, err := u.GetNextShellLevel()
 	if err != nil {
@@ -95,7 +147,8 @@ func ExecuteShell(
 		return nil
 	}
 
-	return u.ShellRunner(command, name, dir, env, os.Stdout)
+	streams = getShellIO(streams)
+	return u.ShellRunnerWithContext(ctx, command, name, dir, env, streams.Stdin, streams.Stdout, streams.Stderr)
 }
 
 // execTerraformShellCommand executes `terraform shell` command by starting a new interactive shell
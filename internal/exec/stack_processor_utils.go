@@ -602,6 +602,7 @@ func ProcessStackConfig(
 	helmfileSettings := map[string]any{}
 	helmfileEnv := map[string]any{}
 	helmfileCommand := ""
+	helmfileHooks := map[string]any{}
 
 	terraformComponents := map[string]any{}
 	helmfileComponents := map[string]any{}
@@ -807,6 +808,18 @@ func ProcessStackConfig(
 		return nil, err
 	}
 
+	if i, ok := globalHelmfileSection[cfg.HooksSectionName]; ok {
+		helmfileHooks, ok = i.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("invalid 'helmfile.hooks' section in the file '%s'", stackName)
+		}
+	}
+
+	globalAndHelmfileHooks, err := m.Merge(atmosConfig, []map[string]any{globalHooksSection, helmfileHooks})
+	if err != nil {
+		return nil, err
+	}
+
 	// Process all Terraform components
 	if componentTypeFilter == "" || componentTypeFilter == "terraform" {
 		if allTerraformComponents, ok := globalComponentsSection["terraform"]; ok {
@@ -1402,6 +1415,14 @@ func ProcessStackConfig(
 					}
 				}
 
+				componentHooks := map[string]any{}
+				if i, ok := componentMap[cfg.HooksSectionName]; ok {
+					componentHooks, ok = i.(map[string]any)
+					if !ok {
+						return nil, fmt.Errorf("invalid 'components.helmfile.%s.hooks' section in the file '%s'", component, stackName)
+					}
+				}
+
 				// Component metadata.
 				// This is per component, not deep-merged and not inherited from base components and globals.
 				componentMetadata := map[string]any{}
@@ -1425,6 +1446,7 @@ func ProcessStackConfig(
 				componentOverridesVars := map[string]any{}
 				componentOverridesSettings := map[string]any{}
 				componentOverridesEnv := map[string]any{}
+				componentOverridesHooks := map[string]any{}
 				componentOverridesHelmfileCommand := ""
 
 				if i, ok := componentMap[cfg.OverridesSectionName]; ok {
@@ -1450,6 +1472,12 @@ func ProcessStackConfig(
 						}
 					}
 
+					if i, ok = componentOverrides[cfg.HooksSectionName]; ok {
+						if componentOverridesHooks, ok = i.(map[string]any); !ok {
+							return nil, fmt.Errorf("invalid 'components.helmfile.%s.overrides.hooks' in the manifest '%s'", component, stackName)
+						}
+					}
+
 					if i, ok = componentOverrides[cfg.CommandSectionName]; ok {
 						if componentOverridesHelmfileCommand, ok = i.(string); !ok {
 							return nil, fmt.Errorf("invalid 'components.helmfile.%s.overrides.command' in the manifest '%s'", component, stackName)
@@ -1461,6 +1489,7 @@ func ProcessStackConfig(
 				baseComponentVars := map[string]any{}
 				baseComponentSettings := map[string]any{}
 				baseComponentEnv := map[string]any{}
+				baseComponentHooks := map[string]any{}
 				baseComponentName := ""
 				baseComponentHelmfileCommand := ""
 				var baseComponentConfig schema.BaseComponentConfig
@@ -1493,6 +1522,7 @@ func ProcessStackConfig(
 					baseComponentVars = baseComponentConfig.BaseComponentVars
 					baseComponentSettings = baseComponentConfig.BaseComponentSettings
 					baseComponentEnv = baseComponentConfig.BaseComponentEnv
+					baseComponentHooks = baseComponentConfig.BaseComponentHooks
 					baseComponentName = baseComponentConfig.FinalBaseComponentName
 					baseComponentHelmfileCommand = baseComponentConfig.BaseComponentCommand
 					componentInheritanceChain = baseComponentConfig.ComponentInheritanceChain
@@ -1556,6 +1586,7 @@ func ProcessStackConfig(
 						baseComponentVars = baseComponentConfig.BaseComponentVars
 						baseComponentSettings = baseComponentConfig.BaseComponentSettings
 						baseComponentEnv = baseComponentConfig.BaseComponentEnv
+						baseComponentHooks = baseComponentConfig.BaseComponentHooks
 						baseComponentName = baseComponentConfig.FinalBaseComponentName
 						baseComponentHelmfileCommand = baseComponentConfig.BaseComponentCommand
 						componentInheritanceChain = baseComponentConfig.ComponentInheritanceChain
@@ -1602,6 +1633,18 @@ func ProcessStackConfig(
 					return nil, err
 				}
 
+				finalComponentHooks, err := m.Merge(
+					atmosConfig,
+					[]map[string]any{
+						globalAndHelmfileHooks,
+						baseComponentHooks,
+						componentHooks,
+						componentOverridesHooks,
+					})
+				if err != nil {
+					return nil, err
+				}
+
 				// Final binary to execute
 				// Check for the binary in the following order:
 				// - `components.helmfile.command` section in `atmos.yaml` CLI config file
@@ -1636,6 +1679,7 @@ func ProcessStackConfig(
 				comp[cfg.SettingsSectionName] = finalSettings
 				comp[cfg.EnvSectionName] = finalComponentEnv
 				comp[cfg.CommandSectionName] = finalComponentHelmfileCommand
+				comp[cfg.HooksSectionName] = finalComponentHooks
 				comp["inheritance"] = componentInheritanceChain
 				comp[cfg.MetadataSectionName] = componentMetadata
 				comp[cfg.OverridesSectionName] = componentOverrides
@@ -0,0 +1,276 @@
+package exec
+
+import (
+	"fmt"
+
+	log "github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	errUtils "github.com/cloudposse/atmos/errors"
+	cfg "github.com/cloudposse/atmos/pkg/config"
+	"github.com/cloudposse/atmos/pkg/schema"
+	"github.com/cloudposse/atmos/pkg/store"
+	u "github.com/cloudposse/atmos/pkg/utils"
+)
+
+// StoreCmdArgs holds the arguments shared by the `atmos store` commands.
+type StoreCmdArgs struct {
+	AtmosConfig schema.AtmosConfiguration
+	StoreName   string
+	Stack       string
+	Component   string
+	Format      string
+}
+
+// parseStoreCliArgs parses the flags shared by the `atmos store` commands and initializes the CLI config.
+func parseStoreCliArgs(cmd *cobra.Command, storeFlag string) (StoreCmdArgs, error) {
+	flags := cmd.Flags()
+
+	storeName, err := flags.GetString(storeFlag)
+	if err != nil {
+		return StoreCmdArgs{}, err
+	}
+
+	stack, err := flags.GetString("stack")
+	if err != nil {
+		return StoreCmdArgs{}, err
+	}
+
+	component, err := flags.GetString("component")
+	if err != nil {
+		return StoreCmdArgs{}, err
+	}
+
+	if storeName == "" || stack == "" || component == "" {
+		return StoreCmdArgs{}, fmt.Errorf("%w: '--%s', '--stack' and '--component' must be provided", errUtils.ErrMissingRequiredFlag, storeFlag)
+	}
+
+	format := "json"
+	if flags.Lookup("format") != nil {
+		format, err = flags.GetString("format")
+		if err != nil {
+			return StoreCmdArgs{}, err
+		}
+		if format != "json" && format != "yaml" {
+			return StoreCmdArgs{}, fmt.Errorf("%w: '--format' must be 'json' or 'yaml', got '%s'", errUtils.ErrInvalidFlag, format)
+		}
+	}
+
+	// InitCliConfig finds and merges CLI configurations in the following order:
+	// system dir, home dir, current dir, ENV vars, command-line arguments
+	atmosConfig, err := cfg.InitCliConfig(schema.ConfigAndStacksInfo{}, false)
+	if err != nil {
+		return StoreCmdArgs{}, err
+	}
+
+	return StoreCmdArgs{
+		AtmosConfig: atmosConfig,
+		StoreName:   storeName,
+		Stack:       stack,
+		Component:   component,
+		Format:      format,
+	}, nil
+}
+
+// getStore returns the store with the given name from the store registry.
+func getStore(atmosConfig *schema.AtmosConfiguration, name string) (store.Store, error) {
+	s, ok := atmosConfig.Stores[name]
+	if !ok || s == nil {
+		return nil, fmt.Errorf(errUtils.ErrStringWrappingFormat, errUtils.ErrStoreNotFound, name)
+	}
+	return s, nil
+}
+
+// parseStoreValue converts a value given on the command line to the value to write to a store.
+// Unless raw is set, the value is parsed as YAML (and therefore JSON), so that maps, lists, numbers
+// and booleans are stored with their types.
+func parseStoreValue(value string, raw bool) any {
+	if raw {
+		return value
+	}
+
+	var parsed any
+	if err := yaml.Unmarshal([]byte(value), &parsed); err != nil || parsed == nil {
+		return value
+	}
+	return parsed
+}
+
+// ExecuteStoreGetCmd executes `atmos store get` command.
+func ExecuteStoreGetCmd(cmd *cobra.Command, args []string) error {
+	a, err := parseStoreCliArgs(cmd, "store")
+	if err != nil {
+		return err
+	}
+
+	s, err := getStore(&a.AtmosConfig, a.StoreName)
+	if err != nil {
+		return err
+	}
+
+	value, err := s.Get(a.Stack, a.Component, args[0])
+	if err != nil {
+		return err
+	}
+
+	return printOrWriteToFile(&a.AtmosConfig, a.Format, "", value)
+}
+
+// ExecuteStoreSetCmd executes `atmos store set` command.
+func ExecuteStoreSetCmd(cmd *cobra.Command, args []string) error {
+	a, err := parseStoreCliArgs(cmd, "store")
+	if err != nil {
+		return err
+	}
+
+	raw, err := cmd.Flags().GetBool("raw")
+	if err != nil {
+		return err
+	}
+
+	s, err := getStore(&a.AtmosConfig, a.StoreName)
+	if err != nil {
+		return err
+	}
+
+	if err := s.Set(a.Stack, a.Component, args[0], parseStoreValue(args[1], raw)); err != nil {
+		return err
+	}
+
+	log.Info("Set key in store", "store", a.StoreName, "stack", a.Stack, "component", a.Component, "key", args[0])
+	return nil
+}
+
+// ExecuteStoreListCmd executes `atmos store list` command.
+func ExecuteStoreListCmd(cmd *cobra.Command, args []string) error {
+	a, err := parseStoreCliArgs(cmd, "store")
+	if err != nil {
+		return err
+	}
+
+	s, err := getStore(&a.AtmosConfig, a.StoreName)
+	if err != nil {
+		return err
+	}
+
+	keys, err := s.List(a.Stack, a.Component)
+	if err != nil {
+		return err
+	}
+
+	return printOrWriteToFile(&a.AtmosConfig, a.Format, "", keys)
+}
+
+// ExecuteStoreDeleteCmd executes `atmos store delete` command.
+func ExecuteStoreDeleteCmd(cmd *cobra.Command, args []string) error {
+	a, err := parseStoreCliArgs(cmd, "store")
+	if err != nil {
+		return err
+	}
+
+	s, err := getStore(&a.AtmosConfig, a.StoreName)
+	if err != nil {
+		return err
+	}
+
+	if err := s.Delete(a.Stack, a.Component, args[0]); err != nil {
+		return err
+	}
+
+	log.Info("Deleted key from store", "store", a.StoreName, "stack", a.Stack, "component", a.Component, "key", args[0])
+	return nil
+}
+
+// ExecuteStoreCopyCmd executes `atmos store copy` command.
+func ExecuteStoreCopyCmd(cmd *cobra.Command, args []string) error {
+	a, err := parseStoreCliArgs(cmd, "from")
+	if err != nil {
+		return err
+	}
+
+	flags := cmd.Flags()
+
+	toStoreName, err := flags.GetString("to")
+	if err != nil {
+		return err
+	}
+	if toStoreName == "" {
+		return fmt.Errorf("%w: '--to' must be provided", errUtils.ErrMissingRequiredFlag)
+	}
+
+	toStack, err := flags.GetString("to-stack")
+	if err != nil {
+		return err
+	}
+	if toStack == "" {
+		toStack = a.Stack
+	}
+
+	toComponent, err := flags.GetString("to-component")
+	if err != nil {
+		return err
+	}
+	if toComponent == "" {
+		toComponent = a.Component
+	}
+
+	src, err := getStore(&a.AtmosConfig, a.StoreName)
+	if err != nil {
+		return err
+	}
+
+	dst, err := getStore(&a.AtmosConfig, toStoreName)
+	if err != nil {
+		return err
+	}
+
+	copied, err := copyStoreKeys(src, dst, a.Stack, a.Component, toStack, toComponent, args)
+	if err != nil {
+		return err
+	}
+
+	u.PrintMessage(fmt.Sprintf("Copied %d key(s) from store '%s' to store '%s'", len(copied), a.StoreName, toStoreName))
+	return nil
+}
+
+// copyStoreKeys copies keys from the source store to the destination store. If no keys are given, all keys of the
+// source stack and component are copied. It returns the keys that were copied.
+func copyStoreKeys(
+	src store.Store,
+	dst store.Store,
+	srcStack string,
+	srcComponent string,
+	dstStack string,
+	dstComponent string,
+	keys []string,
+) ([]string, error) {
+	if len(keys) == 0 {
+		var err error
+		keys, err = src.List(srcStack, srcComponent)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	copied := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value, err := src.Get(srcStack, srcComponent, key)
+		if err != nil {
+			return copied, fmt.Errorf("failed to read key '%s': %w", key, err)
+		}
+
+		if err := dst.Set(dstStack, dstComponent, key, value); err != nil {
+			return copied, fmt.Errorf("failed to write key '%s': %w", key, err)
+		}
+
+		log.Debug("Copied key", "key", key)
+		copied = append(copied, key)
+	}
+
+	if len(copied) == 0 {
+		return copied, errUtils.ErrNoStoreKeysToCopy
+	}
+
+	return copied, nil
+}
@@ -0,0 +1,118 @@
+package exec
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errUtils "github.com/cloudposse/atmos/errors"
+	"github.com/cloudposse/atmos/pkg/schema"
+	"github.com/cloudposse/atmos/pkg/store"
+)
+
+func newTestRedisStore(t *testing.T) store.Store {
+	s := miniredis.RunT(t)
+	redisUrl := fmt.Sprintf("redis://%s", s.Addr())
+
+	redisStore, err := store.NewRedisStore(store.RedisStoreOptions{
+		URL: &redisUrl,
+	})
+	require.NoError(t, err)
+	return redisStore
+}
+
+func TestParseStoreValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		raw      bool
+		expected any
+	}{
+		{name: "string", value: "vpc-123", expected: "vpc-123"},
+		{name: "number", value: "42", expected: 42},
+		{name: "boolean", value: "true", expected: true},
+		{name: "json map", value: `{"a": "b"}`, expected: map[string]any{"a": "b"}},
+		{name: "json list", value: `["a", "b"]`, expected: []any{"a", "b"}},
+		{name: "raw", value: "42", raw: true, expected: "42"},
+		{name: "empty", value: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseStoreValue(tt.value, tt.raw))
+		})
+	}
+}
+
+func TestGetStore(t *testing.T) {
+	redisStore := newTestRedisStore(t)
+	atmosConfig := schema.AtmosConfiguration{
+		Stores: map[string]store.Store{
+			"redis": redisStore,
+		},
+	}
+
+	s, err := getStore(&atmosConfig, "redis")
+	require.NoError(t, err)
+	assert.Equal(t, redisStore, s)
+
+	_, err = getStore(&atmosConfig, "missing")
+	assert.ErrorIs(t, err, errUtils.ErrStoreNotFound)
+}
+
+func TestCopyStoreKeys(t *testing.T) {
+	t.Run("copy all keys", func(t *testing.T) {
+		src := newTestRedisStore(t)
+		dst := newTestRedisStore(t)
+
+		require.NoError(t, src.Set("dev", "vpc", "cidr", "10.0.0.0/16"))
+		require.NoError(t, src.Set("dev", "vpc", "subnets", []any{"a", "b"}))
+
+		copied, err := copyStoreKeys(src, dst, "dev", "vpc", "prod", "vpc", nil)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"cidr", "subnets"}, copied)
+
+		value, err := dst.Get("prod", "vpc", "cidr")
+		require.NoError(t, err)
+		assert.Equal(t, "10.0.0.0/16", value)
+
+		value, err = dst.Get("prod", "vpc", "subnets")
+		require.NoError(t, err)
+		assert.Equal(t, []any{"a", "b"}, value)
+	})
+
+	t.Run("copy selected keys", func(t *testing.T) {
+		src := newTestRedisStore(t)
+		dst := newTestRedisStore(t)
+
+		require.NoError(t, src.Set("dev", "vpc", "cidr", "10.0.0.0/16"))
+		require.NoError(t, src.Set("dev", "vpc", "id", "vpc-123"))
+
+		copied, err := copyStoreKeys(src, dst, "dev", "vpc", "dev", "vpc", []string{"id"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"id"}, copied)
+
+		exists, err := dst.Exists("dev", "vpc", "cidr")
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		src := newTestRedisStore(t)
+		dst := newTestRedisStore(t)
+
+		_, err := copyStoreKeys(src, dst, "dev", "vpc", "dev", "vpc", []string{"missing"})
+		assert.Error(t, err)
+	})
+
+	t.Run("no keys", func(t *testing.T) {
+		src := newTestRedisStore(t)
+		dst := newTestRedisStore(t)
+
+		_, err := copyStoreKeys(src, dst, "dev", "vpc", "dev", "vpc", nil)
+		assert.ErrorIs(t, err, errUtils.ErrNoStoreKeysToCopy)
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"slices"
 	"text/template"
 	"text/template/parse"
 	"time"
@@ -30,12 +31,25 @@ func ProcessTmpl(
 	tmplValue string,
 	tmplData any,
 	ignoreMissingTemplateValues bool,
+) (string, error) {
+	return ProcessTmplWithConfig(&schema.AtmosConfiguration{}, &schema.ConfigAndStacksInfo{}, tmplName, tmplValue, tmplData, ignoreMissingTemplateValues)
+}
+
+// ProcessTmplWithConfig parses and executes Go templates like ProcessTmpl, with the Atmos template functions
+// (e.g. `atmos.Store` and `atmos.Component`) using the CLI config and the component info.
+func ProcessTmplWithConfig(
+	atmosConfig *schema.AtmosConfiguration,
+	configAndStacksInfo *schema.ConfigAndStacksInfo,
+	tmplName string,
+	tmplValue string,
+	tmplData any,
+	ignoreMissingTemplateValues bool,
 ) (string, error) {
 	d := data.Data{}
 	ctx := context.TODO()
 
 	// Add Gomplate, Sprig and Atmos template functions
-	funcs := lo.Assign(gomplate.CreateFuncs(ctx, &d), sprig.FuncMap(), FuncMap(&schema.AtmosConfiguration{}, &schema.ConfigAndStacksInfo{}, ctx, &d))
+	funcs := lo.Assign(gomplate.CreateFuncs(ctx, &d), sprig.FuncMap(), FuncMap(atmosConfig, configAndStacksInfo, ctx, &d))
 
 	t, err := template.New(tmplName).Funcs(funcs).Parse(tmplValue)
 	if err != nil {
@@ -259,6 +273,119 @@ func IsGolangTemplate(str string) (bool, error) {
 	return isGoTemplate, nil
 }
 
+// GetTemplateFields returns the fields of the template data that a parsed Go template references, as paths from the
+// root of the data (e.g. `.steps.plan.status` is `[steps plan status]`, and `$.env.CI` is `[env CI]`).
+// The keys of the `index` function with string literal arguments are included (e.g. `index . "plan"` is `[plan]`).
+// The fields relative to the dot changed by `with` and `range` are returned as if they were relative to the root.
+func GetTemplateFields(t *template.Template) [][]string {
+	var fields [][]string
+	for _, tmpl := range t.Templates() {
+		if tmpl.Tree != nil {
+			fields = appendTemplateNodeFields(fields, tmpl.Tree.Root)
+		}
+	}
+	return fields
+}
+
+func appendTemplateNodeFields(fields [][]string, node parse.Node) [][]string {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n != nil {
+			for _, child := range n.Nodes {
+				fields = appendTemplateNodeFields(fields, child)
+			}
+		}
+	case *parse.ActionNode:
+		fields = appendTemplateNodeFields(fields, n.Pipe)
+	case *parse.PipeNode:
+		if n != nil {
+			for _, cmd := range n.Cmds {
+				fields = appendTemplateNodeFields(fields, cmd)
+			}
+		}
+	case *parse.CommandNode:
+		if path, ok := getTemplateIndexField(n); ok {
+			fields = append(fields, path)
+		}
+		for _, arg := range n.Args {
+			fields = appendTemplateNodeFields(fields, arg)
+		}
+	case *parse.FieldNode:
+		fields = append(fields, n.Ident)
+	case *parse.VariableNode:
+		// Only the fields of the root of the data (`$`) are returned
+		if len(n.Ident) > 1 && n.Ident[0] == "$" {
+			fields = append(fields, n.Ident[1:])
+		}
+	case *parse.ChainNode:
+		if path, ok := getTemplateNodeField(n.Node); ok {
+			fields = append(fields, append(path, n.Field...))
+		}
+		fields = appendTemplateNodeFields(fields, n.Node)
+	case *parse.IfNode:
+		fields = appendTemplateBranchFields(fields, &n.BranchNode)
+	case *parse.RangeNode:
+		fields = appendTemplateBranchFields(fields, &n.BranchNode)
+	case *parse.WithNode:
+		fields = appendTemplateBranchFields(fields, &n.BranchNode)
+	case *parse.TemplateNode:
+		fields = appendTemplateNodeFields(fields, n.Pipe)
+	}
+	return fields
+}
+
+func appendTemplateBranchFields(fields [][]string, n *parse.BranchNode) [][]string {
+	fields = appendTemplateNodeFields(fields, n.Pipe)
+	fields = appendTemplateNodeFields(fields, n.List)
+	return appendTemplateNodeFields(fields, n.ElseList)
+}
+
+// getTemplateNodeField returns the path of the field of the template data that a node evaluates to.
+func getTemplateNodeField(node parse.Node) ([]string, bool) {
+	switch n := node.(type) {
+	case *parse.DotNode:
+		return []string{}, true
+	case *parse.FieldNode:
+		return slices.Clone(n.Ident), true
+	case *parse.VariableNode:
+		if len(n.Ident) > 0 && n.Ident[0] == "$" {
+			return slices.Clone(n.Ident[1:]), true
+		}
+	case *parse.PipeNode:
+		if n == nil || len(n.Cmds) != 1 {
+			break
+		}
+		if len(n.Cmds[0].Args) == 1 {
+			return getTemplateNodeField(n.Cmds[0].Args[0])
+		}
+		return getTemplateIndexField(n.Cmds[0])
+	}
+	return nil, false
+}
+
+// getTemplateIndexField returns the path of the field of the template data that an `index` function call with
+// string literal keys evaluates to (e.g. `index .steps "plan"`).
+func getTemplateIndexField(cmd *parse.CommandNode) ([]string, bool) {
+	if len(cmd.Args) < 3 {
+		return nil, false
+	}
+	if ident, ok := cmd.Args[0].(*parse.IdentifierNode); !ok || ident.Ident != "index" {
+		return nil, false
+	}
+	path, ok := getTemplateNodeField(cmd.Args[1])
+	if !ok {
+		return nil, false
+	}
+	for _, arg := range cmd.Args[2:] {
+		key, ok := arg.(*parse.StringNode)
+		if !ok {
+			return nil, false
+		}
+		path = append(path, key.Text)
+	}
+	return path, true
+}
+
 // Create temporary directory.
 func createTempDirectory() (string, error) {
 	// Create a temporary directory for the temporary files.
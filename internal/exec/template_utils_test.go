@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"os"
 	"runtime"
+	"slices"
 	"strings"
 	"testing"
+	"text/template"
 )
 
 // TestCreateTempDirectory verifies that a temporary directory is created with the expected permissions.
@@ -157,3 +159,26 @@ func TestProcessTmplWithDatasourcesGomplate(t *testing.T) {
 		t.Errorf("Expected result to be %q, got %q", expected, result)
 	}
 }
+
+// TestGetTemplateFields verifies that the fields referenced by a template are returned as paths from the root of the data.
+func TestGetTemplateFields(t *testing.T) {
+	tmpl := `{{ if eq .env.CI "true" }}{{ $.inputs.env }}{{ end }}{{ (index . "plan").summary }}{{ index .steps "plan" "status" }}{{ $x := .a }}{{ $x.b }}`
+	parsed, err := template.New("test").Funcs(template.FuncMap{}).Parse(tmpl)
+	if err != nil {
+		t.Fatalf("failed to parse the template: %v", err)
+	}
+
+	var fields []string
+	for _, field := range GetTemplateFields(parsed) {
+		fields = append(fields, strings.Join(field, "."))
+	}
+
+	for _, expected := range []string{"env.CI", "inputs.env", "plan", "plan.summary", "steps.plan.status", "steps", "a"} {
+		if !slices.Contains(fields, expected) {
+			t.Errorf("expected the field %q in %v", expected, fields)
+		}
+	}
+	if slices.Contains(fields, "b") || slices.Contains(fields, "x.b") {
+		t.Errorf("the fields of the variables should not be returned: %v", fields)
+	}
+}
@@ -36,6 +36,11 @@ var (
 
 // ExecuteTerraform executes terraform commands.
 func ExecuteTerraform(info schema.ConfigAndStacksInfo) error {
+	return ExecuteTerraformWithHooks(info, nil)
+}
+
+// ExecuteTerraformWithHooks executes terraform commands, and runs the hooks of the component around the command.
+func ExecuteTerraformWithHooks(info schema.ConfigAndStacksInfo, hooks ComponentHooks) error {
 	info.CliArgs = []string{"terraform", info.SubCommand, info.SubCommand2}
 
 	atmosConfig, err := cfg.InitCliConfig(info, true)
@@ -85,7 +90,17 @@ func ExecuteTerraform(info schema.ConfigAndStacksInfo) error {
 		return nil
 	}
 
-	err = checkTerraformConfig(atmosConfig)
+	if hooks == nil || !shouldProcessStacks {
+		return executeTerraformComponent(atmosConfig, info)
+	}
+	return hooks(&atmosConfig, &info, func() error {
+		return executeTerraformComponent(atmosConfig, info)
+	})
+}
+
+// executeTerraformComponent executes a terraform command for the component processed from the stacks.
+func executeTerraformComponent(atmosConfig schema.AtmosConfiguration, info schema.ConfigAndStacksInfo) error {
+	err := checkTerraformConfig(atmosConfig)
 	if err != nil {
 		return err
 	}
@@ -229,7 +229,8 @@ func ExecuteTerraformGenerateBackends(
 				configAndStacksInfo.ComponentSection["atmos_manifest"] = stackFileName
 
 				// Process `Go` templates
-				componentSectionStr, err := u.ConvertToYAML(componentSection)
+				componentSectionWithoutHookTemplates, restoreHookTemplates := excludeHookTemplates(componentSection)
+				componentSectionStr, err := u.ConvertToYAML(componentSectionWithoutHookTemplates)
 				if err != nil {
 					return err
 				}
@@ -265,6 +266,8 @@ func ExecuteTerraformGenerateBackends(
 					errUtils.CheckErrorPrintAndExit(err, "", "")
 				}
 
+				restoreHookTemplates(componentSectionConverted)
+
 				componentSectionFinal, err := ProcessCustomYamlTags(&atmosConfig, componentSectionConverted, stackName, nil)
 				if err != nil {
 					return err
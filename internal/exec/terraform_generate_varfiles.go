@@ -238,7 +238,8 @@ func ExecuteTerraformGenerateVarfiles(
 				componentSection["atmos_manifest"] = stackFileName
 
 				// Process `Go` templates
-				componentSectionStr, err := u.ConvertToYAML(componentSection)
+				componentSectionWithoutHookTemplates, restoreHookTemplates := excludeHookTemplates(componentSection)
+				componentSectionStr, err := u.ConvertToYAML(componentSectionWithoutHookTemplates)
 				if err != nil {
 					return err
 				}
@@ -274,6 +275,8 @@ func ExecuteTerraformGenerateVarfiles(
 					errUtils.CheckErrorPrintAndExit(err, "", "")
 				}
 
+				restoreHookTemplates(componentSectionConverted)
+
 				componentSectionFinal, err := ProcessCustomYamlTags(&atmosConfig, componentSectionConverted, stackName, nil)
 				if err != nil {
 					return err
@@ -14,6 +14,7 @@ import (
 	"github.com/samber/lo"
 
 	errUtils "github.com/cloudposse/atmos/errors"
+	tb "github.com/cloudposse/atmos/internal/terraform_backend"
 	cfg "github.com/cloudposse/atmos/pkg/config"
 	"github.com/cloudposse/atmos/pkg/schema"
 	u "github.com/cloudposse/atmos/pkg/utils"
@@ -65,7 +66,20 @@ func execTerraformOutput(
 	stack string,
 	sections map[string]any,
 ) (map[string]any, error) {
+	outputs, _, err := execTerraformOutputs(atmosConfig, component, stack, sections)
+	return outputs, err
+}
+
+// execTerraformOutputs executes `terraform output` for a component in a stack, and returns the outputs
+// and the names of the outputs that are marked as sensitive.
+func execTerraformOutputs(
+	atmosConfig *schema.AtmosConfiguration,
+	component string,
+	stack string,
+	sections map[string]any,
+) (map[string]any, map[string]bool, error) {
 	outputProcessed := map[string]any{}
+	sensitiveOutputs := map[string]bool{}
 	componentAbstract := false
 	componentEnabled := true
 	var err error
@@ -90,27 +104,27 @@ func execTerraformOutput(
 	if componentEnabled && !componentAbstract {
 		executable, ok := sections[cfg.CommandSectionName].(string)
 		if !ok {
-			return nil, fmt.Errorf("the component '%s' in the stack '%s' does not have 'command' (executable) defined", component, stack)
+			return nil, nil, fmt.Errorf("the component '%s' in the stack '%s' does not have 'command' (executable) defined", component, stack)
 		}
 
 		terraformWorkspace, ok := sections[cfg.WorkspaceSectionName].(string)
 		if !ok {
-			return nil, fmt.Errorf("the component '%s' in the stack '%s' does not have Terraform/OpenTofu workspace defined", component, stack)
+			return nil, nil, fmt.Errorf("the component '%s' in the stack '%s' does not have Terraform/OpenTofu workspace defined", component, stack)
 		}
 
 		componentInfo, ok := sections["component_info"]
 		if !ok {
-			return nil, fmt.Errorf("the component '%s' in the stack '%s' does not have 'component_info' defined", component, stack)
+			return nil, nil, fmt.Errorf("the component '%s' in the stack '%s' does not have 'component_info' defined", component, stack)
 		}
 
 		componentInfoMap, ok := componentInfo.(map[string]any)
 		if !ok {
-			return nil, fmt.Errorf("the component '%s' in the stack '%s' has an invalid 'component_info' section", component, stack)
+			return nil, nil, fmt.Errorf("the component '%s' in the stack '%s' has an invalid 'component_info' section", component, stack)
 		}
 
 		componentPath, ok := componentInfoMap["component_path"].(string)
 		if !ok {
-			return nil, fmt.Errorf("the component '%s' in the stack '%s' has an invalid 'component_info.component_path' section", component, stack)
+			return nil, nil, fmt.Errorf("the component '%s' in the stack '%s' has an invalid 'component_info.component_path' section", component, stack)
 		}
 
 		// Auto-generate backend file
@@ -121,22 +135,22 @@ func execTerraformOutput(
 
 			backendTypeSection, ok := sections["backend_type"].(string)
 			if !ok {
-				return nil, fmt.Errorf("the component '%s' in the stack '%s' has an invalid 'backend_type' section", component, stack)
+				return nil, nil, fmt.Errorf("the component '%s' in the stack '%s' has an invalid 'backend_type' section", component, stack)
 			}
 
 			backendSection, ok := sections["backend"].(map[string]any)
 			if !ok {
-				return nil, fmt.Errorf("the component '%s' in the stack '%s' has an invalid 'backend' section", component, stack)
+				return nil, nil, fmt.Errorf("the component '%s' in the stack '%s' has an invalid 'backend' section", component, stack)
 			}
 
 			componentBackendConfig, err := generateComponentBackendConfig(backendTypeSection, backendSection, terraformWorkspace)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 
 			err = u.WriteToFileAsJSON(backendFileName, componentBackendConfig, 0o644)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 
 			log.Debug("Wrote backend config", "file", backendFileName)
@@ -153,7 +167,7 @@ func execTerraformOutput(
 			providerOverrides := generateComponentProviderOverrides(providersSection)
 			err = u.WriteToFileAsJSON(providerOverrideFileName, providerOverrides, 0o644)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 
 			log.Debug("Wrote provider overrides", "file", providerOverrideFileName)
@@ -162,7 +176,7 @@ func execTerraformOutput(
 		// Initialize Terraform/OpenTofu
 		tf, err := tfexec.NewTerraform(componentPath, executable)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		// Set environment variables from the `env` section
@@ -183,7 +197,7 @@ func execTerraformOutput(
 				// Set the environment variables in the process that executes the `tfexec` functions
 				err = tf.SetEnv(environMap)
 				if err != nil {
-					return nil, err
+					return nil, nil, err
 				}
 				log.Debug("Resolved final environment variables",
 					"environment", environMap,
@@ -212,7 +226,7 @@ func execTerraformOutput(
 		}
 		err = tf.Init(ctx, initOptions...)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		log.Debug("Executed terraform",
@@ -238,7 +252,7 @@ func execTerraformOutput(
 				)
 				err = tf.WorkspaceSelect(ctx, terraformWorkspace)
 				if err != nil {
-					return nil, err
+					return nil, nil, err
 				}
 				log.Debug("Successfully selected terraform workspace",
 					"command", fmt.Sprintf("terraform workspace select %s", terraformWorkspace),
@@ -262,7 +276,7 @@ func execTerraformOutput(
 		)
 		outputMeta, err := tf.Output(ctx)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		log.Debug("Executed terraform output command",
 			"command", fmt.Sprintf("terraform output %s -s %s", component, stack),
@@ -285,6 +299,9 @@ func execTerraformOutput(
 		}
 
 		outputProcessed = lo.MapEntries(outputMeta, func(k string, v tfexec.OutputMeta) (string, any) {
+			if v.Sensitive {
+				sensitiveOutputs[k] = true
+			}
 			s := string(v.Value)
 			log.Debug("Converting variable from JSON to Go data type",
 				"variable", k,
@@ -316,7 +333,7 @@ func execTerraformOutput(
 		)
 	}
 
-	return outputProcessed, nil
+	return outputProcessed, sensitiveOutputs, nil
 }
 
 // GetTerraformOutput retrieves a specified Terraform output variable for a given component within a stack.
@@ -381,12 +398,35 @@ func GetTerraformOutput(
 		terraformOutputsCache.Store(stackSlug, remoteStateBackendStaticTypeOutputs)
 		result = GetStaticRemoteStateOutput(atmosConfig, component, stack, remoteStateBackendStaticTypeOutputs, output)
 	} else {
-		// Execute `terraform output`
-		terraformOutputs, err := execTerraformOutput(atmosConfig, component, stack, sections)
+		// Use the persistent state cache if it's enabled, which is valid as long as the state of the component doesn't change
+		stateCache, err := tb.NewStateCache(atmosConfig, skipCache)
 		if err != nil {
 			u.PrintfMessageToTUI("\r✗ %s\n", message)
-			er := fmt.Errorf("failed to execute terraform output for the component %s in the stack %s. Error: %w", component, stack, err)
-			errUtils.CheckErrorPrintAndExit(er, "", "")
+			errUtils.CheckErrorPrintAndExit(err, "", "")
+		}
+
+		var cacheKey *tb.StateCacheKey
+		var terraformOutputs map[string]any
+		found := false
+		if stateCache != nil {
+			cacheKey = stateCache.Key(atmosConfig, &sections)
+			terraformOutputs, found = stateCache.Get(tb.StateCacheKindOutput, cacheKey)
+		}
+
+		if !found {
+			// Execute `terraform output`
+			terraformOutputs, err = execTerraformOutput(atmosConfig, component, stack, sections)
+			if err != nil {
+				u.PrintfMessageToTUI("\r✗ %s\n", message)
+				er := fmt.Errorf("failed to execute terraform output for the component %s in the stack %s. Error: %w", component, stack, err)
+				errUtils.CheckErrorPrintAndExit(er, "", "")
+			}
+
+			if stateCache != nil {
+				if err := stateCache.Set(tb.StateCacheKindOutput, cacheKey, terraformOutputs); err != nil {
+					log.Warn("Failed to write the persistent state cache", "error", err)
+				}
+			}
 		}
 
 		// Cache the result
@@ -398,6 +438,28 @@ func GetTerraformOutput(
 	return result
 }
 
+// GetAllTerraformOutputs executes `terraform output` once for a component in a stack, and returns all the outputs
+// and the names of the sensitive outputs. It bypasses the caches, so that it returns the outputs of the latest apply.
+func GetAllTerraformOutputs(
+	atmosConfig *schema.AtmosConfiguration,
+	component string,
+	stack string,
+) (map[string]any, map[string]bool, error) {
+	sections, err := ExecuteDescribeComponent(component, stack, true, true, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to describe the component %s in the stack %s. Error: %w", component, stack, err)
+	}
+
+	outputs, sensitiveOutputs, err := execTerraformOutputs(atmosConfig, component, stack, sections)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to execute terraform output for the component %s in the stack %s. Error: %w", component, stack, err)
+	}
+
+	// Refresh the outputs cached for `!terraform.output`
+	terraformOutputsCache.Store(fmt.Sprintf("%s-%s", stack, component), outputs)
+	return outputs, sensitiveOutputs, nil
+}
+
 func getTerraformOutputVariable(
 	atmosConfig *schema.AtmosConfiguration,
 	component string,
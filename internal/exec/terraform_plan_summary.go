@@ -0,0 +1,64 @@
+package exec
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/cloudposse/atmos/pkg/schema"
+)
+
+// TerraformPlanSummary is the number of resources that a Terraform plan adds, changes and destroys.
+type TerraformPlanSummary struct {
+	Add     int `json:"add"`
+	Change  int `json:"change"`
+	Destroy int `json:"destroy"`
+}
+
+// HasChanges returns true if the plan adds, changes or destroys any resources.
+func (s *TerraformPlanSummary) HasChanges() bool {
+	return s.Add > 0 || s.Change > 0 || s.Destroy > 0
+}
+
+// GetTerraformPlanSummary summarizes the resource changes in the planfile of a component using `terraform show -json`.
+func GetTerraformPlanSummary(
+	atmosConfig *schema.AtmosConfiguration,
+	info *schema.ConfigAndStacksInfo,
+	componentPath string,
+	planFile string,
+) (*TerraformPlanSummary, error) {
+	planJSON, err := getTerraformPlanJSON(atmosConfig, info, componentPath, planFile)
+	if err != nil {
+		return nil, err
+	}
+	return summarizeTerraformPlan(planJSON)
+}
+
+// summarizeTerraformPlan counts the resource changes in the JSON representation of a Terraform plan.
+// A replaced resource is counted as both added and destroyed, in the same way as `terraform plan` does.
+func summarizeTerraformPlan(planJSON string) (*TerraformPlanSummary, error) {
+	var plan struct {
+		ResourceChanges []struct {
+			Change struct {
+				Actions []string `json:"actions"`
+			} `json:"change"`
+		} `json:"resource_changes"`
+	}
+	if err := json.Unmarshal([]byte(planJSON), &plan); err != nil {
+		return nil, fmt.Errorf("error parsing the Terraform plan: %w", err)
+	}
+
+	summary := &TerraformPlanSummary{}
+	for _, resourceChange := range plan.ResourceChanges {
+		for _, action := range resourceChange.Change.Actions {
+			switch action {
+			case "create":
+				summary.Add++
+			case "update":
+				summary.Change++
+			case "delete":
+				summary.Destroy++
+			}
+		}
+	}
+	return summary, nil
+}
@@ -0,0 +1,34 @@
+package exec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarizeTerraformPlan(t *testing.T) {
+	planJSON := `{
+		"format_version": "1.2",
+		"resource_changes": [
+			{"address": "aws_vpc.this", "change": {"actions": ["create"]}},
+			{"address": "aws_subnet.a", "change": {"actions": ["update"]}},
+			{"address": "aws_subnet.b", "change": {"actions": ["delete", "create"]}},
+			{"address": "aws_route.a", "change": {"actions": ["delete"]}},
+			{"address": "aws_route.b", "change": {"actions": ["no-op"]}},
+			{"address": "data.aws_region.current", "change": {"actions": ["read"]}}
+		]
+	}`
+
+	summary, err := summarizeTerraformPlan(planJSON)
+	require.NoError(t, err)
+	assert.Equal(t, &TerraformPlanSummary{Add: 2, Change: 1, Destroy: 2}, summary)
+	assert.True(t, summary.HasChanges())
+
+	summary, err = summarizeTerraformPlan(`{"format_version": "1.2"}`)
+	require.NoError(t, err)
+	assert.False(t, summary.HasChanges())
+
+	_, err = summarizeTerraformPlan("not json")
+	assert.Error(t, err)
+}
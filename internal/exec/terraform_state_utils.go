@@ -72,8 +72,8 @@ func GetTerraformState(
 		return result, nil
 	}
 
-	// Read Terraform backend.
-	backend, err := tb.GetTerraformBackend(atmosConfig, &componentSections)
+	// Read Terraform backend (or the persistent state cache, if it's enabled).
+	backend, err := tb.GetCachedTerraformBackend(atmosConfig, &componentSections, skipCache)
 	if err != nil {
 		er := fmt.Errorf("%w for component `%s` in stack `%s`\nin YAML function: `%s`\n%v", errUtils.ErrReadTerraformState, component, stack, yamlFunc, err)
 		return nil, er
@@ -385,7 +385,8 @@ func ProcessStacks(
 
 	// Process `Go` templates in Atmos manifest sections
 	if processTemplates {
-		componentSectionStr, err := u.ConvertToYAML(configAndStacksInfo.ComponentSection)
+		componentSectionWithoutHookTemplates, restoreHookTemplates := excludeHookTemplates(configAndStacksInfo.ComponentSection)
+		componentSectionStr, err := u.ConvertToYAML(componentSectionWithoutHookTemplates)
 		if err != nil {
 			return configAndStacksInfo, err
 		}
@@ -423,6 +424,8 @@ func ProcessStacks(
 			errUtils.CheckErrorPrintAndExit(err, "", "")
 		}
 
+		restoreHookTemplates(componentSectionConverted)
+
 		configAndStacksInfo.ComponentSection = componentSectionConverted
 	}
 
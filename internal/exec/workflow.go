@@ -6,7 +6,10 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 
+	log "github.com/charmbracelet/log"
+	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 
 	errUtils "github.com/cloudposse/atmos/errors"
@@ -77,19 +80,7 @@ func ExecuteWorkflowCmd(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	var workflowPath string
-	if u.IsPathAbsolute(workflowFile) {
-		workflowPath = workflowFile
-	} else {
-		workflowPath = filepath.Join(atmosConfig.BasePath, atmosConfig.Workflows.BasePath, workflowFile)
-	}
-
-	// If the workflow file is specified without an extension, use the default extension
-	ext := filepath.Ext(workflowPath)
-	if ext == "" {
-		ext = u.DefaultStackConfigFileExtension
-		workflowPath = workflowPath + ext
-	}
+	workflowPath := getWorkflowManifestPath(&atmosConfig, workflowFile)
 
 	if !u.FileExists(workflowPath) {
 		errUtils.CheckErrorPrintAndExit(
@@ -142,10 +133,126 @@ func ExecuteWorkflowCmd(cmd *cobra.Command, args []string) error {
 		workflowDefinition = i
 	}
 
-	err = ExecuteWorkflow(atmosConfig, workflowName, workflowPath, &workflowDefinition, dryRun, commandLineStack, fromStep)
+	// The `--max-parallel` flag overrides the `max_parallel` attribute of the workflow
+	maxParallel, err := flags.GetInt("max-parallel")
+	if err != nil {
+		return err
+	}
+	if maxParallel > 0 {
+		workflowDefinition.MaxParallel = maxParallel
+	}
+
+	inputFlags, err := flags.GetStringArray("input")
+	if err != nil {
+		return err
+	}
+	inputValues, err := parseWorkflowInputFlags(inputFlags)
+	if err != nil {
+		return err
+	}
+
+	// The `--resume` flag resumes the last run of the workflow recorded in the journal from its failed steps,
+	// with the stack and the inputs of the run. The stack and the inputs specified on the command line override them
+	resume, err := flags.GetBool("resume")
+	if err != nil {
+		return err
+	}
+	if resume {
+		if fromStep != "" {
+			errUtils.CheckErrorPrintAndExit(
+				ErrWorkflowResume,
+				WorkflowErrTitle,
+				"\n## Explanation\nThe `--resume` and `--from-step` flags can't be used together.",
+			)
+			return ErrWorkflowResume
+		}
+
+		run, err := findWorkflowRunToResume(&atmosConfig, workflowName, workflowPath, commandLineStack)
+		if err != nil {
+			return err
+		}
+		var resumeSteps []string
+		if run != nil {
+			resumeSteps = getWorkflowRunResumeSteps(run)
+		}
+		if len(resumeSteps) == 0 {
+			explanation := fmt.Sprintf("No run of workflow `%s` was recorded in the workflow journal.", workflowName)
+			if run != nil {
+				explanation = fmt.Sprintf("The last run `%s` of workflow `%s` has no failed steps to resume.", run.ID, workflowName)
+			}
+			errUtils.CheckErrorPrintAndExit(ErrWorkflowResume, WorkflowErrTitle, "\n## Explanation\n"+explanation)
+			return ErrWorkflowResume
+		}
+
+		fromStep = strings.Join(resumeSteps, ",")
+		if commandLineStack == "" {
+			commandLineStack = run.Stack
+		}
+		inputValues = lo.Assign(run.Inputs, inputValues)
+		log.Info("Resuming workflow run", "workflow", workflowName, "run", run.ID, "steps", fromStep)
+	}
+
+	// The `--report-file` flag writes the report of the run, with the end of the output of each step
+	reportFile, err := flags.GetString("report-file")
+	if err != nil {
+		return err
+	}
+	reportFormat, err := flags.GetString("report-format")
+	if err != nil {
+		return err
+	}
+	if reportFile != "" {
+		if err := validateWorkflowReportFormat(reportFormat); err != nil {
+			return err
+		}
+	}
+
+	autoApproveGates, err := flags.GetBool("auto-approve-gates")
+	if err != nil {
+		return err
+	}
+
+	// The runs are recorded in the journal, except for the dry runs
+	call := newWorkflowCall(&atmosConfig, workflowPath, workflowName)
+	call.autoApproveGates = autoApproveGates
+	call.journal = newWorkflowJournal(&atmosConfig, workflowName, workflowPath, commandLineStack, fromStep, getWorkflowJournalInputs(workflowDefinition.Inputs, inputValues), dryRun)
+	if reportFile != "" {
+		call.journal.outputLines = workflowReportOutputLines
+	}
+
+	err = executeWorkflow(atmosConfig, workflowName, workflowPath, &workflowDefinition, dryRun, commandLineStack, fromStep, inputValues, call)
+
+	// The report is written when the workflow fails too, unless the workflow is invalid and its steps didn't start
+	if reportFile != "" && call.journal.started {
+		if reportErr := writeWorkflowReport(&call.journal.run, reportFile, reportFormat); reportErr != nil {
+			if err == nil {
+				return reportErr
+			}
+			log.Error("Failed to write the workflow run report", "file", reportFile, "error", reportErr)
+		} else {
+			log.Debug("Wrote the workflow run report", "file", reportFile, "format", reportFormat)
+		}
+	}
+
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// getWorkflowManifestPath returns the path of a workflow manifest file. A relative path is relative to `workflows.base_path`.
+// If the file is specified without an extension, the default extension is used.
+func getWorkflowManifestPath(atmosConfig *schema.AtmosConfiguration, workflowFile string) string {
+	var workflowPath string
+	if u.IsPathAbsolute(workflowFile) {
+		workflowPath = workflowFile
+	} else {
+		workflowPath = filepath.Join(atmosConfig.BasePath, atmosConfig.Workflows.BasePath, workflowFile)
+	}
+
+	if filepath.Ext(workflowPath) == "" {
+		workflowPath += u.DefaultStackConfigFileExtension
+	}
+	return workflowPath
+}
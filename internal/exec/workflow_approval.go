@@ -0,0 +1,174 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/user"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/huh"
+	log "github.com/charmbracelet/log"
+	"github.com/samber/lo"
+
+	errUtils "github.com/cloudposse/atmos/errors"
+	"github.com/cloudposse/atmos/pkg/schema"
+)
+
+// workflowApprovalOutputLines is the number of the last lines of the output of the previous steps shown by the steps of type `approval`.
+const workflowApprovalOutputLines = 20
+
+// workflowApprovalMu serializes the approval prompts of the steps that run concurrently.
+var workflowApprovalMu sync.Mutex
+
+// promptWorkflowApproval asks the user to approve a workflow step. It's a variable so that it can be replaced in tests.
+var promptWorkflowApproval = func(ctx context.Context, title string, message string) (bool, error) {
+	approved := false
+	form := huh.NewForm(huh.NewGroup(
+		huh.NewConfirm().
+			Title(title).
+			Description(message).
+			Affirmative("Approve").
+			Negative("Reject").
+			Value(&approved),
+	)).WithTheme(huh.ThemeCharm()).WithOutput(os.Stderr)
+	if err := form.RunWithContext(ctx); err != nil {
+		return false, err
+	}
+	return approved, nil
+}
+
+// isWorkflowApprovalPromptSupported returns true if the steps of type `approval` can ask the user for approval in the terminal.
+// It's a variable so that it can be replaced in tests.
+var isWorkflowApprovalPromptSupported = isWorkflowInputPromptSupported
+
+// getWorkflowApprover returns the name of the user who approves the workflow steps.
+func getWorkflowApprover() string {
+	if current, err := user.Current(); err == nil && current.Username != "" {
+		return current.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return "unknown"
+}
+
+// getWorkflowApprovalOutputSteps returns the steps whose output is shown by the steps of type `approval` with `show_output`:
+// the steps that the approval step needs, or the previous step in a sequential workflow.
+func getWorkflowApprovalOutputSteps(steps []schema.WorkflowStep, graph *workflowGraph) map[string][]string {
+	result := map[string][]string{}
+	for i, step := range steps {
+		if getWorkflowStepType(step) != "approval" || !step.ShowOutput {
+			continue
+		}
+		result[step.Name] = lo.Map(graph.needs[i], func(need int, _ int) string { return steps[need].Name })
+	}
+	return result
+}
+
+// checkWorkflowApprovalSupported returns an error if the workflow has steps of type `approval` that can't be approved,
+// because the terminal is not interactive and the `--auto-approve-gates` flag is not set. The steps with an `if` condition
+// are checked when they run, since they might be skipped.
+func checkWorkflowApprovalSupported(steps []schema.WorkflowStep, dryRun bool, call *workflowCall) error {
+	if dryRun || call.autoApproveGates || isWorkflowApprovalPromptSupported() {
+		return nil
+	}
+
+	names := lo.FilterMap(steps, func(step schema.WorkflowStep, _ int) (string, bool) {
+		return call.stepName(step.Name), getWorkflowStepType(step) == "approval" && strings.TrimSpace(step.If) == ""
+	})
+	if len(names) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: the steps `%s` require approval, but the terminal is not interactive. Use the `--auto-approve-gates` flag to approve them",
+		ErrWorkflowStepNotApproved, strings.Join(names, "`, `"))
+}
+
+// executeWorkflowApprovalStep executes a step of type `approval`. The `command` of the step is the message shown to the user,
+// followed by the end of the output of the previous steps if the step has `show_output`. The workflow continues if the user
+// approves the step, and fails if the user rejects it.
+func executeWorkflowApprovalStep(ctx context.Context, step schema.WorkflowStep, dryRun bool, streams ShellIO, state *workflowState) error {
+	name := state.call.stepName(step.Name)
+	message := strings.TrimSpace(step.Command)
+	for _, previous := range state.approvalOutputSteps[step.Name] {
+		if output := strings.TrimRight(state.stepOutput(previous), "\n"); output != "" {
+			message = fmt.Sprintf("%s\n\nOutput of the step `%s`:\n%s", message, previous, output)
+		}
+	}
+
+	if dryRun {
+		_, _ = fmt.Fprintf(streams.Stderr, "Approval required: %s\n", message)
+		return nil
+	}
+
+	approver := getWorkflowApprover()
+
+	if state.call.autoApproveGates {
+		_, _ = fmt.Fprintf(streams.Stderr, "Approval required: %s\n", message)
+		log.Info("Workflow step approved by the --auto-approve-gates flag", "name", name, "user", approver)
+		state.approved(step.Name, approver, true)
+		return nil
+	}
+
+	if !isWorkflowApprovalPromptSupported() {
+		return fmt.Errorf("%w: the step `%s` requires approval, but the terminal is not interactive. Use the `--auto-approve-gates` flag to approve it",
+			ErrWorkflowStepNotApproved, name)
+	}
+
+	workflowApprovalMu.Lock()
+	approved, err := promptWorkflowApproval(ctx, fmt.Sprintf("Approve the workflow step `%s`?", name), message)
+	workflowApprovalMu.Unlock()
+	if err != nil {
+		return fmt.Errorf("%w: the step `%s`: %v", ErrWorkflowStepNotApproved, name, err)
+	}
+	if !approved {
+		log.Info("Workflow step rejected", "name", name, "user", approver)
+		return fmt.Errorf("%w: the step `%s` was rejected by `%s`", ErrWorkflowStepNotApproved, name, approver)
+	}
+
+	log.Info("Workflow step approved", "name", name, "user", approver)
+	state.approved(step.Name, approver, false)
+	return nil
+}
+
+// setStepOutput records the end of the output of a step shown by the steps of type `approval`.
+func (s *workflowState) setStepOutput(step string, output string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stepOutputs[step] = output
+}
+
+func (s *workflowState) stepOutput(step string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.stepOutputs[step]
+}
+
+// capturesStepOutput returns true if the output of a step is shown by a step of type `approval`.
+func (s *workflowState) capturesStepOutput(step string) bool {
+	return lo.SomeBy(lo.Values(s.approvalOutputSteps), func(steps []string) bool { return lo.Contains(steps, step) })
+}
+
+// approved records the user who approved a step of type `approval`. The templates of the next steps can reference the user
+// as `{{ .steps.<name>.approved_by }}`.
+func (s *workflowState) approved(step string, approver string, autoApproved bool) {
+	s.setStepData(step, "approved_by", approver)
+	s.call.journal.approved(step, approver, autoApproved)
+}
+
+// checkWorkflowApprovals checks that the steps of type `approval` to execute can be approved, and prints the error
+// if they can't. The workflow fails before running any step if its steps can't be approved.
+func checkWorkflowApprovals(workflow string, steps []schema.WorkflowStep, dryRun bool, call *workflowCall) error {
+	if err := checkWorkflowApprovalSupported(steps, dryRun, call); err != nil {
+		errUtils.CheckErrorAndPrint(
+			ErrWorkflowStepNotApproved,
+			WorkflowErrTitle,
+			fmt.Sprintf("\n## Explanation\nWorkflow `%s` can't be executed: %s", workflow, strings.TrimPrefix(err.Error(), ErrWorkflowStepNotApproved.Error()+": ")),
+		)
+		return ErrWorkflowStepNotApproved
+	}
+	return nil
+}
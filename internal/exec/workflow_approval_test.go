@@ -0,0 +1,101 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudposse/atmos/pkg/schema"
+)
+
+func TestExecuteWorkflow_Approval(t *testing.T) {
+	prompt := promptWorkflowApproval
+	supported := isWorkflowApprovalPromptSupported
+	t.Cleanup(func() {
+		promptWorkflowApproval = prompt
+		isWorkflowApprovalPromptSupported = supported
+	})
+
+	var messages []string
+	approve := true
+	promptWorkflowApproval = func(_ context.Context, _ string, message string) (bool, error) {
+		messages = append(messages, message)
+		return approve, nil
+	}
+	isWorkflowApprovalPromptSupported = func() bool { return true }
+
+	result := filepath.Join(t.TempDir(), "result")
+	workflowDefinition := &schema.WorkflowDefinition{
+		Steps: []schema.WorkflowStep{
+			{Name: "plan", Type: "shell", Command: "echo 'Plan: 1 to add, 0 to change, 0 to destroy.'"},
+			{Name: "approve", Type: "approval", Command: "Apply the plan?", ShowOutput: true},
+			{Name: "apply", Type: "shell", Command: fmt.Sprintf("echo {{ .steps.approve.approved_by }} > %s", result)},
+		},
+	}
+
+	err := ExecuteWorkflow(schema.AtmosConfiguration{}, "deploy", "workflows/test.yaml", workflowDefinition, false, "", "", nil)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, "Apply the plan?\n\nOutput of the step `plan`:\nPlan: 1 to add, 0 to change, 0 to destroy.", messages[0])
+	content, err := os.ReadFile(result)
+	require.NoError(t, err)
+	assert.Equal(t, getWorkflowApprover()+"\n", string(content))
+
+	// The workflow fails if the step is rejected
+	require.NoError(t, os.Remove(result))
+	approve = false
+	err = ExecuteWorkflow(schema.AtmosConfiguration{}, "deploy", "workflows/test.yaml", workflowDefinition, false, "", "", nil)
+	assert.ErrorIs(t, err, ErrWorkflowStepFailed)
+	assert.NoFileExists(t, result)
+
+	// The steps are not approved in dry-run mode
+	messages = nil
+	err = ExecuteWorkflow(schema.AtmosConfiguration{}, "deploy", "workflows/test.yaml", workflowDefinition, true, "", "", nil)
+	require.NoError(t, err)
+	assert.Empty(t, messages)
+}
+
+func TestExecuteWorkflow_ApprovalNonInteractive(t *testing.T) {
+	supported := isWorkflowApprovalPromptSupported
+	t.Cleanup(func() { isWorkflowApprovalPromptSupported = supported })
+	isWorkflowApprovalPromptSupported = func() bool { return false }
+
+	dir := t.TempDir()
+	marker := filepath.Join(dir, "marker")
+	atmosConfig := schema.AtmosConfiguration{
+		Workflows: schema.Workflows{Journal: schema.WorkflowJournal{Path: filepath.Join(dir, "journal")}},
+	}
+	workflowPath := filepath.Join(dir, "test.yaml")
+	workflowDefinition := &schema.WorkflowDefinition{
+		Steps: []schema.WorkflowStep{
+			{Name: "plan", Type: "shell", Command: fmt.Sprintf("touch %s", marker)},
+			{Name: "approve", Type: "approval", Command: "Apply the plan?"},
+		},
+	}
+
+	// The workflow fails before running any step
+	call := newWorkflowCall(&atmosConfig, workflowPath, "deploy")
+	err := executeWorkflow(atmosConfig, "deploy", workflowPath, workflowDefinition, false, "", "", nil, call)
+	assert.ErrorIs(t, err, ErrWorkflowStepNotApproved)
+	assert.NoFileExists(t, marker)
+
+	// The `--auto-approve-gates` flag approves the steps, and the approval is recorded in the journal
+	call.autoApproveGates = true
+	call.journal = newWorkflowJournal(&atmosConfig, "deploy", workflowPath, "", "", nil, false)
+	err = executeWorkflow(atmosConfig, "deploy", workflowPath, workflowDefinition, false, "", "", nil, call)
+	require.NoError(t, err)
+	assert.FileExists(t, marker)
+
+	run, err := findWorkflowRunToResume(&atmosConfig, "deploy", workflowPath, "")
+	require.NoError(t, err)
+	require.NotNil(t, run)
+	require.Len(t, run.Steps, 2)
+	assert.Equal(t, WorkflowRunStatusSucceeded, run.Steps[1].Status)
+	assert.Equal(t, getWorkflowApprover(), run.Steps[1].ApprovedBy)
+	assert.True(t, run.Steps[1].AutoApproved)
+}
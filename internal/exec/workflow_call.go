@@ -0,0 +1,302 @@
+package exec
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/samber/lo"
+
+	errUtils "github.com/cloudposse/atmos/errors"
+	"github.com/cloudposse/atmos/pkg/config"
+	"github.com/cloudposse/atmos/pkg/schema"
+	u "github.com/cloudposse/atmos/pkg/utils"
+)
+
+// workflowCall describes how a workflow is executed: by `atmos workflow`, or by a step of type `workflow` of another workflow.
+type workflowCall struct {
+	// chain are the workflows (`<file>:<workflow>`) that called the workflow, followed by the workflow itself
+	chain []string
+	// stepPath is the name of the calling step, prefixed with the names of the steps that called its workflow
+	// (e.g. `deploy/eks`). It's empty for the workflow executed by `atmos workflow`
+	stepPath string
+	// streams are the standard streams of the steps of the workflow
+	streams ShellIO
+	// journal records the run of the workflow executed by `atmos workflow`. It's nil for the called workflows
+	journal *workflowJournal
+	// autoApproveGates approves the steps of type `approval` without asking the user (`--auto-approve-gates`)
+	autoApproveGates bool
+}
+
+// newWorkflowCall returns the call of a workflow executed by `atmos workflow`, which uses the standard streams of the process.
+func newWorkflowCall(atmosConfig *schema.AtmosConfiguration, workflowPath string, workflow string) *workflowCall {
+	return &workflowCall{
+		chain:   []string{getWorkflowCallKey(atmosConfig, workflowPath, workflow)},
+		streams: ShellIO{Stdin: os.Stdin, Stdout: os.Stdout, Stderr: os.Stderr},
+	}
+}
+
+// stepName returns the name of a step of the workflow, prefixed with the names of the calling steps (e.g. `deploy/eks/vpc`).
+func (c *workflowCall) stepName(name string) string {
+	if c.stepPath == "" {
+		return name
+	}
+	return c.stepPath + "/" + name
+}
+
+// workflowFailure is the error of a workflow called by a step of type `workflow`. Instead of printing its own error message,
+// the called workflow returns the failed commands and the steps to resume it from, which the calling workflow includes in its message.
+type workflowFailure struct {
+	failedCommands []string
+	resumeSteps    []string
+	details        string
+}
+
+func (e *workflowFailure) Error() string {
+	return ErrWorkflowStepFailed.Error()
+}
+
+func (e *workflowFailure) Unwrap() error {
+	return ErrWorkflowStepFailed
+}
+
+// getWorkflowCallKey returns the key of a workflow in the call chains: the path of its manifest, relative to `workflows.base_path`,
+// and the name of the workflow.
+func getWorkflowCallKey(atmosConfig *schema.AtmosConfiguration, workflowPath string, workflow string) string {
+	return fmt.Sprintf("%s:%s", getWorkflowFile(atmosConfig, workflowPath), workflow)
+}
+
+// getWorkflowFile returns the path of a workflow manifest relative to `workflows.base_path`,
+// or the path itself if the manifest is not in `workflows.base_path`.
+func getWorkflowFile(atmosConfig *schema.AtmosConfiguration, workflowPath string) string {
+	workflowsDir := filepath.Join(atmosConfig.BasePath, atmosConfig.Workflows.BasePath)
+	if rel, err := filepath.Rel(workflowsDir, workflowPath); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.ToSlash(workflowPath)
+}
+
+// withWorkflowCallDefaults returns the steps, with the `file` of the steps of type `workflow` defaulting to the manifest
+// of the calling workflow.
+func withWorkflowCallDefaults(steps []schema.WorkflowStep, workflowFile string) []schema.WorkflowStep {
+	if !lo.SomeBy(steps, func(step schema.WorkflowStep) bool { return getWorkflowStepType(step) == "workflow" }) {
+		return steps
+	}
+
+	return lo.Map(steps, func(step schema.WorkflowStep, _ int) schema.WorkflowStep {
+		if getWorkflowStepType(step) == "workflow" && strings.TrimSpace(step.File) == "" {
+			step.File = workflowFile
+		}
+		return step
+	})
+}
+
+// loadWorkflowDefinition reads a workflow from a workflow manifest.
+func loadWorkflowDefinition(workflowPath string, workflow string) (schema.WorkflowDefinition, error) {
+	fileContent, err := os.ReadFile(workflowPath)
+	if err != nil {
+		return schema.WorkflowDefinition{}, fmt.Errorf("%w: `%s`", ErrWorkflowFileNotFound, filepath.ToSlash(workflowPath))
+	}
+
+	workflowManifest, err := u.UnmarshalYAML[schema.WorkflowManifest](string(fileContent))
+	if err != nil {
+		return schema.WorkflowDefinition{}, fmt.Errorf("%w: `%s`: %v", ErrInvalidWorkflowManifest, filepath.ToSlash(workflowPath), err)
+	}
+	if workflowManifest.Workflows == nil {
+		return schema.WorkflowDefinition{}, fmt.Errorf("%w: `%s` must be a map with the top-level `workflows:` key",
+			ErrInvalidWorkflowManifest, filepath.ToSlash(workflowPath))
+	}
+
+	workflowDefinition, ok := workflowManifest.Workflows[workflow]
+	if !ok {
+		return schema.WorkflowDefinition{}, fmt.Errorf("%w: `%s` in `%s`", ErrWorkflowNoWorkflow, workflow, filepath.ToSlash(workflowPath))
+	}
+	return workflowDefinition, nil
+}
+
+// getWorkflowCallStack returns the stack of the workflow called by a step of type `workflow`.
+// The stack defined on the command line overrides the `stack` of the step. If neither is set, the called workflow
+// uses its own stacks.
+func getWorkflowCallStack(step schema.WorkflowStep, commandLineStack string) string {
+	if commandLineStack != "" {
+		return commandLineStack
+	}
+	return strings.TrimSpace(step.Stack)
+}
+
+// getWorkflowCallCommand returns the command to execute the workflow called by a step of type `workflow`.
+func getWorkflowCallCommand(step schema.WorkflowStep, stack string) string {
+	command := fmt.Sprintf("%s workflow %s -f %s", config.AtmosCommand, strings.TrimSpace(step.Command), step.File)
+	if stack != "" {
+		command = fmt.Sprintf("%s -s %s", command, stack)
+	}
+	names := lo.Keys(step.Inputs)
+	sort.Strings(names)
+	for _, name := range names {
+		command = fmt.Sprintf("%s --input %s=%v", command, name, step.Inputs[name])
+	}
+	return command
+}
+
+// checkWorkflowCalls checks that the workflows called by the steps of type `workflow` of a workflow exist,
+// and that the workflows don't call each other in a cycle, directly or through other workflows.
+// The steps with templates in the `command` or the `file` can only be checked when they are executed.
+func checkWorkflowCalls(atmosConfig *schema.AtmosConfiguration, chain []string, workflowDefinition *schema.WorkflowDefinition) error {
+	checked := map[string]bool{}
+
+	var check func(chain []string, workflowDefinition *schema.WorkflowDefinition) error
+	check = func(chain []string, workflowDefinition *schema.WorkflowDefinition) error {
+		allSteps := append(append(append([]schema.WorkflowStep{}, workflowDefinition.Steps...), workflowDefinition.OnFailure...), workflowDefinition.Finally...)
+
+		for _, step := range allSteps {
+			if getWorkflowStepType(step) != "workflow" || strings.Contains(step.Command, "{{") || strings.Contains(step.File, "{{") {
+				continue
+			}
+
+			workflow := strings.TrimSpace(step.Command)
+			workflowPath := getWorkflowManifestPath(atmosConfig, step.File)
+			key := getWorkflowCallKey(atmosConfig, workflowPath, workflow)
+
+			if err := checkWorkflowCallCycle(chain, key); err != nil {
+				return err
+			}
+			if checked[key] {
+				continue
+			}
+
+			calledDefinition, err := loadWorkflowDefinition(workflowPath, workflow)
+			if err != nil {
+				return fmt.Errorf("%w: the step `%s` of workflow `%s` calls a workflow that can't be loaded: %v",
+					ErrInvalidWorkflowCall, step.Name, chain[len(chain)-1], err)
+			}
+
+			// The steps of type `workflow` without a `file` call the workflows in the same manifest
+			checkAndGenerateWorkflowStepNames(&calledDefinition)
+			calledDefinition.Steps = withWorkflowCallDefaults(calledDefinition.Steps, step.File)
+			calledDefinition.OnFailure = withWorkflowCallDefaults(calledDefinition.OnFailure, step.File)
+			calledDefinition.Finally = withWorkflowCallDefaults(calledDefinition.Finally, step.File)
+
+			if err := check(append(append([]string{}, chain...), key), &calledDefinition); err != nil {
+				return err
+			}
+			checked[key] = true
+		}
+		return nil
+	}
+
+	return check(chain, workflowDefinition)
+}
+
+// checkWorkflowCallCycle returns an error if the workflow is already in the call chain.
+func checkWorkflowCallCycle(chain []string, key string) error {
+	if start := lo.IndexOf(chain, key); start >= 0 {
+		cycle := append(append([]string{}, chain[start:]...), key)
+		return fmt.Errorf("%w: the workflows have a circular call: `%s`", ErrInvalidWorkflowCall, strings.Join(cycle, "` -> `"))
+	}
+	return nil
+}
+
+// executeCalledWorkflow executes the workflow called by a step of type `workflow`.
+// The called workflow uses the standard streams of the step, and its steps are named after the calling step
+// (e.g. the step `vpc` of the workflow called by the step `deploy` is named `deploy/vpc`).
+func executeCalledWorkflow(
+	atmosConfig schema.AtmosConfiguration,
+	step schema.WorkflowStep,
+	dryRun bool,
+	commandLineStack string,
+	streams ShellIO,
+	state *workflowState,
+) error {
+	workflow := strings.TrimSpace(step.Command)
+	workflowPath := getWorkflowManifestPath(&atmosConfig, step.File)
+	key := getWorkflowCallKey(&atmosConfig, workflowPath, workflow)
+
+	if err := checkWorkflowCallCycle(state.call.chain, key); err != nil {
+		return err
+	}
+
+	workflowDefinition, err := loadWorkflowDefinition(workflowPath, workflow)
+	if err != nil {
+		return err
+	}
+
+	stack := getWorkflowCallStack(step, commandLineStack)
+	inputs := make(map[string]string, len(step.Inputs))
+	for name, value := range step.Inputs {
+		inputs[name] = fmt.Sprintf("%v", value)
+	}
+
+	_, _ = fmt.Fprintf(streams.Stderr, "Executing workflow: `%s`\n", getWorkflowCallCommand(step, stack))
+
+	call := &workflowCall{
+		chain:            append(append([]string{}, state.call.chain...), key),
+		stepPath:         state.call.stepName(step.Name),
+		streams:          streams,
+		autoApproveGates: state.call.autoApproveGates,
+	}
+	fromStep := strings.Join(state.fromSteps[step.Name], ",")
+
+	return executeWorkflow(atmosConfig, workflow, workflowPath, &workflowDefinition, dryRun, stack, fromStep, inputs, call)
+}
+
+// getWorkflowCalls returns the workflows called by the steps of type `workflow` of a workflow, for `atmos describe workflows`.
+func getWorkflowCalls(workflowDefinition schema.WorkflowDefinition, workflowFile string) []schema.DescribeWorkflowsCall {
+	var calls []schema.DescribeWorkflowsCall
+
+	allSteps := append(append(append([]schema.WorkflowStep{}, workflowDefinition.Steps...), workflowDefinition.OnFailure...), workflowDefinition.Finally...)
+	for _, step := range withWorkflowCallDefaults(allSteps, workflowFile) {
+		if getWorkflowStepType(step) != "workflow" {
+			continue
+		}
+
+		file := strings.TrimSpace(step.File)
+		if filepath.Ext(file) == "" {
+			file += u.DefaultStackConfigFileExtension
+		}
+		calls = append(calls, schema.DescribeWorkflowsCall{
+			Step:     step.Name,
+			File:     file,
+			Workflow: strings.TrimSpace(step.Command),
+		})
+	}
+
+	return calls
+}
+
+// checkWorkflowStepCalls checks the steps of type `workflow` before any step runs, and prints the error if they are invalid.
+// The workflows called by other workflows are checked with the workflow executed by `atmos workflow`.
+func checkWorkflowStepCalls(
+	atmosConfig *schema.AtmosConfiguration,
+	workflow string,
+	call *workflowCall,
+	steps []schema.WorkflowStep,
+	onFailureSteps []schema.WorkflowStep,
+	finallySteps []schema.WorkflowStep,
+) error {
+	if call.stepPath != "" {
+		return nil
+	}
+	calls := &schema.WorkflowDefinition{Steps: steps, OnFailure: onFailureSteps, Finally: finallySteps}
+	if err := checkWorkflowCalls(atmosConfig, call.chain, calls); err != nil {
+		errUtils.CheckErrorAndPrint(
+			ErrInvalidWorkflowCall,
+			WorkflowErrTitle,
+			fmt.Sprintf("\n## Explanation\nThe steps of type `workflow` of workflow `%s` are invalid: %s", workflow, strings.TrimPrefix(err.Error(), ErrInvalidWorkflowCall.Error()+": ")),
+		)
+		return ErrInvalidWorkflowCall
+	}
+	return nil
+}
+
+// getCalledWorkflowFailure returns the failure of the workflow called by a failed step of type `workflow`,
+// or nil if the step is not of type `workflow`.
+func getCalledWorkflowFailure(err error) *workflowFailure {
+	var failure *workflowFailure
+	if errors.As(err, &failure) {
+		return failure
+	}
+	return nil
+}
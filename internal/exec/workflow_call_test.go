@@ -0,0 +1,210 @@
+package exec
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudposse/atmos/pkg/schema"
+)
+
+// setupWorkflowCallTest writes the workflow manifests to a temporary `workflows.base_path`, replacing `$DIR` with the
+// temporary directory, and returns the Atmos configuration and the directory where the steps of the workflows write their logs.
+func setupWorkflowCallTest(t *testing.T, manifests map[string]string) (schema.AtmosConfiguration, string) {
+	dir := t.TempDir()
+	workflowsDir := filepath.Join(dir, "workflows")
+	require.NoError(t, os.MkdirAll(workflowsDir, 0o755))
+
+	for file, content := range manifests {
+		require.NoError(t, os.WriteFile(filepath.Join(workflowsDir, file), []byte(strings.ReplaceAll(content, "$DIR", dir)), 0o644))
+	}
+
+	atmosConfig := schema.AtmosConfiguration{
+		BasePath:  dir,
+		Workflows: schema.Workflows{BasePath: "workflows"},
+	}
+	return atmosConfig, dir
+}
+
+func executeWorkflowCallTest(atmosConfig schema.AtmosConfiguration, file string, workflow string, fromStep string, inputs map[string]string) error {
+	workflowPath := getWorkflowManifestPath(&atmosConfig, file)
+	workflowDefinition, err := loadWorkflowDefinition(workflowPath, workflow)
+	if err != nil {
+		return err
+	}
+	return ExecuteWorkflow(atmosConfig, workflow, workflowPath, &workflowDefinition, false, "", fromStep, inputs)
+}
+
+func TestExecuteWorkflow_CallWorkflow(t *testing.T) {
+	atmosConfig, dir := setupWorkflowCallTest(t, map[string]string{
+		"platform.yaml": `
+workflows:
+  platform-up:
+    inputs:
+      environment:
+        default: dev
+    steps:
+      - name: init
+        type: shell
+        command: echo init >> $DIR/log
+      - name: network
+        type: workflow
+        command: network-up
+        file: network
+        inputs:
+          environment: "{{ .inputs.environment }}"
+      - name: done
+        type: shell
+        command: echo done >> $DIR/log
+`,
+		"network.yaml": `
+workflows:
+  network-up:
+    inputs:
+      environment: {}
+    steps:
+      - name: vpc
+        type: shell
+        command: echo vpc-{{ .inputs.environment }} >> $DIR/log
+      - name: dns
+        type: shell
+        command: if [ -f $DIR/fail ]; then exit 1; fi; echo dns >> $DIR/log
+`,
+	})
+	logFile := filepath.Join(dir, "log")
+
+	err := executeWorkflowCallTest(atmosConfig, "platform", "platform-up", "", map[string]string{"environment": "prod"})
+	require.NoError(t, err)
+	content, err := os.ReadFile(logFile)
+	require.NoError(t, err)
+	assert.Equal(t, "init\nvpc-prod\ndns\ndone\n", string(content))
+
+	// The workflow fails when a step of the called workflow fails
+	require.NoError(t, os.Remove(logFile))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "fail"), nil, 0o644))
+	err = executeWorkflowCallTest(atmosConfig, "platform", "platform-up", "", nil)
+	assert.ErrorIs(t, err, ErrWorkflowStepFailed)
+	content, err = os.ReadFile(logFile)
+	require.NoError(t, err)
+	assert.Equal(t, "init\nvpc-dev\n", string(content))
+
+	// The workflow is resumed from the failed step of the called workflow
+	require.NoError(t, os.Remove(logFile))
+	require.NoError(t, os.Remove(filepath.Join(dir, "fail")))
+	err = executeWorkflowCallTest(atmosConfig, "platform", "platform-up", "network/dns", nil)
+	require.NoError(t, err)
+	content, err = os.ReadFile(logFile)
+	require.NoError(t, err)
+	assert.Equal(t, "dns\ndone\n", string(content))
+
+	err = executeWorkflowCallTest(atmosConfig, "platform", "platform-up", "init/dns", nil)
+	assert.ErrorIs(t, err, ErrInvalidFromStep)
+}
+
+func TestExecuteWorkflow_CallWorkflowFailure(t *testing.T) {
+	atmosConfig, _ := setupWorkflowCallTest(t, map[string]string{
+		"platform.yaml": `
+workflows:
+  platform-up:
+    steps:
+      - name: network
+        type: workflow
+        command: network-up
+  network-up:
+    steps:
+      - name: vpc
+        type: shell
+        command: exit 1
+`,
+	})
+
+	workflowPath := getWorkflowManifestPath(&atmosConfig, "platform")
+	workflowDefinition, err := loadWorkflowDefinition(workflowPath, "platform-up")
+	require.NoError(t, err)
+
+	// The calling workflow gets the failed commands and the steps to resume from
+	call := &workflowCall{chain: []string{"platform.yaml:platform-up"}, stepPath: "platform", streams: ShellIO{Stdout: os.Stdout, Stderr: os.Stderr}}
+	err = executeWorkflow(atmosConfig, "platform-up", workflowPath, &workflowDefinition, false, "", "", nil, call)
+
+	var failure *workflowFailure
+	require.ErrorAs(t, err, &failure)
+	assert.Equal(t, []string{"exit 1"}, failure.failedCommands)
+	assert.Equal(t, []string{"network/vpc"}, failure.resumeSteps)
+}
+
+func TestCheckWorkflowCalls(t *testing.T) {
+	atmosConfig, _ := setupWorkflowCallTest(t, map[string]string{
+		"a.yaml": `
+workflows:
+  deploy:
+    steps:
+      - name: network
+        type: workflow
+        command: network
+        file: b
+      - name: templated
+        type: workflow
+        command: "{{ .inputs.workflow }}"
+  missing:
+    steps:
+      - type: workflow
+        command: idontexist
+`,
+		"b.yaml": `
+workflows:
+  network:
+    steps:
+      - name: dns
+        type: workflow
+        command: dns
+  dns:
+    finally:
+      - name: redeploy
+        type: workflow
+        command: deploy
+        file: a.yaml
+`,
+	})
+
+	load := func(file string, workflow string) *schema.WorkflowDefinition {
+		workflowDefinition, err := loadWorkflowDefinition(getWorkflowManifestPath(&atmosConfig, file), workflow)
+		require.NoError(t, err)
+		checkAndGenerateWorkflowStepNames(&workflowDefinition)
+		workflowDefinition.Steps = withWorkflowCallDefaults(workflowDefinition.Steps, file)
+		return &workflowDefinition
+	}
+
+	err := checkWorkflowCalls(&atmosConfig, []string{"a.yaml:deploy"}, load("a.yaml", "deploy"))
+	assert.ErrorIs(t, err, ErrInvalidWorkflowCall)
+	assert.ErrorContains(t, err, "circular call: `a.yaml:deploy` -> `b.yaml:network` -> `b.yaml:dns` -> `a.yaml:deploy`")
+
+	err = checkWorkflowCalls(&atmosConfig, []string{"a.yaml:missing"}, load("a.yaml", "missing"))
+	assert.ErrorIs(t, err, ErrInvalidWorkflowCall)
+	assert.ErrorContains(t, err, "the step `step1` of workflow `a.yaml:missing` calls a workflow that can't be loaded")
+
+	err = checkWorkflowCalls(&atmosConfig, []string{"b.yaml:dns"}, &schema.WorkflowDefinition{})
+	assert.NoError(t, err)
+
+	// The cycles are also detected when the workflows are executed
+	err = executeWorkflowCallTest(atmosConfig, "a", "deploy", "", nil)
+	assert.ErrorIs(t, err, ErrInvalidWorkflowCall)
+}
+
+func TestGetWorkflowCalls(t *testing.T) {
+	workflowDefinition := schema.WorkflowDefinition{
+		Steps: []schema.WorkflowStep{
+			{Name: "init", Type: "shell", Command: "echo init"},
+			{Name: "network", Type: "workflow", Command: "network-up", File: "network"},
+			{Name: "eks", Type: "workflow", Command: "eks-up"},
+		},
+	}
+
+	assert.Equal(t, []schema.DescribeWorkflowsCall{
+		{Step: "network", File: "network.yaml", Workflow: "network-up"},
+		{Step: "eks", File: "platform.yaml", Workflow: "eks-up"},
+	}, getWorkflowCalls(workflowDefinition, "platform.yaml"))
+}
@@ -0,0 +1,157 @@
+package exec
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig/v3"
+	log "github.com/charmbracelet/log"
+	"github.com/hairyhenderson/gomplate/v3"
+	"github.com/hairyhenderson/gomplate/v3/data"
+	"github.com/samber/lo"
+
+	"github.com/cloudposse/atmos/pkg/schema"
+)
+
+// errWorkflowStepSkipped is returned when a workflow step is skipped because its `if` condition is false.
+var errWorkflowStepSkipped = errors.New("workflow step skipped")
+
+// evaluateWorkflowStepCondition evaluates the `if` condition of a workflow step. A condition without `{{` is an expression
+// evaluated as a Go template action (e.g. `eq .inputs.environment "prod"`). The condition must evaluate to a boolean,
+// and an empty result is `false`.
+//
+// Besides the workflow inputs, the outputs of the previous steps and the `for_each` item, the condition can reference
+// the status of the steps (`.steps.plan.status`), the ENV variables (`.env.CI`, empty if not set), and the final stack of the step (`.stack`),
+// which can be used to get the configuration of a component in the stack (e.g. `(atmos.Component "vpc" .stack).vars.enabled`).
+func evaluateWorkflowStepCondition(
+	atmosConfig *schema.AtmosConfiguration,
+	step schema.WorkflowStep,
+	stack string,
+	templateData map[string]any,
+) (bool, error) {
+	condition := strings.TrimSpace(step.If)
+	if !strings.Contains(condition, "{{") {
+		condition = "{{ " + condition + " }}"
+	}
+
+	env := make(map[string]string)
+	for _, kv := range os.Environ() {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			env[k] = v
+		}
+	}
+	conditionData := lo.Assign(templateData, map[string]any{"env": env, "stack": stack})
+
+	d := data.Data{}
+	ctx := context.TODO()
+	funcs := lo.Assign(gomplate.CreateFuncs(ctx, &d), sprig.FuncMap(), FuncMap(atmosConfig, &schema.ConfigAndStacksInfo{}, ctx, &d))
+
+	t, err := template.New(fmt.Sprintf("workflow-step-%s-if", step.Name)).Funcs(funcs).Option("missingkey=error").Parse(condition)
+	if err != nil {
+		return false, fmt.Errorf("%w: the `if` condition of the step `%s`: %v", ErrWorkflowStepCondition, step.Name, err)
+	}
+
+	// The missing inputs and outputs are errors, but the ENV variables that are not set evaluate to an empty string
+	for _, field := range GetTemplateFields(t) {
+		if len(field) > 1 && field[0] == "env" {
+			if _, ok := env[field[1]]; !ok {
+				env[field[1]] = ""
+			}
+		}
+	}
+
+	var result bytes.Buffer
+	if err := t.Execute(&result, conditionData); err != nil {
+		return false, fmt.Errorf("%w: the `if` condition of the step `%s`: %v", ErrWorkflowStepCondition, step.Name, err)
+	}
+
+	value := strings.TrimSpace(result.String())
+	if value == "" {
+		return false, nil
+	}
+	ok, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%w: the `if` condition of the step `%s` must evaluate to `true` or `false`, but it evaluated to `%s`",
+			ErrWorkflowStepCondition, step.Name, value)
+	}
+	return ok, nil
+}
+
+// getWorkflowStepConditionStack returns the final stack of a workflow step for its `if` condition.
+// The templates in the `stack` of the step are processed, since the condition is evaluated before the step is rendered.
+func getWorkflowStepConditionStack(
+	workflowDefinition *schema.WorkflowDefinition,
+	step schema.WorkflowStep,
+	commandLineStack string,
+	templateData map[string]any,
+) (string, error) {
+	if strings.Contains(step.Stack, "{{") {
+		stack, err := ProcessTmpl(fmt.Sprintf("workflow-step-%s-stack", step.Name), step.Stack, templateData, false)
+		if err != nil {
+			return "", fmt.Errorf("%w: the `stack` of the step `%s`: %v", ErrWorkflowStepCondition, step.Name, err)
+		}
+		step.Stack = stack
+	}
+	return getWorkflowStepStack(workflowDefinition, step, commandLineStack), nil
+}
+
+// shouldRunWorkflowStep evaluates the `if` condition of a workflow step.
+func shouldRunWorkflowStep(
+	atmosConfig *schema.AtmosConfiguration,
+	workflowDefinition *schema.WorkflowDefinition,
+	step schema.WorkflowStep,
+	commandLineStack string,
+	state *workflowState,
+) (bool, error) {
+	templateData := state.templateData(step.Name)
+	stack, err := getWorkflowStepConditionStack(workflowDefinition, step, commandLineStack, templateData)
+	if err != nil {
+		return false, err
+	}
+	return evaluateWorkflowStepCondition(atmosConfig, step, stack, templateData)
+}
+
+// checkWorkflowStepCondition evaluates the `if` condition of a workflow step, if any.
+// It returns errWorkflowStepSkipped if the condition is false, or the error of the evaluation.
+// In dry-run mode, the steps don't have outputs, so the conditions referencing them can't be evaluated
+// and the step runs.
+func checkWorkflowStepCondition(
+	atmosConfig *schema.AtmosConfiguration,
+	workflowDefinition *schema.WorkflowDefinition,
+	step schema.WorkflowStep,
+	dryRun bool,
+	commandLineStack string,
+	state *workflowState,
+) error {
+	if strings.TrimSpace(step.If) == "" {
+		return nil
+	}
+
+	run, err := shouldRunWorkflowStep(atmosConfig, workflowDefinition, step, commandLineStack, state)
+	switch {
+	case err != nil && dryRun:
+		log.Warn("Failed to evaluate the workflow step condition in dry-run mode", "name", state.call.stepName(step.Name), "error", err)
+	case err != nil:
+		state.setStatus(step.Name, WorkflowRunStatusFailed)
+		return err
+	case !run:
+		log.Info("Skipping workflow step", "name", state.call.stepName(step.Name), "if", strings.TrimSpace(step.If))
+		state.setStatus(step.Name, WorkflowRunStatusSkipped)
+		return errWorkflowStepSkipped
+	}
+	return nil
+}
+
+// setPending sets the status of the steps to pending, since the `if` conditions of the steps can reference
+// the status of all the steps, including the steps that don't run.
+func (s *workflowState) setPending(steps []schema.WorkflowStep) {
+	for _, step := range steps {
+		s.setStatus(step.Name, WorkflowRunStatusPending)
+	}
+}
@@ -0,0 +1,108 @@
+package exec
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudposse/atmos/pkg/schema"
+)
+
+func TestEvaluateWorkflowStepCondition(t *testing.T) {
+	t.Setenv("ATMOS_TEST_CONDITION", "yes")
+
+	templateData := map[string]any{
+		"inputs": map[string]any{"environment": "prod", "force": true},
+		"steps":  map[string]any{"plan": map[string]any{"status": WorkflowRunStatusSucceeded}},
+	}
+
+	tests := []struct {
+		condition string
+		expected  bool
+	}{
+		{condition: `eq .inputs.environment "prod"`, expected: true},
+		{condition: `{{ ne .inputs.environment "prod" }}`, expected: false},
+		{condition: `.inputs.force`, expected: true},
+		{condition: `eq .env.ATMOS_TEST_CONDITION "yes"`, expected: true},
+		{condition: `eq (index .env "ATMOS_TEST_CONDITION_UNSET") "yes"`, expected: false},
+		{condition: `eq .env.ATMOS_TEST_CONDITION_UNSET "prod"`, expected: false},
+		{condition: `eq .env.ATMOS_TEST_CONDITION_UNSET ""`, expected: true},
+		{condition: `{{ if $.env.ATMOS_TEST_CONDITION_UNSET }}true{{ else }}false{{ end }}`, expected: false},
+		{condition: `eq .steps.plan.status "succeeded"`, expected: true},
+		{condition: `eq .stack "plat-ue2-prod"`, expected: true},
+		{condition: `{{ if eq .inputs.environment "dev" }}true{{ end }}`, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.condition, func(t *testing.T) {
+			ok, err := evaluateWorkflowStepCondition(&schema.AtmosConfiguration{}, schema.WorkflowStep{Name: "apply", If: tt.condition}, "plat-ue2-prod", templateData)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, ok)
+		})
+	}
+
+	_, err := evaluateWorkflowStepCondition(&schema.AtmosConfiguration{}, schema.WorkflowStep{Name: "apply", If: `.inputs.environment`}, "", templateData)
+	assert.ErrorIs(t, err, ErrWorkflowStepCondition)
+	assert.ErrorContains(t, err, "evaluated to `prod`")
+
+	_, err = evaluateWorkflowStepCondition(&schema.AtmosConfiguration{}, schema.WorkflowStep{Name: "apply", If: `.inputs.region`}, "", templateData)
+	assert.ErrorIs(t, err, ErrWorkflowStepCondition)
+}
+
+func TestCheckWorkflowStepCondition(t *testing.T) {
+	atmosConfig := &schema.AtmosConfiguration{}
+	workflowDefinition := &schema.WorkflowDefinition{}
+	state := newWorkflowState(workflowDefinition, map[string]any{"environment": "dev"}, nil)
+	state.call = &workflowCall{}
+	state.setPending([]schema.WorkflowStep{{Name: "plan"}, {Name: "apply"}})
+
+	err := checkWorkflowStepCondition(atmosConfig, workflowDefinition, schema.WorkflowStep{Name: "plan"}, false, "", state)
+	require.NoError(t, err)
+
+	err = checkWorkflowStepCondition(atmosConfig, workflowDefinition, schema.WorkflowStep{Name: "apply", If: `eq .steps.plan.status "pending"`}, false, "", state)
+	require.NoError(t, err)
+
+	err = checkWorkflowStepCondition(atmosConfig, workflowDefinition, schema.WorkflowStep{Name: "apply", If: `eq .inputs.environment "prod"`}, false, "", state)
+	assert.ErrorIs(t, err, errWorkflowStepSkipped)
+	assert.Equal(t, WorkflowRunStatusSkipped, state.templateData("")["steps"].(map[string]any)["apply"].(map[string]any)["status"])
+
+	// In dry-run mode, the step runs if its condition can't be evaluated
+	err = checkWorkflowStepCondition(atmosConfig, workflowDefinition, schema.WorkflowStep{Name: "apply", If: `.steps.plan.outputs.changes`}, true, "", state)
+	require.NoError(t, err)
+
+	err = checkWorkflowStepCondition(atmosConfig, workflowDefinition, schema.WorkflowStep{Name: "apply", If: `.steps.plan.outputs.changes`}, false, "", state)
+	assert.ErrorIs(t, err, ErrWorkflowStepCondition)
+	assert.Equal(t, WorkflowRunStatusFailed, state.templateData("")["steps"].(map[string]any)["apply"].(map[string]any)["status"])
+}
+
+func TestExecuteWorkflow_Conditions(t *testing.T) {
+	dir := t.TempDir()
+	result := filepath.Join(dir, "result")
+
+	workflowDefinition := &schema.WorkflowDefinition{
+		Inputs: map[string]schema.WorkflowInput{
+			"environment": {},
+		},
+		Steps: []schema.WorkflowStep{
+			{Name: "plan", Type: "shell", Command: fmt.Sprintf("echo plan >> %s", result)},
+			{Name: "approve", Type: "shell", Command: fmt.Sprintf("echo approve >> %s", result), If: `eq .inputs.environment "prod"`},
+			{Name: "apply", Type: "shell", Command: fmt.Sprintf("echo apply >> %s", result), If: `ne .steps.approve.status "failed"`},
+			{Name: "notify", Type: "shell", Command: fmt.Sprintf("echo {{ .steps.approve.status }} >> %s", result)},
+		},
+	}
+
+	err := ExecuteWorkflow(schema.AtmosConfiguration{}, "deploy", "workflows/test.yaml", workflowDefinition, false, "", "", map[string]string{"environment": "dev"})
+	require.NoError(t, err)
+	content, err := os.ReadFile(result)
+	require.NoError(t, err)
+	assert.Equal(t, "plan\napply\nskipped\n", string(content))
+
+	// The steps with invalid conditions fail
+	workflowDefinition.Steps[1].If = ".inputs.environment"
+	err = ExecuteWorkflow(schema.AtmosConfiguration{}, "deploy", "workflows/test.yaml", workflowDefinition, false, "", "", map[string]string{"environment": "dev"})
+	assert.ErrorIs(t, err, ErrWorkflowStepFailed)
+}
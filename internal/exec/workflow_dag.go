@@ -0,0 +1,388 @@
+package exec
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/samber/lo"
+
+	errUtils "github.com/cloudposse/atmos/errors"
+	"github.com/cloudposse/atmos/pkg/schema"
+)
+
+// workflowGraph holds the dependencies between the steps of a workflow. In a sequential workflow, each step depends on
+// the previous step. Otherwise, the steps only depend on the steps in their `needs`, and the steps whose dependencies
+// have completed can run concurrently.
+// The steps are identified by their index in the workflow definition.
+type workflowGraph struct {
+	steps []schema.WorkflowStep
+	// index maps the step names to the index of the first step with the name
+	index map[string]int
+	// needs are the steps that each step depends on
+	needs [][]int
+	// dependents are the steps that depend on each step
+	dependents [][]int
+	// parallel is true if the steps only depend on the steps in their `needs`
+	parallel bool
+}
+
+// newWorkflowGraph returns the dependency graph of the steps. The steps run sequentially unless parallel is `true`.
+// It returns an error if a step needs a step that doesn't exist, if the step names are not unique,
+// or if the dependencies have a cycle.
+func newWorkflowGraph(steps []schema.WorkflowStep, parallel bool) (*workflowGraph, error) {
+	g := &workflowGraph{
+		steps:      steps,
+		index:      make(map[string]int, len(steps)),
+		needs:      make([][]int, len(steps)),
+		dependents: make([][]int, len(steps)),
+		parallel:   parallel,
+	}
+
+	for i, step := range steps {
+		if _, ok := g.index[step.Name]; !ok {
+			g.index[step.Name] = i
+		}
+	}
+
+	if !g.parallel {
+		for i := 1; i < len(steps); i++ {
+			g.addDependency(i, i-1)
+		}
+		return g, nil
+	}
+
+	for i, step := range steps {
+		// The step names identify the steps in `needs`, so they must be unique
+		if g.index[step.Name] != i {
+			return nil, fmt.Errorf("%w: the step name `%s` is used by more than one step", ErrInvalidWorkflowStepNeeds, step.Name)
+		}
+		for _, need := range step.Needs {
+			n, ok := g.index[need]
+			if !ok {
+				return nil, fmt.Errorf("%w: the step `%s` needs the step `%s`, which does not exist", ErrInvalidWorkflowStepNeeds, step.Name, need)
+			}
+			g.addDependency(i, n)
+		}
+	}
+
+	if cycle := g.findCycle(); len(cycle) > 0 {
+		return nil, fmt.Errorf("%w: the steps have a circular dependency: `%s`", ErrInvalidWorkflowStepNeeds, strings.Join(cycle, "` -> `"))
+	}
+	return g, nil
+}
+
+func (g *workflowGraph) addDependency(step int, need int) {
+	g.needs[step] = append(g.needs[step], need)
+	g.dependents[need] = append(g.dependents[need], step)
+}
+
+// findCycle returns the names of the steps forming a dependency cycle, or nil if there are no cycles.
+func (g *workflowGraph) findCycle() []string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(g.steps))
+	var path []int
+	var cycle []string
+
+	var visit func(step int) bool
+	visit = func(step int) bool {
+		state[step] = visiting
+		path = append(path, step)
+		for _, need := range g.needs[step] {
+			switch state[need] {
+			case visiting:
+				start := 0
+				for i, s := range path {
+					if s == need {
+						start = i
+					}
+				}
+				for _, s := range path[start:] {
+					cycle = append(cycle, g.steps[s].Name)
+				}
+				cycle = append(cycle, g.steps[need].Name)
+				return true
+			case unvisited:
+				if visit(need) {
+					return true
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[step] = visited
+		return false
+	}
+
+	for i := range g.steps {
+		if state[i] == unvisited && visit(i) {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// descendants returns the steps and all the steps that depend on them, directly or transitively.
+func (g *workflowGraph) descendants(steps []int) map[int]bool {
+	result := map[int]bool{}
+	queue := append([]int{}, steps...)
+	for len(queue) > 0 {
+		step := queue[0]
+		queue = queue[1:]
+		if result[step] {
+			continue
+		}
+		result[step] = true
+		queue = append(queue, g.dependents[step]...)
+	}
+	return result
+}
+
+// workflowRun is the result of running the steps of a workflow graph.
+type workflowRun struct {
+	completed map[int]bool
+	// failed are the failed steps, in the order in which they failed
+	failed []int
+	errors map[int]error
+	// resumeSteps are the steps to resume the workflow from: the failed steps, and the steps that did not start
+	// although their dependencies had completed
+	resumeSteps []int
+}
+
+type workflowStepDone struct {
+	step int
+	err  error
+}
+
+// run runs the selected steps, starting each step after all its selected dependencies have completed, with at most
+// maxParallel steps running at the same time. After a step fails, no other steps are started, and run waits for the
+// running steps to complete.
+func (g *workflowGraph) run(selected map[int]bool, maxParallel int, runStep func(index int, step schema.WorkflowStep) error) *workflowRun {
+	r := &workflowRun{completed: map[int]bool{}, errors: map[int]error{}}
+	if maxParallel < 1 {
+		maxParallel = 1
+	}
+
+	// The number of selected dependencies of each step that have not completed yet
+	pending := make([]int, len(g.steps))
+	for step := range selected {
+		for _, need := range g.needs[step] {
+			if selected[need] {
+				pending[step]++
+			}
+		}
+	}
+
+	var ready []int
+	for i := range g.steps {
+		if selected[i] && pending[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	started := map[int]bool{}
+	done := make(chan workflowStepDone)
+	running := 0
+
+	for {
+		for len(r.failed) == 0 && running < maxParallel && len(ready) > 0 {
+			step := ready[0]
+			ready = ready[1:]
+			started[step] = true
+			running++
+
+			go func(step int) {
+				done <- workflowStepDone{step: step, err: runStep(step, g.steps[step])}
+			}(step)
+		}
+
+		if running == 0 {
+			break
+		}
+
+		result := <-done
+		running--
+
+		if result.err != nil {
+			r.failed = append(r.failed, result.step)
+			r.errors[result.step] = result.err
+			continue
+		}
+
+		r.completed[result.step] = true
+		for _, dependent := range g.dependents[result.step] {
+			if !selected[dependent] {
+				continue
+			}
+			pending[dependent]--
+			if pending[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+		// Start the ready steps in the order in which they are defined
+		sort.Ints(ready)
+	}
+
+	for i := range g.steps {
+		if r.errors[i] != nil || (selected[i] && !started[i] && pending[i] == 0) {
+			r.resumeSteps = append(r.resumeSteps, i)
+		}
+	}
+	return r
+}
+
+// buildWorkflowGraph returns the dependency graph of the steps of a workflow, and prints the error if the `needs` are invalid.
+func buildWorkflowGraph(workflow string, steps []schema.WorkflowStep, parallel bool) (*workflowGraph, error) {
+	graph, err := newWorkflowGraph(steps, parallel)
+	if err != nil {
+		errUtils.CheckErrorAndPrint(
+			ErrInvalidWorkflowStepNeeds,
+			WorkflowErrTitle,
+			fmt.Sprintf("\n## Explanation\nThe `needs` of the steps of workflow `%s` are invalid: %s", workflow, strings.TrimPrefix(err.Error(), ErrInvalidWorkflowStepNeeds.Error()+": ")),
+		)
+		return nil, ErrInvalidWorkflowStepNeeds
+	}
+	return graph, nil
+}
+
+// selectWorkflowSteps returns the steps to execute. If `--from-step` is specified, all the steps that the specified steps
+// (and the steps depending on them) don't need are skipped. Several steps can be specified as a comma-separated list.
+// The steps of the called workflows are specified with the names of the calling steps (e.g. `deploy/eks`), and are
+// returned by calling step.
+func selectWorkflowSteps(
+	workflow string,
+	steps []schema.WorkflowStep,
+	graph *workflowGraph,
+	expanded map[string][]string,
+	fromStep string,
+) (map[int]bool, map[string][]string, error) {
+	selected := map[int]bool{}
+	calledFromSteps := map[string][]string{}
+
+	if fromStep == "" {
+		for i := range steps {
+			selected[i] = true
+		}
+		return selected, calledFromSteps, nil
+	}
+
+	var fromSteps []int
+	for _, name := range strings.Split(fromStep, ",") {
+		name = strings.TrimSpace(name)
+		// The name of a step with `for_each` selects all its expanded steps
+		if names, ok := expanded[name]; ok {
+			for _, n := range names {
+				fromSteps = append(fromSteps, graph.index[n])
+			}
+			continue
+		}
+		index, ok := graph.index[name]
+		if !ok {
+			if stepName, calledStep, found := strings.Cut(name, "/"); found {
+				if index, ok = graph.index[stepName]; ok && getWorkflowStepType(steps[index]) == "workflow" {
+					calledFromSteps[stepName] = append(calledFromSteps[stepName], calledStep)
+				} else {
+					ok = false
+				}
+			}
+		}
+		if !ok {
+			stepNames := lo.Map(steps, func(step schema.WorkflowStep, _ int) string { return step.Name })
+			errUtils.CheckErrorAndPrint(
+				ErrInvalidFromStep,
+				WorkflowErrTitle,
+				fmt.Sprintf("\n## Explanation\nThe `--from-step` flag was set to `%s`, but this step does not exist in workflow `%s`. \n### Available steps:\n%s", name, workflow, FormatList(stepNames)),
+			)
+			return nil, nil, ErrInvalidFromStep
+		}
+		fromSteps = append(fromSteps, index)
+	}
+
+	return graph.descendants(fromSteps), calledFromSteps, nil
+}
+
+// getWorkflowMaxParallel returns the maximum number of steps of a workflow that run concurrently.
+func getWorkflowMaxParallel(workflowDefinition *schema.WorkflowDefinition, graph *workflowGraph, parallel bool) int {
+	if !graph.parallel {
+		return 1
+	}
+	maxParallel := workflowDefinition.MaxParallel
+	if maxParallel <= 0 {
+		// In a sequential workflow, the expanded steps of a `for_each` step run one at a time unless `max_parallel` is set
+		maxParallel = lo.Ternary(parallel, runtime.NumCPU(), 1)
+	}
+	return maxParallel
+}
+
+// executeWorkflowGraph executes the selected steps of a workflow in the order of their dependencies.
+// It returns the run of the steps, and the attempts to execute each step, to show the retry history when the workflow fails.
+func executeWorkflowGraph(
+	atmosConfig schema.AtmosConfiguration,
+	workflow string,
+	workflowDefinition *schema.WorkflowDefinition,
+	graph *workflowGraph,
+	selected map[int]bool,
+	maxParallel int,
+	dryRun bool,
+	commandLineStack string,
+	state *workflowState,
+) (*workflowRun, map[int][]workflowStepAttempt) {
+	call := state.call
+
+	// When the steps run concurrently, the lines of their output are prefixed with the step names,
+	// and the steps can't read from the terminal
+	var outputMu sync.Mutex
+	prefixOutput := maxParallel > 1 && len(selected) > 1
+
+	var attemptsMu sync.Mutex
+	stepAttempts := map[int][]workflowStepAttempt{}
+
+	run := graph.run(selected, maxParallel, func(stepIdx int, step schema.WorkflowStep) error {
+		streams := call.streams
+		if prefixOutput {
+			var flush func()
+			streams, flush = prefixWorkflowStepOutput(&outputMu, call.streams, step.Name)
+			defer flush()
+		}
+
+		// The end of the output of the step is captured for the workflow run report
+		output := call.journal.outputWriter()
+		streams = teeWorkflowStepOutput(streams, output)
+
+		// The end of the output of the step is captured for the steps of type `approval` that show it
+		var approvalOutput *tailWriter
+		if state.capturesStepOutput(step.Name) {
+			approvalOutput = newTailWriter(workflowApprovalOutputLines)
+			streams = teeWorkflowStepOutput(streams, approvalOutput)
+		}
+
+		call.journal.stepStarted(stepIdx)
+
+		commandName := fmt.Sprintf("%s-step-%d", workflow, stepIdx)
+		renderedStep, attempts, err := executeWorkflowStepWithPolicy(atmosConfig, commandName, workflowDefinition, step, dryRun, commandLineStack, streams, state)
+
+		call.journal.stepFinished(stepIdx, workflowDefinition, renderedStep, commandLineStack, attempts, err, output.String())
+		if approvalOutput != nil {
+			state.setStepOutput(step.Name, approvalOutput.String())
+		}
+
+		// The skipped steps complete, so that the steps depending on them run
+		if errors.Is(err, errWorkflowStepSkipped) {
+			return nil
+		}
+
+		attemptsMu.Lock()
+		stepAttempts[stepIdx] = attempts
+		attemptsMu.Unlock()
+
+		return err
+	})
+
+	return run, stepAttempts
+}
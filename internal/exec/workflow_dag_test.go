@@ -0,0 +1,222 @@
+package exec
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudposse/atmos/pkg/schema"
+)
+
+func allWorkflowSteps(g *workflowGraph) map[int]bool {
+	selected := map[int]bool{}
+	for i := range g.steps {
+		selected[i] = true
+	}
+	return selected
+}
+
+func TestNewWorkflowGraph(t *testing.T) {
+	// In a sequential workflow, each step depends on the previous step
+	g, err := newWorkflowGraph([]schema.WorkflowStep{{Name: "a"}, {Name: "b"}, {Name: "c"}}, false)
+	require.NoError(t, err)
+	assert.False(t, g.parallel)
+	assert.Equal(t, [][]int{nil, {0}, {1}}, g.needs)
+	assert.Equal(t, map[int]bool{1: true, 2: true}, g.descendants([]int{1}))
+
+	// In a sequential workflow, the step names don't need to be unique
+	_, err = newWorkflowGraph([]schema.WorkflowStep{{Name: "a"}, {Name: "a"}}, false)
+	require.NoError(t, err)
+
+	g, err = newWorkflowGraph([]schema.WorkflowStep{
+		{Name: "vpc"},
+		{Name: "dns"},
+		{Name: "eks", Needs: []string{"vpc"}},
+		{Name: "app", Needs: []string{"eks", "dns"}},
+	}, true)
+	require.NoError(t, err)
+	assert.True(t, g.parallel)
+	assert.Equal(t, map[int]bool{1: true, 3: true}, g.descendants([]int{1}))
+	assert.Equal(t, map[int]bool{0: true, 2: true, 3: true}, g.descendants([]int{0}))
+
+	tests := []struct {
+		name   string
+		steps  []schema.WorkflowStep
+		errMsg string
+	}{
+		{
+			name:   "unknown step",
+			steps:  []schema.WorkflowStep{{Name: "a", Needs: []string{"b"}}},
+			errMsg: "the step `a` needs the step `b`, which does not exist",
+		},
+		{
+			name:   "duplicate step names",
+			steps:  []schema.WorkflowStep{{Name: "a"}, {Name: "a", Needs: []string{"a"}}},
+			errMsg: "the step name `a` is used by more than one step",
+		},
+		{
+			name:   "self dependency",
+			steps:  []schema.WorkflowStep{{Name: "a", Needs: []string{"a"}}},
+			errMsg: "circular dependency: `a` -> `a`",
+		},
+		{
+			name: "cycle",
+			steps: []schema.WorkflowStep{
+				{Name: "a"},
+				{Name: "b", Needs: []string{"a", "d"}},
+				{Name: "c", Needs: []string{"b"}},
+				{Name: "d", Needs: []string{"c"}},
+			},
+			errMsg: "circular dependency: `b` -> `d` -> `c` -> `b`",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newWorkflowGraph(tt.steps, true)
+			assert.ErrorIs(t, err, ErrInvalidWorkflowStepNeeds)
+			assert.ErrorContains(t, err, tt.errMsg)
+		})
+	}
+}
+
+func TestWorkflowGraphRun(t *testing.T) {
+	g, err := newWorkflowGraph([]schema.WorkflowStep{
+		{Name: "vpc"},
+		{Name: "dns"},
+		{Name: "eks", Needs: []string{"vpc"}},
+		{Name: "app", Needs: []string{"eks", "dns"}},
+	}, true)
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var order []string
+	running, maxRunning := 0, 0
+
+	r := g.run(allWorkflowSteps(g), 2, func(_ int, step schema.WorkflowStep) error {
+		mu.Lock()
+		running++
+		maxRunning = max(maxRunning, running)
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		running--
+		order = append(order, step.Name)
+		mu.Unlock()
+		return nil
+	})
+
+	assert.Empty(t, r.failed)
+	assert.Len(t, r.completed, 4)
+	assert.Equal(t, 2, maxRunning)
+	assert.Equal(t, "app", order[3])
+	assert.Less(t, lo.IndexOf(order, "vpc"), lo.IndexOf(order, "eks"))
+}
+
+func TestWorkflowGraphRun_Failure(t *testing.T) {
+	g, err := newWorkflowGraph([]schema.WorkflowStep{
+		{Name: "vpc"},
+		{Name: "dns"},
+		{Name: "s3"},
+		{Name: "eks", Needs: []string{"vpc"}},
+		{Name: "app", Needs: []string{"eks", "dns"}},
+	}, true)
+	require.NoError(t, err)
+
+	// `vpc` fails while `dns` is running, so `s3` and `eks` are not started
+	r := g.run(allWorkflowSteps(g), 2, func(_ int, step schema.WorkflowStep) error {
+		if step.Name == "vpc" {
+			return errors.New("failed")
+		}
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	})
+
+	assert.Equal(t, []int{0}, r.failed)
+	assert.Equal(t, map[int]bool{1: true}, r.completed)
+	assert.Equal(t, []int{0, 2}, r.resumeSteps)
+
+	// A sequential workflow is resumed from the failed step
+	g, err = newWorkflowGraph([]schema.WorkflowStep{{Name: "a"}, {Name: "b"}, {Name: "c"}}, false)
+	require.NoError(t, err)
+
+	var ran []string
+	r = g.run(g.descendants([]int{1}), 1, func(_ int, step schema.WorkflowStep) error {
+		ran = append(ran, step.Name)
+		return errors.New("failed")
+	})
+	assert.Equal(t, []string{"b"}, ran)
+	assert.Equal(t, []int{1}, r.resumeSteps)
+}
+
+func TestPrefixWriter(t *testing.T) {
+	var mu sync.Mutex
+	var out bytes.Buffer
+
+	w := newPrefixWriter(&mu, &out, "[vpc] ")
+	_, err := w.Write([]byte("line 1\nline"))
+	require.NoError(t, err)
+	assert.Equal(t, "[vpc] line 1\n", out.String())
+
+	_, err = w.Write([]byte(" 2\n\nline 3"))
+	require.NoError(t, err)
+	require.NoError(t, w.Flush())
+	assert.Equal(t, "[vpc] line 1\n[vpc] line 2\n[vpc] \n[vpc] line 3\n", out.String())
+}
+
+func TestSelectWorkflowSteps(t *testing.T) {
+	steps := []schema.WorkflowStep{
+		{Name: "vpc"},
+		{Name: "deploy:a", Needs: []string{"vpc"}},
+		{Name: "deploy:b", Needs: []string{"vpc"}},
+		{Name: "eks", Type: "workflow", Needs: []string{"vpc"}},
+		{Name: "app", Needs: []string{"eks"}},
+	}
+	g, err := newWorkflowGraph(steps, true)
+	require.NoError(t, err)
+	expanded := map[string][]string{"deploy": {"deploy:a", "deploy:b"}}
+
+	selected, calledFromSteps, err := selectWorkflowSteps("test", steps, g, expanded, "")
+	require.NoError(t, err)
+	assert.Equal(t, allWorkflowSteps(g), selected)
+	assert.Empty(t, calledFromSteps)
+
+	// The name of a step with `for_each` selects all its expanded steps
+	selected, _, err = selectWorkflowSteps("test", steps, g, expanded, "deploy")
+	require.NoError(t, err)
+	assert.Equal(t, map[int]bool{1: true, 2: true}, selected)
+
+	// The steps of the called workflows are returned by calling step
+	selected, calledFromSteps, err = selectWorkflowSteps("test", steps, g, expanded, "eks/cluster, eks/nodes")
+	require.NoError(t, err)
+	assert.Equal(t, map[int]bool{3: true, 4: true}, selected)
+	assert.Equal(t, map[string][]string{"eks": {"cluster", "nodes"}}, calledFromSteps)
+
+	_, _, err = selectWorkflowSteps("test", steps, g, expanded, "vpc/subnets")
+	assert.ErrorIs(t, err, ErrInvalidFromStep)
+
+	_, _, err = selectWorkflowSteps("test", steps, g, expanded, "missing")
+	assert.ErrorIs(t, err, ErrInvalidFromStep)
+}
+
+func TestGetWorkflowMaxParallel(t *testing.T) {
+	steps := []schema.WorkflowStep{{Name: "a"}, {Name: "b"}}
+	sequential, err := newWorkflowGraph(steps, false)
+	require.NoError(t, err)
+	parallel, err := newWorkflowGraph(steps, true)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, getWorkflowMaxParallel(&schema.WorkflowDefinition{MaxParallel: 4}, sequential, false))
+	assert.Equal(t, 4, getWorkflowMaxParallel(&schema.WorkflowDefinition{MaxParallel: 4}, parallel, true))
+	// In a sequential workflow, the expanded steps of a `for_each` step run one at a time unless `max_parallel` is set
+	assert.Equal(t, 1, getWorkflowMaxParallel(&schema.WorkflowDefinition{}, parallel, false))
+	assert.Equal(t, 2, getWorkflowMaxParallel(&schema.WorkflowDefinition{MaxParallel: 2}, parallel, false))
+}
@@ -0,0 +1,257 @@
+package exec
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	log "github.com/charmbracelet/log"
+	"github.com/samber/lo"
+
+	errUtils "github.com/cloudposse/atmos/errors"
+	cfg "github.com/cloudposse/atmos/pkg/config"
+	"github.com/cloudposse/atmos/pkg/schema"
+	u "github.com/cloudposse/atmos/pkg/utils"
+)
+
+// workflowForEachItem is the `for_each` item of an expanded workflow step.
+type workflowForEachItem struct {
+	value any
+	index int
+	// stack is true if the item is a stack selected by the `stacks` filter or the `query`
+	stack bool
+}
+
+// workflowExpansion holds the steps of a workflow after the steps with `for_each` have been expanded.
+type workflowExpansion struct {
+	steps []schema.WorkflowStep
+	// items are the `for_each` items of the expanded steps, by step name
+	items map[string]workflowForEachItem
+	// expanded maps the names of the steps with `for_each` to the names of their expanded steps
+	expanded map[string][]string
+}
+
+// describeWorkflowStacks returns the stacks that the `stacks` filters and the queries of the `for_each` steps
+// are evaluated against. The stacks are described once, when the first step needs them.
+func describeWorkflowStacks(atmosConfig schema.AtmosConfiguration, commandLineStack string) func() (map[string]any, error) {
+	var stacks map[string]any
+	return func() (map[string]any, error) {
+		if stacks != nil {
+			return stacks, nil
+		}
+		var err error
+		stacks, err = ExecuteDescribeStacks(atmosConfig, commandLineStack, nil, []string{cfg.TerraformComponentType}, nil, false, true, false, false, nil)
+		return stacks, err
+	}
+}
+
+// expandWorkflowSteps expands each step with `for_each` into one step for each item, named `<step>:<item>`
+// (or `<step>:<index>` if the item is not a scalar value). The steps that need a step with `for_each` need all its
+// expanded steps. In a sequential workflow, the expanded steps of a step need all the expanded steps of the previous
+// step, so that they can run concurrently with each other.
+func expandWorkflowSteps(
+	atmosConfig *schema.AtmosConfiguration,
+	steps []schema.WorkflowStep,
+	sequential bool,
+	describeStacks func() (map[string]any, error),
+) (*workflowExpansion, error) {
+	e := &workflowExpansion{
+		items:    map[string]workflowForEachItem{},
+		expanded: map[string][]string{},
+	}
+
+	if !lo.SomeBy(steps, func(step schema.WorkflowStep) bool { return step.ForEach != nil }) {
+		e.steps = steps
+		return e, nil
+	}
+
+	var previous []string
+	for _, step := range steps {
+		if step.ForEach == nil {
+			if sequential {
+				step.Needs = previous
+			}
+			e.steps = append(e.steps, step)
+			previous = []string{step.Name}
+			continue
+		}
+
+		items, fromStacks, err := getWorkflowForEachItems(atmosConfig, step, describeStacks)
+		if err != nil {
+			return nil, err
+		}
+		if len(items) == 0 {
+			log.Warn("Skipping the workflow step because its `for_each` has no items", "step", step.Name)
+		}
+
+		names := make([]string, 0, len(items))
+		for i, item := range items {
+			expanded := step
+			expanded.ForEach = nil
+			expanded.Name = getWorkflowForEachStepName(step.Name, item, i)
+			if fromStacks && strings.TrimSpace(step.Stack) == "" {
+				expanded.Stack = fmt.Sprintf("%v", item)
+			}
+			if sequential {
+				expanded.Needs = previous
+			}
+
+			e.steps = append(e.steps, expanded)
+			e.items[expanded.Name] = workflowForEachItem{value: item, index: i, stack: fromStacks}
+			names = append(names, expanded.Name)
+		}
+		e.expanded[step.Name] = names
+
+		if len(names) > 0 {
+			previous = names
+		}
+	}
+
+	if !sequential {
+		for i, step := range e.steps {
+			e.steps[i].Needs = lo.FlatMap(step.Needs, func(need string, _ int) []string {
+				if names, ok := e.expanded[need]; ok {
+					return names
+				}
+				return []string{need}
+			})
+		}
+	}
+
+	log.Debug("Expanded the workflow steps", "steps", lo.Map(e.steps, func(step schema.WorkflowStep, _ int) string { return step.Name }))
+	return e, nil
+}
+
+// getWorkflowForEachStepName returns the name of the expanded step for an item.
+func getWorkflowForEachStepName(name string, item any, index int) string {
+	switch item.(type) {
+	case string, bool, int, int64, uint64, float64:
+		return fmt.Sprintf("%s:%v", name, item)
+	default:
+		return fmt.Sprintf("%s:%d", name, index)
+	}
+}
+
+// getWorkflowForEachItems returns the items of a `for_each` step. The items are either the `items` list,
+// or the stacks matching the `stacks` filter and the `query`. It returns `true` if the items are stacks.
+func getWorkflowForEachItems(
+	atmosConfig *schema.AtmosConfiguration,
+	step schema.WorkflowStep,
+	describeStacks func() (map[string]any, error),
+) ([]any, bool, error) {
+	forEach := step.ForEach
+
+	if len(forEach.Items) > 0 {
+		if len(forEach.Stacks) > 0 || forEach.Query != "" || len(forEach.Components) > 0 {
+			return nil, false, fmt.Errorf("%w: the `for_each` of the step `%s` can't combine `items` with `stacks`, `query` or `components`",
+				ErrInvalidWorkflowStepForEach, step.Name)
+		}
+		return forEach.Items, false, nil
+	}
+
+	if len(forEach.Stacks) == 0 && forEach.Query == "" {
+		return nil, false, fmt.Errorf("%w: the `for_each` of the step `%s` must define `items`, `stacks` or `query`",
+			ErrInvalidWorkflowStepForEach, step.Name)
+	}
+
+	stacks, err := describeStacks()
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: failed to describe the stacks for the step `%s`: %v", ErrInvalidWorkflowStepForEach, step.Name, err)
+	}
+
+	stackNames, err := getWorkflowForEachStacks(atmosConfig, forEach, stacks)
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: the `for_each` of the step `%s`: %v", ErrInvalidWorkflowStepForEach, step.Name, err)
+	}
+
+	return lo.Map(stackNames, func(stack string, _ int) any { return stack }), true, nil
+}
+
+// getWorkflowForEachStacks returns the sorted names of the stacks with a Terraform component that matches the `stacks`
+// filter and the `query` of a `for_each`. The abstract and disabled components are ignored.
+// The `stacks` filter matches the context variables of the components (e.g. `tenant`, `stage`) against glob patterns.
+// The `query` is a YQ expression evaluated against the component sections, which must return `true`
+// (the same as in `atmos terraform <command> --query`).
+func getWorkflowForEachStacks(atmosConfig *schema.AtmosConfiguration, forEach *schema.WorkflowStepForEach, stacks map[string]any) ([]string, error) {
+	matched := map[string]bool{}
+
+	err := walkTerraformComponents(stacks, func(stackName, componentName string, componentSection map[string]any) error {
+		if matched[stackName] {
+			return nil
+		}
+		if len(forEach.Components) > 0 && !lo.Contains(forEach.Components, componentName) {
+			return nil
+		}
+
+		metadataSection, _ := componentSection[cfg.MetadataSectionName].(map[string]any)
+		if metadataType, ok := metadataSection["type"].(string); ok && metadataType == "abstract" {
+			return nil
+		}
+		if !isComponentEnabled(metadataSection, componentName) {
+			return nil
+		}
+
+		varsSection, _ := componentSection[cfg.VarsSectionName].(map[string]any)
+		for name, pattern := range forEach.Stacks {
+			value, ok := varsSection[name]
+			if !ok {
+				return nil
+			}
+			match, err := u.MatchWildcard(pattern, fmt.Sprintf("%v", value))
+			if err != nil {
+				return fmt.Errorf("invalid pattern `%s` of the context variable `%s`: %v", pattern, name, err)
+			}
+			if !match {
+				return nil
+			}
+		}
+
+		if forEach.Query != "" {
+			queryResult, err := u.EvaluateYqExpression(atmosConfig, componentSection, forEach.Query)
+			if err != nil {
+				return err
+			}
+			if queryPassed, ok := queryResult.(bool); !ok || !queryPassed {
+				return nil
+			}
+		}
+
+		matched[stackName] = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stackNames := lo.Keys(matched)
+	sort.Strings(stackNames)
+	return stackNames, nil
+}
+
+// expandWorkflowDefinition expands the steps with `for_each` of a workflow, and of its `on_failure` and `finally` steps.
+// The stacks that the `stacks` filters and the queries select are restricted to the stack defined on the command line.
+func expandWorkflowDefinition(
+	atmosConfig *schema.AtmosConfiguration,
+	workflow string,
+	workflowDefinition *schema.WorkflowDefinition,
+	sequential bool,
+	commandLineStack string,
+) (steps *workflowExpansion, onFailure *workflowExpansion, finally *workflowExpansion, err error) {
+	describeStacks := describeWorkflowStacks(*atmosConfig, commandLineStack)
+	steps, err = expandWorkflowSteps(atmosConfig, workflowDefinition.Steps, sequential, describeStacks)
+	if err == nil {
+		onFailure, err = expandWorkflowSteps(atmosConfig, workflowDefinition.OnFailure, true, describeStacks)
+	}
+	if err == nil {
+		finally, err = expandWorkflowSteps(atmosConfig, workflowDefinition.Finally, true, describeStacks)
+	}
+	if err != nil {
+		errUtils.CheckErrorAndPrint(
+			ErrInvalidWorkflowStepForEach,
+			WorkflowErrTitle,
+			fmt.Sprintf("\n## Explanation\nThe steps of workflow `%s` are invalid: %s", workflow, strings.TrimPrefix(err.Error(), ErrInvalidWorkflowStepForEach.Error()+": ")),
+		)
+		return nil, nil, nil, ErrInvalidWorkflowStepForEach
+	}
+	return steps, onFailure, finally, nil
+}
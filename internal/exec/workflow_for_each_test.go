@@ -0,0 +1,193 @@
+package exec
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudposse/atmos/pkg/schema"
+)
+
+func workflowForEachTestStacks() map[string]any {
+	component := func(tenant, stage string, metadata map[string]any) map[string]any {
+		return map[string]any{
+			"metadata": metadata,
+			"vars":     map[string]any{"tenant": tenant, "stage": stage, "tags": map[string]any{"team": "eks"}},
+		}
+	}
+	stack := func(components map[string]any) map[string]any {
+		return map[string]any{"components": map[string]any{"terraform": components}}
+	}
+
+	return map[string]any{
+		"plat-ue2-dev": stack(map[string]any{
+			"vpc": component("plat", "dev", map[string]any{}),
+			"eks": component("plat", "dev", map[string]any{}),
+		}),
+		"plat-ue2-prod": stack(map[string]any{
+			"vpc": component("plat", "prod", map[string]any{}),
+			"eks": component("plat", "prod", map[string]any{"enabled": false}),
+		}),
+		"core-ue2-prod": stack(map[string]any{
+			"vpc": component("core", "prod", map[string]any{}),
+		}),
+		"core-ue2-dev": stack(map[string]any{
+			"vpc": component("core", "dev", map[string]any{"type": "abstract"}),
+		}),
+	}
+}
+
+func TestGetWorkflowForEachStacks(t *testing.T) {
+	stacks := workflowForEachTestStacks()
+
+	tests := []struct {
+		name     string
+		forEach  schema.WorkflowStepForEach
+		expected []string
+	}{
+		{
+			name:     "tenant",
+			forEach:  schema.WorkflowStepForEach{Stacks: map[string]string{"tenant": "plat"}},
+			expected: []string{"plat-ue2-dev", "plat-ue2-prod"},
+		},
+		{
+			name:     "tenant and stage globs",
+			forEach:  schema.WorkflowStepForEach{Stacks: map[string]string{"tenant": "*", "stage": "p*"}},
+			expected: []string{"core-ue2-prod", "plat-ue2-prod"},
+		},
+		{
+			name:     "components",
+			forEach:  schema.WorkflowStepForEach{Stacks: map[string]string{"stage": "*"}, Components: []string{"eks"}},
+			expected: []string{"plat-ue2-dev"},
+		},
+		{
+			name:     "query",
+			forEach:  schema.WorkflowStepForEach{Query: `.vars.tenant == "core"`},
+			expected: []string{"core-ue2-prod"},
+		},
+		{
+			name:     "stacks and query",
+			forEach:  schema.WorkflowStepForEach{Stacks: map[string]string{"stage": "prod"}, Query: `.vars.tags.team == "eks"`},
+			expected: []string{"core-ue2-prod", "plat-ue2-prod"},
+		},
+		{
+			name:     "no match",
+			forEach:  schema.WorkflowStepForEach{Stacks: map[string]string{"region": "*"}},
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stackNames, err := getWorkflowForEachStacks(&schema.AtmosConfiguration{}, &tt.forEach, stacks)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, stackNames)
+		})
+	}
+}
+
+func TestExpandWorkflowSteps(t *testing.T) {
+	describeStacks := func() (map[string]any, error) { return workflowForEachTestStacks(), nil }
+	names := func(steps []schema.WorkflowStep) []string {
+		return lo.Map(steps, func(step schema.WorkflowStep, _ int) string { return step.Name })
+	}
+
+	steps := []schema.WorkflowStep{
+		{Name: "init", Type: "shell", Command: "echo init"},
+		{Name: "vpc", Command: "terraform apply vpc", ForEach: &schema.WorkflowStepForEach{Stacks: map[string]string{"tenant": "plat"}}},
+		{Name: "regions", Type: "shell", Command: "echo {{ .item.name }}", ForEach: &schema.WorkflowStepForEach{Items: []any{map[string]any{"name": "us-east-2"}}}},
+		{Name: "done", Type: "shell", Command: "echo done"},
+	}
+
+	// In a sequential workflow, the expanded steps need all the expanded steps of the previous step
+	e, err := expandWorkflowSteps(&schema.AtmosConfiguration{}, steps, true, describeStacks)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"init", "vpc:plat-ue2-dev", "vpc:plat-ue2-prod", "regions:0", "done"}, names(e.steps))
+	assert.Equal(t, "plat-ue2-prod", e.steps[2].Stack)
+	assert.Nil(t, e.steps[2].ForEach)
+	assert.Equal(t, []string{"init"}, e.steps[2].Needs)
+	assert.Equal(t, []string{"vpc:plat-ue2-dev", "vpc:plat-ue2-prod"}, e.steps[3].Needs)
+	assert.Equal(t, []string{"regions:0"}, e.steps[4].Needs)
+	assert.Equal(t, workflowForEachItem{value: "plat-ue2-prod", index: 1, stack: true}, e.items["vpc:plat-ue2-prod"])
+	assert.Equal(t, []string{"vpc:plat-ue2-dev", "vpc:plat-ue2-prod"}, e.expanded["vpc"])
+
+	// The steps that need a step with `for_each` need all its expanded steps
+	steps = []schema.WorkflowStep{
+		{Name: "vpc", Command: "terraform apply vpc", Stack: "{{ .item }}", ForEach: &schema.WorkflowStepForEach{Items: []any{"dev", "prod"}}},
+		{Name: "empty", Command: "terraform apply dns", ForEach: &schema.WorkflowStepForEach{Query: "false"}},
+		{Name: "eks", Command: "terraform apply eks", Needs: []string{"vpc", "empty"}},
+	}
+	e, err = expandWorkflowSteps(&schema.AtmosConfiguration{}, steps, false, describeStacks)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"vpc:dev", "vpc:prod", "eks"}, names(e.steps))
+	assert.Equal(t, "{{ .item }}", e.steps[0].Stack)
+	assert.Empty(t, e.steps[0].Needs)
+	assert.Equal(t, []string{"vpc:dev", "vpc:prod"}, e.steps[2].Needs)
+
+	invalid := []schema.WorkflowStepForEach{
+		{},
+		{Items: []any{"dev"}, Query: ".vars"},
+	}
+	for _, forEach := range invalid {
+		_, err = expandWorkflowSteps(&schema.AtmosConfiguration{}, []schema.WorkflowStep{{Name: "vpc", ForEach: &forEach}}, true, describeStacks)
+		assert.ErrorIs(t, err, ErrInvalidWorkflowStepForEach)
+	}
+}
+
+func TestExecuteWorkflow_ForEach(t *testing.T) {
+	dir := t.TempDir()
+
+	workflowDefinition := &schema.WorkflowDefinition{
+		MaxParallel: 2,
+		Steps: []schema.WorkflowStep{
+			{
+				Name:    "env",
+				Type:    "shell",
+				Command: fmt.Sprintf("echo {{ .index }} > %s/{{ .item }}", dir),
+				ForEach: &schema.WorkflowStepForEach{Items: []any{"dev", "staging", "prod"}},
+			},
+			{
+				Name:    "summary",
+				Type:    "shell",
+				Command: fmt.Sprintf("ls %[1]s > %[1]s/summary", dir),
+			},
+		},
+	}
+
+	err := ExecuteWorkflow(schema.AtmosConfiguration{}, "for-each", "workflows/test.yaml", workflowDefinition, false, "", "", nil)
+	require.NoError(t, err)
+	content, err := os.ReadFile(filepath.Join(dir, "prod"))
+	require.NoError(t, err)
+	assert.Equal(t, "2\n", string(content))
+	content, err = os.ReadFile(filepath.Join(dir, "summary"))
+	require.NoError(t, err)
+	files := strings.Fields(string(content))
+	sort.Strings(files)
+	assert.Equal(t, []string{"dev", "prod", "staging", "summary"}, files)
+
+	// The workflow can be resumed from an expanded step, or from all the expanded steps of a step
+	require.NoError(t, os.RemoveAll(dir))
+	require.NoError(t, os.Mkdir(dir, 0o755))
+	err = ExecuteWorkflow(schema.AtmosConfiguration{}, "for-each", "workflows/test.yaml", workflowDefinition, false, "", "env:prod", nil)
+	require.NoError(t, err)
+	assert.NoFileExists(t, filepath.Join(dir, "dev"))
+	assert.FileExists(t, filepath.Join(dir, "prod"))
+
+	err = ExecuteWorkflow(schema.AtmosConfiguration{}, "for-each", "workflows/test.yaml", workflowDefinition, false, "", "env", nil)
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(dir, "dev"))
+
+	err = ExecuteWorkflow(schema.AtmosConfiguration{}, "for-each", "workflows/test.yaml", workflowDefinition, false, "", "env:qa", nil)
+	assert.ErrorIs(t, err, ErrInvalidFromStep)
+
+	workflowDefinition.Steps[0].ForEach = &schema.WorkflowStepForEach{}
+	err = ExecuteWorkflow(schema.AtmosConfiguration{}, "for-each", "workflows/test.yaml", workflowDefinition, false, "", "", nil)
+	assert.ErrorIs(t, err, ErrInvalidWorkflowStepForEach)
+}
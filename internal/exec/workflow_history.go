@@ -0,0 +1,134 @@
+package exec
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/spf13/cobra"
+
+	"github.com/cloudposse/atmos/internal/tui/templates/term"
+	cfg "github.com/cloudposse/atmos/pkg/config"
+	"github.com/cloudposse/atmos/pkg/schema"
+	"github.com/cloudposse/atmos/pkg/ui/theme"
+	u "github.com/cloudposse/atmos/pkg/utils"
+)
+
+// ExecuteWorkflowHistoryCmd executes `atmos workflow history` command.
+func ExecuteWorkflowHistoryCmd(cmd *cobra.Command, args []string) error {
+	// InitCliConfig finds and merges CLI configurations in the following order:
+	// system dir, home dir, current dir, ENV vars, command-line arguments
+	atmosConfig, err := cfg.InitCliConfig(schema.ConfigAndStacksInfo{}, false)
+	if err != nil {
+		return err
+	}
+
+	flags := cmd.Flags()
+
+	workflowFile, err := flags.GetString("file")
+	if err != nil {
+		return err
+	}
+
+	limit, err := flags.GetInt("limit")
+	if err != nil {
+		return err
+	}
+
+	format, err := flags.GetString("format")
+	if err != nil {
+		return err
+	}
+	if format != "" && format != "table" && format != "json" && format != "yaml" {
+		return fmt.Errorf("invalid '--format' flag '%s'. Valid values are 'table', 'json' and 'yaml'", format)
+	}
+
+	workflow := ""
+	if len(args) > 0 {
+		workflow = args[0]
+	}
+
+	runs, err := loadWorkflowRuns(&atmosConfig)
+	if err != nil {
+		return err
+	}
+
+	runs = filterWorkflowRuns(&atmosConfig, runs, workflow, workflowFile, limit)
+
+	if format == "json" || format == "yaml" {
+		return printOrWriteToFile(&atmosConfig, format, "", runs)
+	}
+
+	if len(runs) == 0 {
+		u.PrintMessage("No workflow runs found")
+		return nil
+	}
+
+	u.PrintMessage(formatWorkflowRunsTable(runs))
+	return nil
+}
+
+// filterWorkflowRuns returns the most recent runs of the workflow in the workflow manifest, if they are specified.
+// If limit is positive, at most limit runs are returned.
+func filterWorkflowRuns(atmosConfig *schema.AtmosConfiguration, runs []schema.WorkflowRun, workflow string, workflowFile string, limit int) []schema.WorkflowRun {
+	path := ""
+	if workflowFile != "" {
+		path = getWorkflowManifestAbsPath(getWorkflowManifestPath(atmosConfig, workflowFile))
+	}
+
+	result := []schema.WorkflowRun{}
+	for _, run := range runs {
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+		if (workflow == "" || run.Workflow == workflow) && (path == "" || run.Path == path) {
+			result = append(result, run)
+		}
+	}
+	return result
+}
+
+// formatWorkflowRunsTable formats the workflow runs as a table.
+func formatWorkflowRunsTable(runs []schema.WorkflowRun) string {
+	header := []string{"Run", "Workflow", "File", "Stack", "Status", "Started", "Duration", "Failed Steps"}
+
+	rows := make([][]string, 0, len(runs))
+	for i := range runs {
+		run := &runs[i]
+		rows = append(rows, []string{
+			run.ID,
+			run.Workflow,
+			run.File,
+			run.Stack,
+			run.Status,
+			run.StartedAt.Local().Format(time.DateTime),
+			formatWorkflowRunDuration(run),
+			getWorkflowRunFailedSteps(run),
+		})
+	}
+
+	if term.IsTTYSupportForStdout() {
+		t := table.New().
+			Border(lipgloss.ThickBorder()).
+			BorderStyle(lipgloss.NewStyle().Foreground(lipgloss.Color(theme.ColorBorder))).
+			StyleFunc(func(row, col int) lipgloss.Style {
+				style := lipgloss.NewStyle().PaddingLeft(1).PaddingRight(1)
+				if row == table.HeaderRow {
+					return style.Inherit(theme.Styles.CommandName).Align(lipgloss.Center)
+				}
+				return style.Inherit(theme.Styles.Description)
+			}).
+			Headers(header...).
+			Rows(rows...)
+		return t.String()
+	}
+
+	var output strings.Builder
+	output.WriteString(strings.Join(header, "\t") + u.GetLineEnding())
+	for _, row := range rows {
+		output.WriteString(strings.Join(row, "\t") + u.GetLineEnding())
+	}
+	return strings.TrimSuffix(output.String(), u.GetLineEnding())
+}
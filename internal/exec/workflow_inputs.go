@@ -0,0 +1,368 @@
+package exec
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/huh"
+	"github.com/samber/lo"
+	"golang.org/x/term"
+
+	errUtils "github.com/cloudposse/atmos/errors"
+	"github.com/cloudposse/atmos/pkg/schema"
+	u "github.com/cloudposse/atmos/pkg/utils"
+)
+
+// The types of the workflow inputs.
+const (
+	WorkflowInputTypeString  = "string"
+	WorkflowInputTypeNumber  = "number"
+	WorkflowInputTypeBoolean = "boolean"
+)
+
+// promptWorkflowInput asks the user for the value of a workflow input. It's a variable so that it can be replaced in tests.
+var promptWorkflowInput = func(name string, input schema.WorkflowInput) (string, error) {
+	var value string
+	prompt := huh.NewInput().
+		Title(fmt.Sprintf("Workflow input `%s` (%s)", name, getWorkflowInputType(input))).
+		Description(input.Description).
+		Value(&value).
+		WithTheme(huh.ThemeCharm())
+	if err := prompt.Run(); err != nil {
+		return "", err
+	}
+	return value, nil
+}
+
+// isWorkflowInputPromptSupported returns true if the missing workflow inputs can be prompted in the terminal.
+func isWorkflowInputPromptSupported() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stderr.Fd()))
+}
+
+func getWorkflowInputType(input schema.WorkflowInput) string {
+	if input.Type == "" {
+		return WorkflowInputTypeString
+	}
+	return input.Type
+}
+
+// resolveWorkflowInputs returns the values of the workflow inputs, converted to the types of the inputs.
+// The values are taken from the command line (`--input key=value`), or from the defaults of the inputs.
+// If a required input is missing and prompt is `true`, the user is asked for the value.
+func resolveWorkflowInputs(definitions map[string]schema.WorkflowInput, values map[string]string, prompt bool) (map[string]any, error) {
+	for name := range values {
+		if _, ok := definitions[name]; !ok {
+			return nil, fmt.Errorf("%w: the workflow does not define the input `%s`", ErrInvalidWorkflowInput, name)
+		}
+	}
+
+	names := lo.Keys(definitions)
+	sort.Strings(names)
+
+	inputs := make(map[string]any, len(definitions))
+	for _, name := range names {
+		input := definitions[name]
+
+		switch getWorkflowInputType(input) {
+		case WorkflowInputTypeString, WorkflowInputTypeNumber, WorkflowInputTypeBoolean:
+		default:
+			return nil, fmt.Errorf("%w: the input `%s` has an invalid type `%s`. Supported types: `string`, `number`, `boolean`",
+				ErrInvalidWorkflowInput, name, input.Type)
+		}
+
+		var value any
+		if v, ok := values[name]; ok {
+			value = v
+		} else if input.Default != nil {
+			value = input.Default
+		} else if prompt {
+			v, err := promptWorkflowInput(name, input)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrInvalidWorkflowInput, err)
+			}
+			value = v
+		} else {
+			return nil, fmt.Errorf("%w: the required input `%s` is not set. Use `--input %s=<value>`", ErrInvalidWorkflowInput, name, name)
+		}
+
+		converted, err := convertWorkflowInput(name, input, value)
+		if err != nil {
+			return nil, err
+		}
+		inputs[name] = converted
+	}
+
+	return inputs, nil
+}
+
+// convertWorkflowInput converts the value of a workflow input to the type of the input.
+func convertWorkflowInput(name string, input schema.WorkflowInput, value any) (any, error) {
+	inputType := getWorkflowInputType(input)
+	invalid := fmt.Errorf("%w: the value `%v` of the input `%s` is not a %s", ErrInvalidWorkflowInput, value, name, inputType)
+
+	switch inputType {
+	case WorkflowInputTypeNumber:
+		switch v := value.(type) {
+		case int, int64, uint64, float64:
+			return v, nil
+		case string:
+			if i, err := strconv.Atoi(v); err == nil {
+				return i, nil
+			}
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				return f, nil
+			}
+		}
+		return nil, invalid
+	case WorkflowInputTypeBoolean:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			if b, err := strconv.ParseBool(v); err == nil {
+				return b, nil
+			}
+		}
+		return nil, invalid
+	default:
+		return fmt.Sprintf("%v", value), nil
+	}
+}
+
+// parseWorkflowInputFlags parses the `--input key=value` flags.
+func parseWorkflowInputFlags(flags []string) (map[string]string, error) {
+	values := make(map[string]string, len(flags))
+	for _, flag := range flags {
+		name, value, ok := strings.Cut(flag, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("%w: invalid `--input` flag `%s`. The flag must be in the `key=value` format", ErrInvalidWorkflowInput, flag)
+		}
+		values[strings.TrimSpace(name)] = value
+	}
+	return values, nil
+}
+
+// usesWorkflowTemplates returns true if the steps of the workflow are processed as Go templates.
+// For compatibility with the commands that contain `{{ }}` (e.g. `docker ps --format '{{ .ID }}'`),
+// the templates are processed only in the workflows that define inputs, step outputs, `for_each` or `if` steps, or steps of type `approval`.
+func usesWorkflowTemplates(workflowDefinition *schema.WorkflowDefinition) bool {
+	if len(workflowDefinition.Inputs) > 0 {
+		return true
+	}
+	allSteps := append(append(append([]schema.WorkflowStep{}, workflowDefinition.Steps...), workflowDefinition.OnFailure...), workflowDefinition.Finally...)
+	return lo.SomeBy(allSteps, func(step schema.WorkflowStep) bool {
+		return len(step.Outputs) > 0 || step.ForEach != nil || step.If != "" || getWorkflowStepType(step) == "approval"
+	})
+}
+
+// renderWorkflowStep processes the templates in the `command`, `stack`, `env`, `file` and `inputs` of a workflow step.
+// The templates can reference the workflow inputs (`{{ .inputs.name }}`), the outputs of the previous steps
+// (`{{ .steps.name.outputs.output }}`), and the `for_each` item of the step (`{{ .item }}` and `{{ .index }}`).
+func renderWorkflowStep(step schema.WorkflowStep, data map[string]any, ignoreMissingTemplateValues bool) (schema.WorkflowStep, error) {
+	render := func(attribute string, value string) (string, error) {
+		if !strings.Contains(value, "{{") {
+			return value, nil
+		}
+		result, err := ProcessTmpl(fmt.Sprintf("workflow-step-%s-%s", step.Name, attribute), value, data, ignoreMissingTemplateValues)
+		if err != nil {
+			return "", fmt.Errorf("%w: the `%s` of the step `%s`: %v", ErrWorkflowStepTemplate, attribute, step.Name, err)
+		}
+		return result, nil
+	}
+
+	var err error
+	if step.Command, err = render("command", step.Command); err != nil {
+		return step, err
+	}
+	if step.Stack, err = render("stack", step.Stack); err != nil {
+		return step, err
+	}
+
+	if step.File, err = render("file", step.File); err != nil {
+		return step, err
+	}
+
+	if len(step.Env) > 0 {
+		env := make(map[string]string, len(step.Env))
+		for k, v := range step.Env {
+			if env[k], err = render("env", v); err != nil {
+				return step, err
+			}
+		}
+		step.Env = env
+	}
+
+	if len(step.Inputs) > 0 {
+		inputs := make(map[string]any, len(step.Inputs))
+		for k, v := range step.Inputs {
+			inputs[k] = v
+			if value, ok := v.(string); ok {
+				if inputs[k], err = render("inputs", value); err != nil {
+					return step, err
+				}
+			}
+		}
+		step.Inputs = inputs
+	}
+
+	return step, nil
+}
+
+// getWorkflowStepEnv returns the ENV variables of a workflow step as a sorted list of `key=value` pairs.
+func getWorkflowStepEnv(step schema.WorkflowStep) []string {
+	env := make([]string, 0, len(step.Env))
+	for k, v := range step.Env {
+		env = append(env, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(env)
+	return env
+}
+
+// getWorkflowStepOutputs evaluates the `outputs` of a workflow step against the standard output of the step command.
+// An empty expression returns the whole output (without the trailing whitespace). The other expressions are YQ expressions
+// evaluated against the output parsed as JSON or YAML.
+func getWorkflowStepOutputs(atmosConfig *schema.AtmosConfiguration, step schema.WorkflowStep, stdout string) (map[string]any, error) {
+	outputs := make(map[string]any, len(step.Outputs))
+
+	var parsed any
+	parsedOnce := false
+
+	for name, expression := range step.Outputs {
+		if strings.TrimSpace(expression) == "" {
+			outputs[name] = strings.TrimSpace(stdout)
+			continue
+		}
+
+		if !parsedOnce {
+			var err error
+			if parsed, err = u.UnmarshalYAML[any](stdout); err != nil {
+				return nil, fmt.Errorf("%w: the output of the step `%s` is not valid JSON or YAML: %v", ErrWorkflowStepOutputs, step.Name, err)
+			}
+			parsedOnce = true
+		}
+
+		value, err := u.EvaluateYqExpression(atmosConfig, parsed, expression)
+		if err != nil {
+			return nil, fmt.Errorf("%w: the output `%s` of the step `%s`: %v", ErrWorkflowStepOutputs, name, step.Name, err)
+		}
+		outputs[name] = value
+	}
+
+	return outputs, nil
+}
+
+// workflowState holds the inputs of a workflow, the `for_each` items of the expanded steps and the outputs and statuses of the steps,
+// which the templates of the steps can reference. The steps that run concurrently access it through its methods.
+type workflowState struct {
+	mu        sync.Mutex
+	templates bool
+	inputs    map[string]any
+	items     map[string]workflowForEachItem
+	steps     map[string]any
+	// call describes how the workflow is executed
+	call *workflowCall
+	// fromSteps are the steps to resume the called workflows from, by the name of the calling step
+	fromSteps map[string][]string
+	// approvalOutputSteps maps the steps of type `approval` with `show_output` to the steps whose output they show
+	approvalOutputSteps map[string][]string
+	// stepOutputs are the ends of the outputs of the steps shown by the steps of type `approval`
+	stepOutputs map[string]string
+}
+
+func newWorkflowState(workflowDefinition *schema.WorkflowDefinition, inputs map[string]any, items map[string]workflowForEachItem) *workflowState {
+	return &workflowState{
+		templates: usesWorkflowTemplates(workflowDefinition),
+		inputs:    inputs,
+		items:     items,
+		steps:     map[string]any{},
+		// The outputs are only captured for the steps shown by the steps of type `approval`
+		approvalOutputSteps: map[string][]string{},
+		stepOutputs:         map[string]string{},
+	}
+}
+
+// templateData returns the data of the templates of a step.
+func (s *workflowState) templateData(step string) map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data := map[string]any{
+		"inputs": s.inputs,
+		"steps":  lo.Assign(s.steps),
+	}
+	if item, ok := s.items[step]; ok {
+		data["item"] = item.value
+		data["index"] = item.index
+	}
+	return data
+}
+
+// setOutputs records the outputs of a step.
+func (s *workflowState) setOutputs(step string, outputs map[string]any) {
+	s.setStepData(step, "outputs", outputs)
+}
+
+// setStatus records the status of a step, which the `if` conditions of the steps can reference.
+func (s *workflowState) setStatus(step string, status string) {
+	s.setStepData(step, "status", status)
+}
+
+// setStepData sets an attribute of a step. The attributes of the step are replaced with a new map,
+// since the maps returned by templateData are read by the steps that run concurrently.
+func (s *workflowState) setStepData(step string, key string, value any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, _ := s.steps[step].(map[string]any)
+	s.steps[step] = lo.Assign(existing, map[string]any{key: value})
+}
+
+// resolveWorkflowDefinitionInputs resolves the inputs of a workflow, and prints the error if they are invalid.
+// The missing inputs are prompted for if the workflow can read from the terminal.
+func resolveWorkflowDefinitionInputs(
+	workflow string,
+	workflowDefinition *schema.WorkflowDefinition,
+	inputValues map[string]string,
+	call *workflowCall,
+) (map[string]any, error) {
+	inputs, err := resolveWorkflowInputs(workflowDefinition.Inputs, inputValues, isWorkflowInputPromptSupported() && call.streams.Stdin != nil)
+	if err != nil {
+		errUtils.CheckErrorAndPrint(
+			ErrInvalidWorkflowInput,
+			WorkflowErrTitle,
+			fmt.Sprintf("\n## Explanation\nThe inputs of workflow `%s` are invalid: %s", workflow, strings.TrimPrefix(err.Error(), ErrInvalidWorkflowInput.Error()+": ")),
+		)
+		return nil, ErrInvalidWorkflowInput
+	}
+	return inputs, nil
+}
+
+// renderWorkflowStack processes the templates in the `stack` attribute of a workflow, which can reference the inputs.
+// It returns a copy of the workflow definition with the rendered stack.
+func renderWorkflowStack(
+	workflow string,
+	workflowDefinition *schema.WorkflowDefinition,
+	state *workflowState,
+	dryRun bool,
+) (*schema.WorkflowDefinition, error) {
+	if !state.templates || !strings.Contains(workflowDefinition.Stack, "{{") {
+		return workflowDefinition, nil
+	}
+
+	renderedDefinition := *workflowDefinition
+	stack, err := ProcessTmpl("workflow-stack", workflowDefinition.Stack, state.templateData(""), dryRun)
+	if err != nil {
+		errUtils.CheckErrorAndPrint(
+			ErrWorkflowStepTemplate,
+			WorkflowErrTitle,
+			fmt.Sprintf("\n## Explanation\nFailed to process the `stack` of workflow `%s`: %v", workflow, err),
+		)
+		return nil, ErrWorkflowStepTemplate
+	}
+	renderedDefinition.Stack = stack
+	return &renderedDefinition, nil
+}
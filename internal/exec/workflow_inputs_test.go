@@ -0,0 +1,139 @@
+package exec
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudposse/atmos/pkg/schema"
+)
+
+func TestResolveWorkflowInputs(t *testing.T) {
+	definitions := map[string]schema.WorkflowInput{
+		"environment": {Type: "string"},
+		"replicas":    {Type: "number", Default: 2},
+		"ratio":       {Type: "number"},
+		"force":       {Type: "boolean", Default: false},
+	}
+
+	inputs, err := resolveWorkflowInputs(definitions, map[string]string{"environment": "dev", "ratio": "0.5", "force": "true"}, false)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"environment": "dev", "replicas": 2, "ratio": 0.5, "force": true}, inputs)
+
+	tests := []struct {
+		name   string
+		values map[string]string
+		errMsg string
+	}{
+		{name: "unknown input", values: map[string]string{"environment": "dev", "ratio": "1", "region": "us-east-2"}, errMsg: "does not define the input `region`"},
+		{name: "missing input", values: map[string]string{"environment": "dev"}, errMsg: "the required input `ratio` is not set"},
+		{name: "invalid number", values: map[string]string{"environment": "dev", "ratio": "half"}, errMsg: "the value `half` of the input `ratio` is not a number"},
+		{name: "invalid boolean", values: map[string]string{"environment": "dev", "ratio": "1", "force": "maybe"}, errMsg: "the value `maybe` of the input `force` is not a boolean"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := resolveWorkflowInputs(definitions, tt.values, false)
+			assert.ErrorIs(t, err, ErrInvalidWorkflowInput)
+			assert.ErrorContains(t, err, tt.errMsg)
+		})
+	}
+
+	_, err = resolveWorkflowInputs(map[string]schema.WorkflowInput{"size": {Type: "list"}}, nil, false)
+	assert.ErrorContains(t, err, "the input `size` has an invalid type `list`")
+}
+
+func TestResolveWorkflowInputs_Prompt(t *testing.T) {
+	prompt := promptWorkflowInput
+	t.Cleanup(func() { promptWorkflowInput = prompt })
+
+	var prompted []string
+	promptWorkflowInput = func(name string, _ schema.WorkflowInput) (string, error) {
+		prompted = append(prompted, name)
+		return "42", nil
+	}
+
+	definitions := map[string]schema.WorkflowInput{
+		"replicas":    {Type: "number"},
+		"environment": {},
+		"region":      {Default: "us-east-2"},
+	}
+
+	inputs, err := resolveWorkflowInputs(definitions, map[string]string{"environment": "dev"}, true)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"environment": "dev", "replicas": 42, "region": "us-east-2"}, inputs)
+	assert.Equal(t, []string{"replicas"}, prompted)
+}
+
+func TestParseWorkflowInputFlags(t *testing.T) {
+	values, err := parseWorkflowInputFlags([]string{"environment=dev", "filter=a=b", "empty="})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"environment": "dev", "filter": "a=b", "empty": ""}, values)
+
+	_, err = parseWorkflowInputFlags([]string{"environment"})
+	assert.ErrorIs(t, err, ErrInvalidWorkflowInput)
+}
+
+func TestGetWorkflowStepOutputs(t *testing.T) {
+	step := schema.WorkflowStep{
+		Name: "vpc",
+		Outputs: map[string]string{
+			"raw":     "",
+			"vpc_id":  ".vpc_id",
+			"subnets": ".subnet_ids | length",
+		},
+	}
+
+	outputs, err := getWorkflowStepOutputs(&schema.AtmosConfiguration{}, step, `{"vpc_id": "vpc-123", "subnet_ids": ["a", "b"]}`+"\n")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"raw":     `{"vpc_id": "vpc-123", "subnet_ids": ["a", "b"]}`,
+		"vpc_id":  "vpc-123",
+		"subnets": 2,
+	}, outputs)
+
+	_, err = getWorkflowStepOutputs(&schema.AtmosConfiguration{}, step, "vpc_id: [")
+	assert.ErrorIs(t, err, ErrWorkflowStepOutputs)
+}
+
+func TestExecuteWorkflow_InputsAndOutputs(t *testing.T) {
+	result := filepath.Join(t.TempDir(), "result")
+
+	workflowDefinition := &schema.WorkflowDefinition{
+		Inputs: map[string]schema.WorkflowInput{
+			"environment": {Description: "The environment"},
+		},
+		Steps: []schema.WorkflowStep{
+			{
+				Name:    "vpc",
+				Type:    "shell",
+				Command: `echo '{"vpc_id": "vpc-123"}'`,
+				Outputs: map[string]string{"vpc_id": ".vpc_id"},
+			},
+			{
+				Name:    "eks",
+				Type:    "shell",
+				Command: fmt.Sprintf(`echo "{{ .steps.vpc.outputs.vpc_id }} $ENVIRONMENT" > %s`, result),
+				Env:     map[string]string{"ENVIRONMENT": "{{ .inputs.environment }}"},
+			},
+		},
+	}
+
+	err := ExecuteWorkflow(schema.AtmosConfiguration{}, "inputs", "workflows/test.yaml", workflowDefinition, false, "", "", map[string]string{"environment": "dev"})
+	require.NoError(t, err)
+	content, err := os.ReadFile(result)
+	require.NoError(t, err)
+	assert.Equal(t, "vpc-123 dev\n", string(content))
+
+	err = ExecuteWorkflow(schema.AtmosConfiguration{}, "inputs", "workflows/test.yaml", workflowDefinition, false, "", "", nil)
+	assert.ErrorIs(t, err, ErrInvalidWorkflowInput)
+
+	// The steps can only reference the outputs of the steps that have run
+	workflowDefinition.Steps[1].Command = "echo {{ .steps.eks.outputs.cluster }}"
+	err = ExecuteWorkflow(schema.AtmosConfiguration{}, "inputs", "workflows/test.yaml", workflowDefinition, false, "", "", map[string]string{"environment": "dev"})
+	assert.ErrorIs(t, err, ErrWorkflowStepFailed)
+}
@@ -0,0 +1,561 @@
+package exec
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/charmbracelet/log"
+	"github.com/samber/lo"
+	"mvdan.cc/sh/v3/interp"
+
+	"github.com/cloudposse/atmos/pkg/config/go-homedir"
+	"github.com/cloudposse/atmos/pkg/schema"
+)
+
+// The statuses of the workflow runs and steps recorded in the journal.
+const (
+	WorkflowRunStatusPending   = "pending"
+	WorkflowRunStatusRunning   = "running"
+	WorkflowRunStatusSucceeded = "succeeded"
+	WorkflowRunStatusFailed    = "failed"
+	// WorkflowRunStatusContinued is the status of the failed steps with `continue_on_error`
+	WorkflowRunStatusContinued = "continued"
+	// WorkflowRunStatusSkipped is the status of the steps whose `if` condition is false
+	WorkflowRunStatusSkipped = "skipped"
+)
+
+// defaultWorkflowJournalMaxRuns is the default number of runs kept in the journal.
+const defaultWorkflowJournalMaxRuns = 100
+
+// workflowJournal records a run of a workflow executed by `atmos workflow`. If the journal is enabled, the run is written
+// to a file, which is updated after each step, so that the run can be resumed after the terminal is gone.
+// The recorded run is also used for the workflow run reports. A nil journal records nothing.
+type workflowJournal struct {
+	mu sync.Mutex
+	// path is the file of the run in the journal. It's empty if the run is not written to the journal
+	path    string
+	maxRuns int
+	run     schema.WorkflowRun
+	// steps maps the indexes of the steps of the workflow to the indexes of the steps in the run
+	steps map[int]int
+	// started is true after the steps of the workflow started
+	started bool
+	// outputLines is the number of the last lines of the output of the steps recorded in the run (0 doesn't capture the output)
+	outputLines int
+	// failed is true after the journal failed to be written, so that the error is only logged once
+	failed bool
+	// approvals are the approvals of the steps of type `approval` that haven't been recorded in the steps of the run yet
+	approvals map[string]workflowApproval
+	// sensitiveValues are the values of the sensitive inputs, redacted from the commands, errors and output of the steps
+	sensitiveValues []string
+}
+
+// workflowJournalRedacted replaces the values of the sensitive inputs in the journal.
+const workflowJournalRedacted = "<redacted>"
+
+// workflowApproval is the approval of a step of type `approval`.
+type workflowApproval struct {
+	approvedBy   string
+	autoApproved bool
+}
+
+// isWorkflowJournalEnabled returns true if the workflow runs are recorded in the journal. The journal is enabled by default.
+func isWorkflowJournalEnabled(atmosConfig *schema.AtmosConfiguration) bool {
+	enabled := atmosConfig.Workflows.Journal.Enabled
+	return enabled == nil || *enabled
+}
+
+// getWorkflowJournalDir returns the directory of the workflow journal. The journal of each project is stored in its own directory,
+// named after the hash of the absolute base path of the project, so that the projects don't see the runs of each other.
+// A relative `workflows.journal.path` is relative to the base path of the project.
+func getWorkflowJournalDir(atmosConfig *schema.AtmosConfiguration) (string, error) {
+	if path := atmosConfig.Workflows.Journal.Path; path != "" {
+		path, err := homedir.Expand(path)
+		if err != nil {
+			return "", err
+		}
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(atmosConfig.BasePath, path)
+		}
+		return path, nil
+	}
+
+	stateHome := os.Getenv("XDG_STATE_HOME")
+	if stateHome == "" {
+		home, err := homedir.Dir()
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", ErrWorkflowJournal, err)
+		}
+		stateHome = filepath.Join(home, ".local", "state")
+	}
+
+	basePath, err := filepath.Abs(atmosConfig.BasePath)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrWorkflowJournal, err)
+	}
+	project := sha256.Sum256([]byte(basePath))
+	return filepath.Join(stateHome, "atmos", "workflows", hex.EncodeToString(project[:8])), nil
+}
+
+// getWorkflowManifestAbsPath returns the absolute path of a workflow manifest, which identifies the runs of its workflows
+// in the journal.
+func getWorkflowManifestAbsPath(workflowPath string) string {
+	if path, err := filepath.Abs(workflowPath); err == nil {
+		return path
+	}
+	return workflowPath
+}
+
+// newWorkflowJournal returns the journal of a new run of a workflow. The dry runs, and the runs when the journal is disabled,
+// are only recorded in memory. Otherwise, the run is written to the journal when its steps start.
+func newWorkflowJournal(
+	atmosConfig *schema.AtmosConfiguration,
+	workflow string,
+	workflowPath string,
+	commandLineStack string,
+	fromStep string,
+	inputValues map[string]string,
+	dryRun bool,
+) *workflowJournal {
+	dir := ""
+	if !dryRun && isWorkflowJournalEnabled(atmosConfig) {
+		var err error
+		if dir, err = getWorkflowJournalDir(atmosConfig); err != nil {
+			log.Warn("Failed to get the directory of the workflow journal; not recording the workflow run", "error", err)
+			dir = ""
+		}
+	}
+
+	startedAt := time.Now().UTC()
+	suffix := make([]byte, 3)
+	_, _ = rand.Read(suffix)
+	id := fmt.Sprintf("%s-%s", startedAt.Format("20060102T150405Z"), hex.EncodeToString(suffix))
+
+	maxRuns := atmosConfig.Workflows.Journal.MaxRuns
+	if maxRuns <= 0 {
+		maxRuns = defaultWorkflowJournalMaxRuns
+	}
+
+	path := ""
+	if dir != "" {
+		path = filepath.Join(dir, id+".json")
+	}
+
+	return &workflowJournal{
+		path:    path,
+		maxRuns: maxRuns,
+		steps:   map[int]int{},
+		run: schema.WorkflowRun{
+			ID:        id,
+			Workflow:  workflow,
+			File:      getWorkflowFile(atmosConfig, workflowPath),
+			Path:      getWorkflowManifestAbsPath(workflowPath),
+			Stack:     commandLineStack,
+			Inputs:    inputValues,
+			FromStep:  fromStep,
+			DryRun:    dryRun,
+			Status:    WorkflowRunStatusRunning,
+			StartedAt: startedAt,
+		},
+	}
+}
+
+// getWorkflowJournalInputs returns the inputs recorded in the journal to resume the run: the inputs set on the command line,
+// except the sensitive inputs, which must be set again when the run is resumed.
+func getWorkflowJournalInputs(definitions map[string]schema.WorkflowInput, values map[string]string) map[string]string {
+	inputs := lo.OmitBy(values, func(name string, _ string) bool { return definitions[name].Sensitive })
+	if len(inputs) == 0 {
+		return nil
+	}
+	return inputs
+}
+
+// setSensitiveInputs records the values of the sensitive inputs of a workflow, to redact them from the steps of the run.
+func (j *workflowJournal) setSensitiveInputs(definitions map[string]schema.WorkflowInput, inputs map[string]any) {
+	if j == nil {
+		return
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	for name, input := range definitions {
+		if value := fmt.Sprintf("%v", inputs[name]); input.Sensitive && inputs[name] != nil && value != "" {
+			j.sensitiveValues = append(j.sensitiveValues, value)
+		}
+	}
+}
+
+// redact replaces the values of the sensitive inputs in the command, error and output of a step.
+func (j *workflowJournal) redact(runStep *schema.WorkflowRunStep) {
+	for _, value := range j.sensitiveValues {
+		runStep.Command = strings.ReplaceAll(runStep.Command, value, workflowJournalRedacted)
+		runStep.Error = strings.ReplaceAll(runStep.Error, value, workflowJournalRedacted)
+		runStep.Output = strings.ReplaceAll(runStep.Output, value, workflowJournalRedacted)
+	}
+}
+
+// start records the selected steps of the workflow as pending, and writes the run to the journal.
+func (j *workflowJournal) start(
+	workflowDefinition *schema.WorkflowDefinition,
+	steps []schema.WorkflowStep,
+	selected map[int]bool,
+	commandLineStack string,
+) {
+	if j == nil {
+		return
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	for i, step := range steps {
+		if !selected[i] {
+			continue
+		}
+		j.steps[i] = len(j.run.Steps)
+		j.run.Steps = append(j.run.Steps, newWorkflowRunStep(workflowDefinition, step, commandLineStack))
+	}
+	j.started = true
+	j.save()
+	j.prune()
+}
+
+// stepStarted records that a step started.
+func (j *workflowJournal) stepStarted(index int) {
+	if j == nil {
+		return
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if i, ok := j.steps[index]; ok {
+		now := time.Now().UTC()
+		j.run.Steps[i].Status = WorkflowRunStatusRunning
+		j.run.Steps[i].StartedAt = &now
+		j.save()
+	}
+}
+
+// stepFinished records the result of a step. The step is the step with its templates processed, and the output is the end
+// of the output of the step.
+func (j *workflowJournal) stepFinished(
+	index int,
+	workflowDefinition *schema.WorkflowDefinition,
+	step schema.WorkflowStep,
+	commandLineStack string,
+	attempts []workflowStepAttempt,
+	err error,
+	output string,
+) {
+	if j == nil {
+		return
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	i, ok := j.steps[index]
+	if !ok {
+		return
+	}
+
+	runStep := newWorkflowRunStep(workflowDefinition, step, commandLineStack)
+	setWorkflowRunStepResult(&runStep, j.run.Steps[i].StartedAt, attempts, err, output)
+	j.setApproval(&runStep)
+	j.redact(&runStep)
+	j.run.Steps[i] = runStep
+	j.save()
+}
+
+// cleanupStepFinished records the result of an `on_failure` or `finally` step.
+func (j *workflowJournal) cleanupStepFinished(
+	kind string,
+	workflowDefinition *schema.WorkflowDefinition,
+	step schema.WorkflowStep,
+	commandLineStack string,
+	startedAt time.Time,
+	attempts []workflowStepAttempt,
+	err error,
+	output string,
+) {
+	if j == nil {
+		return
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	runStep := newWorkflowRunStep(workflowDefinition, step, commandLineStack)
+	runStep.Kind = kind
+	startedAt = startedAt.UTC()
+	setWorkflowRunStepResult(&runStep, &startedAt, attempts, err, output)
+	j.setApproval(&runStep)
+	j.redact(&runStep)
+	j.run.Steps = append(j.run.Steps, runStep)
+	j.save()
+}
+
+// approved records the user who approved a step of type `approval`. The approval is recorded in the step when it finishes.
+func (j *workflowJournal) approved(step string, approvedBy string, autoApproved bool) {
+	if j == nil {
+		return
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.approvals == nil {
+		j.approvals = map[string]workflowApproval{}
+	}
+	j.approvals[step] = workflowApproval{approvedBy: approvedBy, autoApproved: autoApproved}
+}
+
+func (j *workflowJournal) setApproval(runStep *schema.WorkflowRunStep) {
+	if approval, ok := j.approvals[runStep.Name]; ok {
+		runStep.ApprovedBy = approval.approvedBy
+		runStep.AutoApproved = approval.autoApproved
+		delete(j.approvals, runStep.Name)
+	}
+}
+
+// outputWriter returns a writer that keeps the end of the output of a step, or nil if the output is not captured.
+func (j *workflowJournal) outputWriter() *tailWriter {
+	if j == nil || j.outputLines <= 0 {
+		return nil
+	}
+	return newTailWriter(j.outputLines)
+}
+
+// finish records the final status of the run, and the steps to resume the failed run from.
+func (j *workflowJournal) finish(status string, resumeSteps []string) {
+	if j == nil {
+		return
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	now := time.Now().UTC()
+	j.run.Status = status
+	j.run.FinishedAt = &now
+	j.run.ResumeSteps = resumeSteps
+	j.save()
+}
+
+// save writes the run to the journal. The journal is only readable by the user, since the steps and their output can
+// contain secrets. The file is replaced atomically, so that an interrupted write doesn't corrupt the run.
+func (j *workflowJournal) save() {
+	if j.path == "" || j.failed {
+		return
+	}
+
+	err := func() error {
+		if err := os.MkdirAll(filepath.Dir(j.path), 0o700); err != nil {
+			return err
+		}
+		data, err := json.MarshalIndent(j.run, "", "  ")
+		if err != nil {
+			return err
+		}
+		tmp := j.path + ".tmp"
+		if err := os.WriteFile(tmp, data, 0o600); err != nil {
+			return err
+		}
+		return os.Rename(tmp, j.path)
+	}()
+	if err != nil {
+		j.failed = true
+		log.Warn("Failed to record the workflow run in the journal", "path", j.path, "error", err)
+	}
+}
+
+// prune removes the oldest runs from the journal, keeping `workflows.journal.max_runs` runs.
+func (j *workflowJournal) prune() {
+	if j.path == "" {
+		return
+	}
+
+	files, err := filepath.Glob(filepath.Join(filepath.Dir(j.path), "*.json"))
+	if err != nil || len(files) <= j.maxRuns {
+		return
+	}
+
+	// The names of the files start with the time of the runs
+	sort.Strings(files)
+	for _, file := range files[:len(files)-j.maxRuns] {
+		if err := os.Remove(file); err != nil {
+			log.Debug("Failed to remove a workflow run from the journal", "path", file, "error", err)
+		}
+	}
+}
+
+// newWorkflowRunStep returns a pending step of a workflow run.
+func newWorkflowRunStep(workflowDefinition *schema.WorkflowDefinition, step schema.WorkflowStep, commandLineStack string) schema.WorkflowRunStep {
+	stack := getWorkflowStepStack(workflowDefinition, step, commandLineStack)
+	return schema.WorkflowRunStep{
+		Name:    step.Name,
+		Type:    getWorkflowStepType(step),
+		Command: getWorkflowStepFailedCommand(step, stack),
+		Stack:   stack,
+		Status:  WorkflowRunStatusPending,
+	}
+}
+
+// setWorkflowRunStepResult records the result of the attempts to execute a step of a workflow run.
+func setWorkflowRunStepResult(runStep *schema.WorkflowRunStep, startedAt *time.Time, attempts []workflowStepAttempt, err error, output string) {
+	now := time.Now().UTC()
+	runStep.StartedAt = startedAt
+	runStep.FinishedAt = &now
+	if startedAt != nil {
+		runStep.Duration = now.Sub(*startedAt).Round(time.Millisecond).String()
+	}
+	runStep.Attempts = len(attempts)
+	if len(attempts) > 1 {
+		runStep.Retries = len(attempts) - 1
+	}
+	runStep.Output = output
+
+	// The error of the last attempt is kept for the steps that continued on error
+	lastErr := err
+	if lastErr == nil && len(attempts) > 0 {
+		lastErr = attempts[len(attempts)-1].err
+	}
+	switch {
+	case errors.Is(err, errWorkflowStepSkipped):
+		runStep.Status = WorkflowRunStatusSkipped
+		return
+	case err != nil:
+		runStep.Status = WorkflowRunStatusFailed
+	case lastErr != nil:
+		runStep.Status = WorkflowRunStatusContinued
+	default:
+		runStep.Status = WorkflowRunStatusSucceeded
+	}
+	if lastErr != nil {
+		runStep.Error = lastErr.Error()
+	}
+	if len(attempts) > 0 {
+		runStep.ExitCode = getWorkflowStepExitCode(attempts[len(attempts)-1].err)
+	}
+}
+
+// getWorkflowStepExitCode returns the exit code of a step command from its error, or nil if the command didn't exit.
+func getWorkflowStepExitCode(err error) *int {
+	if err == nil {
+		code := 0
+		return &code
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		code := exitErr.ExitCode()
+		return &code
+	}
+	if status, ok := interp.IsExitStatus(err); ok {
+		code := int(status)
+		return &code
+	}
+	return nil
+}
+
+// loadWorkflowRuns returns the runs recorded in the journal, the most recent run first.
+func loadWorkflowRuns(atmosConfig *schema.AtmosConfiguration) ([]schema.WorkflowRun, error) {
+	dir, err := getWorkflowJournalDir(atmosConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWorkflowJournal, err)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(files)))
+
+	runs := make([]schema.WorkflowRun, 0, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			log.Debug("Failed to read a workflow run from the journal", "path", file, "error", err)
+			continue
+		}
+		var run schema.WorkflowRun
+		if err := json.Unmarshal(data, &run); err != nil {
+			log.Debug("Failed to parse a workflow run from the journal", "path", file, "error", err)
+			continue
+		}
+		runs = append(runs, run)
+	}
+
+	// The names of the files only have the time of the runs to the second
+	sort.SliceStable(runs, func(i, j int) bool { return runs[i].StartedAt.After(runs[j].StartedAt) })
+	return runs, nil
+}
+
+// findWorkflowRunToResume returns the most recent run of a workflow recorded in the journal. The run must have been executed
+// with the same workflow manifest and, if the stack is specified, with the same stack.
+func findWorkflowRunToResume(atmosConfig *schema.AtmosConfiguration, workflow string, workflowPath string, stack string) (*schema.WorkflowRun, error) {
+	runs, err := loadWorkflowRuns(atmosConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	path := getWorkflowManifestAbsPath(workflowPath)
+	for i := range runs {
+		run := &runs[i]
+		if run.Workflow == workflow && run.Path == path && (stack == "" || run.Stack == stack) {
+			return run, nil
+		}
+	}
+	return nil, nil
+}
+
+// getWorkflowRunResumeSteps returns the steps to resume a workflow run from. A failed run is resumed from the steps recorded
+// when it failed. A run interrupted before it completed is resumed from the steps that didn't complete.
+func getWorkflowRunResumeSteps(run *schema.WorkflowRun) []string {
+	if run.Status == WorkflowRunStatusSucceeded {
+		return nil
+	}
+	if len(run.ResumeSteps) > 0 {
+		return run.ResumeSteps
+	}
+
+	var steps []string
+	for _, step := range run.Steps {
+		if step.Kind == "" && step.Status != WorkflowRunStatusSucceeded && step.Status != WorkflowRunStatusContinued && step.Status != WorkflowRunStatusSkipped {
+			steps = append(steps, step.Name)
+		}
+	}
+	return steps
+}
+
+// formatWorkflowRunDuration returns the duration of a workflow run, or an empty string if the run didn't finish.
+func formatWorkflowRunDuration(run *schema.WorkflowRun) string {
+	if run.FinishedAt == nil {
+		return ""
+	}
+	return run.FinishedAt.Sub(run.StartedAt).Round(time.Second).String()
+}
+
+// getWorkflowRunFailedSteps returns the names of the failed steps of a workflow run.
+func getWorkflowRunFailedSteps(run *schema.WorkflowRun) string {
+	var failed []string
+	for _, step := range run.Steps {
+		if step.Status == WorkflowRunStatusFailed {
+			failed = append(failed, step.Name)
+		}
+	}
+	return strings.Join(failed, ",")
+}
@@ -0,0 +1,230 @@
+package exec
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudposse/atmos/pkg/schema"
+)
+
+func TestGetWorkflowJournalDir(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", "/tmp/state")
+
+	// Each project has its own journal
+	dir, err := getWorkflowJournalDir(&schema.AtmosConfiguration{BasePath: "/repo/a"})
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("/tmp/state", "atmos", "workflows"), filepath.Dir(dir))
+	other, err := getWorkflowJournalDir(&schema.AtmosConfiguration{BasePath: "/repo/b"})
+	require.NoError(t, err)
+	assert.NotEqual(t, dir, other)
+	same, err := getWorkflowJournalDir(&schema.AtmosConfiguration{BasePath: "/repo/a/"})
+	require.NoError(t, err)
+	assert.Equal(t, dir, same)
+
+	atmosConfig := schema.AtmosConfiguration{Workflows: schema.Workflows{Journal: schema.WorkflowJournal{Path: "/tmp/journal"}}}
+	dir, err = getWorkflowJournalDir(&atmosConfig)
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/journal", dir)
+
+	// A relative path is relative to the base path of the project
+	relative := schema.AtmosConfiguration{BasePath: "/repo/a", Workflows: schema.Workflows{Journal: schema.WorkflowJournal{Path: ".atmos/workflows"}}}
+	dir, err = getWorkflowJournalDir(&relative)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("/repo/a", ".atmos", "workflows"), dir)
+
+	disabled := false
+	atmosConfig.Workflows.Journal.Enabled = &disabled
+	assert.False(t, isWorkflowJournalEnabled(&atmosConfig))
+	assert.Empty(t, newWorkflowJournal(&atmosConfig, "deploy", "workflows/test.yaml", "", "", nil, false).path)
+
+	// The dry runs are not written to the journal
+	atmosConfig.Workflows.Journal.Enabled = nil
+	assert.Empty(t, newWorkflowJournal(&atmosConfig, "deploy", "workflows/test.yaml", "", "", nil, true).path)
+}
+
+func TestExecuteWorkflow_Journal(t *testing.T) {
+	dir := t.TempDir()
+	marker := filepath.Join(dir, "marker")
+	atmosConfig := schema.AtmosConfiguration{
+		BasePath:  dir,
+		Workflows: schema.Workflows{Journal: schema.WorkflowJournal{Path: filepath.Join(dir, "journal")}},
+	}
+	workflowPath := filepath.Join(dir, "test.yaml")
+
+	// The `deploy` step fails until the marker file exists
+	workflowDefinition := &schema.WorkflowDefinition{
+		Steps: []schema.WorkflowStep{
+			{Name: "init", Type: "shell", Command: "exit 0"},
+			{Name: "optional", Type: "shell", Command: "exit 2", ContinueOnError: true},
+			{Name: "deploy", Type: "shell", Command: fmt.Sprintf("test -f %s || exit 3", marker)},
+			{Name: "verify", Type: "shell", Command: "exit 0"},
+		},
+	}
+
+	execute := func(fromStep string) error {
+		call := newWorkflowCall(&atmosConfig, workflowPath, "deploy")
+		call.journal = newWorkflowJournal(&atmosConfig, "deploy", workflowPath, "", fromStep, map[string]string{"env": "dev"}, false)
+		return executeWorkflow(atmosConfig, "deploy", workflowPath, workflowDefinition, false, "", fromStep, nil, call)
+	}
+
+	err := execute("")
+	assert.ErrorIs(t, err, ErrWorkflowStepFailed)
+
+	run, err := findWorkflowRunToResume(&atmosConfig, "deploy", workflowPath, "")
+	require.NoError(t, err)
+	require.NotNil(t, run)
+	assert.Equal(t, WorkflowRunStatusFailed, run.Status)
+	assert.Equal(t, map[string]string{"env": "dev"}, run.Inputs)
+	assert.Equal(t, []string{"deploy"}, run.ResumeSteps)
+	require.Len(t, run.Steps, 4)
+
+	statuses := []string{WorkflowRunStatusSucceeded, WorkflowRunStatusContinued, WorkflowRunStatusFailed, WorkflowRunStatusPending}
+	exitCodes := []int{0, 2, 3}
+	for i, step := range run.Steps {
+		assert.Equal(t, statuses[i], step.Status, step.Name)
+		if i < len(exitCodes) {
+			require.NotNil(t, step.ExitCode, step.Name)
+			assert.Equal(t, exitCodes[i], *step.ExitCode, step.Name)
+			assert.NotEmpty(t, step.Duration, step.Name)
+		}
+	}
+	assert.Equal(t, "deploy", getWorkflowRunFailedSteps(run))
+
+	// The run is resumed from the failed step
+	require.NoError(t, os.WriteFile(marker, nil, 0o644))
+	err = execute(getWorkflowRunResumeSteps(run)[0])
+	require.NoError(t, err)
+
+	run, err = findWorkflowRunToResume(&atmosConfig, "deploy", workflowPath, "")
+	require.NoError(t, err)
+	require.NotNil(t, run)
+	assert.Equal(t, WorkflowRunStatusSucceeded, run.Status)
+	assert.Equal(t, "deploy", run.FromStep)
+	assert.Len(t, run.Steps, 2)
+	assert.Empty(t, getWorkflowRunResumeSteps(run))
+
+	runs, err := loadWorkflowRuns(&atmosConfig)
+	require.NoError(t, err)
+	assert.Len(t, runs, 2)
+	assert.Len(t, filterWorkflowRuns(&atmosConfig, runs, "deploy", "", 1), 1)
+	assert.Empty(t, filterWorkflowRuns(&atmosConfig, runs, "destroy", "", 0))
+
+	// A run with a different stack is not resumed
+	run, err = findWorkflowRunToResume(&atmosConfig, "deploy", workflowPath, "prod")
+	require.NoError(t, err)
+	assert.Nil(t, run)
+
+	// A run of a manifest with the same relative path in another project sharing the journal is not resumed
+	otherProject := atmosConfig
+	otherProject.BasePath = filepath.Join(dir, "other")
+	otherWorkflowPath := filepath.Join(otherProject.BasePath, "test.yaml")
+	assert.Equal(t, getWorkflowFile(&atmosConfig, workflowPath), getWorkflowFile(&otherProject, otherWorkflowPath))
+	run, err = findWorkflowRunToResume(&otherProject, "deploy", otherWorkflowPath, "")
+	require.NoError(t, err)
+	assert.Nil(t, run)
+	assert.Len(t, filterWorkflowRuns(&atmosConfig, runs, "", "test.yaml", 0), 2)
+	assert.Empty(t, filterWorkflowRuns(&otherProject, runs, "", "test.yaml", 0))
+
+	// The journal is only readable by the user
+	info, err := os.Stat(filepath.Join(dir, "journal"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o700), info.Mode().Perm())
+	files, err := filepath.Glob(filepath.Join(dir, "journal", "*.json"))
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+	info, err = os.Stat(files[0])
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestGetWorkflowJournalInputs(t *testing.T) {
+	definitions := map[string]schema.WorkflowInput{
+		"environment": {},
+		"token":       {Sensitive: true},
+	}
+
+	assert.Equal(t, map[string]string{"environment": "dev"}, getWorkflowJournalInputs(definitions, map[string]string{"environment": "dev", "token": "s3cr3t"}))
+	assert.Nil(t, getWorkflowJournalInputs(definitions, map[string]string{"token": "s3cr3t"}))
+	assert.Nil(t, getWorkflowJournalInputs(definitions, nil))
+}
+
+func TestGetWorkflowRunResumeSteps(t *testing.T) {
+	// An interrupted run is resumed from the steps that didn't complete
+	run := &schema.WorkflowRun{
+		Status: WorkflowRunStatusRunning,
+		Steps: []schema.WorkflowRunStep{
+			{Name: "vpc", Status: WorkflowRunStatusSucceeded},
+			{Name: "eks", Status: WorkflowRunStatusRunning},
+			{Name: "rds", Status: WorkflowRunStatusContinued},
+			{Name: "apps", Status: WorkflowRunStatusPending},
+		},
+	}
+	assert.Equal(t, []string{"eks", "apps"}, getWorkflowRunResumeSteps(run))
+
+	run.Status = WorkflowRunStatusFailed
+	run.ResumeSteps = []string{"deploy/eks"}
+	assert.Equal(t, []string{"deploy/eks"}, getWorkflowRunResumeSteps(run))
+}
+
+func TestWorkflowJournal_Prune(t *testing.T) {
+	dir := t.TempDir()
+	atmosConfig := schema.AtmosConfiguration{
+		Workflows: schema.Workflows{Journal: schema.WorkflowJournal{Path: dir, MaxRuns: 2}},
+	}
+	for _, name := range []string{"20250101T000000Z-000001.json", "20250102T000000Z-000001.json", "20250103T000000Z-000001.json"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(`{}`), 0o644))
+	}
+
+	journal := newWorkflowJournal(&atmosConfig, "deploy", "test.yaml", "", "", nil, false)
+	require.NotNil(t, journal)
+	journal.start(&schema.WorkflowDefinition{}, nil, nil, "")
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	require.NoError(t, err)
+	assert.Len(t, files, 2)
+	assert.Contains(t, files, journal.path)
+	assert.Contains(t, files, filepath.Join(dir, "20250103T000000Z-000001.json"))
+}
+
+func TestExecuteWorkflow_JournalSensitiveInputs(t *testing.T) {
+	dir := t.TempDir()
+	atmosConfig := schema.AtmosConfiguration{
+		Workflows: schema.Workflows{Journal: schema.WorkflowJournal{Path: filepath.Join(dir, "journal")}},
+	}
+	workflowPath := filepath.Join(dir, "test.yaml")
+	workflowDefinition := &schema.WorkflowDefinition{
+		Inputs: map[string]schema.WorkflowInput{
+			"environment": {},
+			"token":       {Sensitive: true},
+		},
+		Steps: []schema.WorkflowStep{
+			{Name: "login", Type: "shell", Command: "echo {{ .inputs.environment }} {{ .inputs.token }}"},
+		},
+	}
+	inputValues := map[string]string{"environment": "dev", "token": "s3cr3t"}
+
+	call := newWorkflowCall(&atmosConfig, workflowPath, "deploy")
+	call.journal = newWorkflowJournal(&atmosConfig, "deploy", workflowPath, "", "", getWorkflowJournalInputs(workflowDefinition.Inputs, inputValues), false)
+	call.journal.outputLines = workflowReportOutputLines
+	require.NoError(t, executeWorkflow(atmosConfig, "deploy", workflowPath, workflowDefinition, false, "", "", inputValues, call))
+
+	run, err := findWorkflowRunToResume(&atmosConfig, "deploy", workflowPath, "")
+	require.NoError(t, err)
+	require.NotNil(t, run)
+	assert.Equal(t, map[string]string{"environment": "dev"}, run.Inputs)
+	require.Len(t, run.Steps, 1)
+	assert.Equal(t, "echo dev <redacted>", run.Steps[0].Command)
+	assert.Equal(t, "dev <redacted>", run.Steps[0].Output)
+
+	files, err := filepath.Glob(filepath.Join(dir, "journal", "*.json"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	content, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "s3cr3t")
+}
@@ -0,0 +1,132 @@
+package exec
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// prefixWriter prefixes each line written to it, so that the output of the workflow steps that run concurrently
+// can be told apart. The lines are written to the underlying writer whole, and the writes of all the prefixWriters
+// sharing the same mutex are serialized.
+type prefixWriter struct {
+	mu     *sync.Mutex
+	out    io.Writer
+	prefix []byte
+	buf    []byte
+}
+
+func newPrefixWriter(mu *sync.Mutex, out io.Writer, prefix string) *prefixWriter {
+	return &prefixWriter{mu: mu, out: out, prefix: []byte(prefix)}
+}
+
+// Write buffers the partial lines, and writes the complete lines with the prefix.
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+
+	i := bytes.LastIndexByte(w.buf, '\n')
+	if i < 0 {
+		return len(p), nil
+	}
+
+	lines := w.buf[:i+1]
+	if err := w.writeLines(lines); err != nil {
+		return 0, err
+	}
+	w.buf = append(w.buf[:0], w.buf[i+1:]...)
+	return len(p), nil
+}
+
+// Flush writes the last partial line, if any.
+func (w *prefixWriter) Flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	err := w.writeLines(append(w.buf, '\n'))
+	w.buf = w.buf[:0]
+	return err
+}
+
+func (w *prefixWriter) writeLines(lines []byte) error {
+	var out bytes.Buffer
+	for _, line := range bytes.SplitAfter(lines, []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		out.Write(w.prefix)
+		out.Write(line)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, err := w.out.Write(out.Bytes())
+	return err
+}
+
+// tailWriter keeps the last lines written to it, to show the end of the output of the workflow steps in the run reports.
+// It can be written to concurrently (e.g. by the standard output and error of a step). A nil tailWriter keeps nothing.
+type tailWriter struct {
+	mu    sync.Mutex
+	lines int
+	buf   []byte
+}
+
+// tailWriterMaxBytes limits the output kept by a tailWriter, since the lines can be arbitrarily long.
+const tailWriterMaxBytes = 16 * 1024
+
+func newTailWriter(lines int) *tailWriter {
+	return &tailWriter{lines: lines}
+}
+
+func (w *tailWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+	if len(w.buf) > tailWriterMaxBytes {
+		w.buf = append(w.buf[:0], w.buf[len(w.buf)-tailWriterMaxBytes:]...)
+	}
+	return len(p), nil
+}
+
+// String returns the last lines written, without the trailing newline.
+func (w *tailWriter) String() string {
+	if w == nil {
+		return ""
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	lines := bytes.Split(bytes.TrimRight(w.buf, "\n"), []byte("\n"))
+	if len(lines) > w.lines {
+		lines = lines[len(lines)-w.lines:]
+	}
+	return string(bytes.Join(lines, []byte("\n")))
+}
+
+// prefixWorkflowStepOutput returns the standard output and error of a step that runs concurrently with other steps,
+// whose lines are prefixed with the step name. The step can't read from the terminal.
+// The returned function writes the last line of the output, if it doesn't end with a newline.
+func prefixWorkflowStepOutput(mu *sync.Mutex, streams ShellIO, name string) (ShellIO, func()) {
+	prefix := fmt.Sprintf("[%s] ", name)
+	stdout := newPrefixWriter(mu, streams.Stdout, prefix)
+	stderr := newPrefixWriter(mu, streams.Stderr, prefix)
+	flush := func() {
+		_ = stderr.Flush()
+		_ = stdout.Flush()
+	}
+	return ShellIO{Stdout: stdout, Stderr: stderr}, flush
+}
+
+// teeWorkflowStepOutput returns the streams of a step whose standard output and error are also written to w.
+// A nil w keeps the streams.
+func teeWorkflowStepOutput(streams ShellIO, w *tailWriter) ShellIO {
+	if w == nil {
+		return streams
+	}
+	streams.Stdout = io.MultiWriter(streams.Stdout, w)
+	streams.Stderr = io.MultiWriter(streams.Stderr, w)
+	return streams
+}
@@ -0,0 +1,190 @@
+package exec
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cloudposse/atmos/pkg/schema"
+)
+
+// The formats of the workflow run reports.
+const (
+	WorkflowReportFormatJSON  = "json"
+	WorkflowReportFormatJUnit = "junit"
+)
+
+// workflowReportOutputLines is the number of the last lines of the output of each step included in the workflow run reports.
+const workflowReportOutputLines = 50
+
+// validateWorkflowReportFormat checks the format of the workflow run report.
+func validateWorkflowReportFormat(format string) error {
+	switch format {
+	case WorkflowReportFormatJSON, WorkflowReportFormatJUnit:
+		return nil
+	default:
+		return fmt.Errorf("%w: invalid '--report-format' flag '%s'. Valid values are '%s' and '%s'",
+			ErrWorkflowReport, format, WorkflowReportFormatJSON, WorkflowReportFormatJUnit)
+	}
+}
+
+// writeWorkflowReport writes the report of a workflow run to a file in the JSON or JUnit XML format.
+func writeWorkflowReport(run *schema.WorkflowRun, file string, format string) error {
+	var data []byte
+	var err error
+
+	switch format {
+	case WorkflowReportFormatJUnit:
+		data, err = xml.MarshalIndent(newJUnitTestSuites(run), "", "  ")
+		if err == nil {
+			data = append([]byte(xml.Header), data...)
+		}
+	default:
+		data, err = json.MarshalIndent(run, "", "  ")
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrWorkflowReport, err)
+	}
+
+	if dir := filepath.Dir(file); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("%w: %v", ErrWorkflowReport, err)
+		}
+	}
+	if err := os.WriteFile(file, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("%w: %v", ErrWorkflowReport, err)
+	}
+	return nil
+}
+
+// The JUnit XML report of a workflow run has one test suite for the workflow, with one test case for each step.
+
+type jUnitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	Name       string           `xml:"name,attr"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	Skipped    int              `xml:"skipped,attr"`
+	Time       string           `xml:"time,attr"`
+	TestSuites []jUnitTestSuite `xml:"testsuite"`
+}
+
+type jUnitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Skipped    int             `xml:"skipped,attr"`
+	Time       string          `xml:"time,attr"`
+	Timestamp  string          `xml:"timestamp,attr"`
+	Properties []jUnitProperty `xml:"properties>property,omitempty"`
+	TestCases  []jUnitTestCase `xml:"testcase"`
+}
+
+type jUnitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type jUnitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *jUnitMessage `xml:"failure,omitempty"`
+	Skipped   *jUnitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+	SystemErr string        `xml:"system-err,omitempty"`
+}
+
+type jUnitMessage struct {
+	Message string `xml:"message,attr,omitempty"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// newJUnitTestSuites returns the JUnit XML report of a workflow run. The failed steps are reported as failures,
+// and the steps that didn't run (or whose `if` condition was false) as skipped. The steps that failed with `continue_on_error` pass, with their error in `system-err`.
+func newJUnitTestSuites(run *schema.WorkflowRun) jUnitTestSuites {
+	suite := jUnitTestSuite{
+		Name:      fmt.Sprintf("%s:%s", run.File, run.Workflow),
+		Timestamp: run.StartedAt.Format(time.RFC3339),
+		Time:      formatJUnitTime(run.StartedAt, run.FinishedAt),
+	}
+
+	for _, property := range []jUnitProperty{
+		{Name: "workflow", Value: run.Workflow},
+		{Name: "file", Value: run.File},
+		{Name: "stack", Value: run.Stack},
+		{Name: "status", Value: run.Status},
+		{Name: "from_step", Value: run.FromStep},
+	} {
+		if property.Value != "" {
+			suite.Properties = append(suite.Properties, property)
+		}
+	}
+
+	for _, step := range run.Steps {
+		name := step.Name
+		if step.Kind != "" {
+			name = fmt.Sprintf("%s/%s", step.Kind, step.Name)
+		}
+		testCase := jUnitTestCase{
+			Name:      name,
+			ClassName: suite.Name,
+			Time:      formatJUnitTime(derefTime(step.StartedAt), step.FinishedAt),
+			SystemOut: step.Output,
+		}
+
+		switch step.Status {
+		case WorkflowRunStatusFailed:
+			var text strings.Builder
+			text.WriteString(step.Command)
+			if step.Attempts > 1 {
+				text.WriteString(fmt.Sprintf("\nThe step was attempted %d times", step.Attempts))
+			}
+			if step.ExitCode != nil {
+				text.WriteString(fmt.Sprintf("\nExit code: %d", *step.ExitCode))
+			}
+			testCase.Failure = &jUnitMessage{Message: step.Error, Type: step.Type, Text: text.String()}
+			suite.Failures++
+		case WorkflowRunStatusContinued:
+			testCase.SystemErr = step.Error
+		case WorkflowRunStatusSkipped:
+			testCase.Skipped = &jUnitMessage{Message: "the `if` condition of the step was false"}
+			suite.Skipped++
+		case WorkflowRunStatusPending, WorkflowRunStatusRunning:
+			testCase.Skipped = &jUnitMessage{Message: fmt.Sprintf("the step was %s when the workflow finished", step.Status)}
+			suite.Skipped++
+		}
+
+		suite.TestCases = append(suite.TestCases, testCase)
+		suite.Tests++
+	}
+
+	return jUnitTestSuites{
+		Name:       suite.Name,
+		Tests:      suite.Tests,
+		Failures:   suite.Failures,
+		Skipped:    suite.Skipped,
+		Time:       suite.Time,
+		TestSuites: []jUnitTestSuite{suite},
+	}
+}
+
+// formatJUnitTime returns the duration between the times in seconds, or `0` if the end time is not known.
+func formatJUnitTime(start time.Time, end *time.Time) string {
+	if end == nil || start.IsZero() {
+		return "0"
+	}
+	return fmt.Sprintf("%.3f", end.Sub(start).Seconds())
+}
+
+func derefTime(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
@@ -0,0 +1,98 @@
+package exec
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudposse/atmos/pkg/schema"
+)
+
+func TestTailWriter(t *testing.T) {
+	w := newTailWriter(2)
+	_, _ = w.Write([]byte("one\ntwo\n"))
+	_, _ = w.Write([]byte("three\n"))
+	assert.Equal(t, "two\nthree", w.String())
+
+	var nilWriter *tailWriter
+	assert.Empty(t, nilWriter.String())
+}
+
+func TestWriteWorkflowReport(t *testing.T) {
+	dir := t.TempDir()
+	atmosConfig := schema.AtmosConfiguration{}
+	workflowPath := filepath.Join(dir, "test.yaml")
+
+	workflowDefinition := &schema.WorkflowDefinition{
+		Steps: []schema.WorkflowStep{
+			{Name: "plan", Type: "shell", Command: "echo planned"},
+			{Name: "lint", Type: "shell", Command: "echo warning; exit 2", ContinueOnError: true},
+			{Name: "apply", Type: "shell", Command: "echo applying; exit 1", Retry: &schema.WorkflowStepRetry{Attempts: 2}},
+			{Name: "verify", Type: "shell", Command: "echo verified"},
+		},
+		Finally: []schema.WorkflowStep{{Name: "unlock", Type: "shell", Command: "echo unlocked"}},
+	}
+
+	call := newWorkflowCall(&atmosConfig, workflowPath, "deploy")
+	call.journal = newWorkflowJournal(&atmosConfig, "deploy", workflowPath, "", "", nil, false)
+	call.journal.path = ""
+	call.journal.outputLines = workflowReportOutputLines
+	err := executeWorkflow(atmosConfig, "deploy", workflowPath, workflowDefinition, false, "", "", nil, call)
+	assert.ErrorIs(t, err, ErrWorkflowStepFailed)
+	require.True(t, call.journal.started)
+
+	// The JSON report is the recorded run, with the end of the output of each step
+	jsonFile := filepath.Join(dir, "reports", "report.json")
+	require.NoError(t, writeWorkflowReport(&call.journal.run, jsonFile, WorkflowReportFormatJSON))
+	data, err := os.ReadFile(jsonFile)
+	require.NoError(t, err)
+	var run schema.WorkflowRun
+	require.NoError(t, json.Unmarshal(data, &run))
+	require.Len(t, run.Steps, 5)
+
+	assert.Equal(t, WorkflowRunStatusFailed, run.Status)
+	assert.Equal(t, "planned", run.Steps[0].Output)
+	assert.Equal(t, WorkflowRunStatusContinued, run.Steps[1].Status)
+	assert.Equal(t, WorkflowRunStatusFailed, run.Steps[2].Status)
+	assert.Equal(t, 1, run.Steps[2].Retries)
+	assert.Equal(t, "applying\napplying", run.Steps[2].Output)
+	assert.Equal(t, WorkflowRunStatusPending, run.Steps[3].Status)
+	assert.Equal(t, "finally", run.Steps[4].Kind)
+	assert.Equal(t, "unlocked", run.Steps[4].Output)
+
+	// The JUnit report has a test case for each step
+	junitFile := filepath.Join(dir, "report.xml")
+	require.NoError(t, writeWorkflowReport(&call.journal.run, junitFile, WorkflowReportFormatJUnit))
+	data, err = os.ReadFile(junitFile)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), xml.Header))
+
+	var suites jUnitTestSuites
+	require.NoError(t, xml.Unmarshal(data, &suites))
+	assert.Equal(t, 5, suites.Tests)
+	assert.Equal(t, 1, suites.Failures)
+	assert.Equal(t, 1, suites.Skipped)
+	require.Len(t, suites.TestSuites, 1)
+
+	testCases := suites.TestSuites[0].TestCases
+	require.Len(t, testCases, 5)
+	assert.Equal(t, workflowPath+":deploy", testCases[0].ClassName)
+	assert.Nil(t, testCases[1].Failure)
+	assert.NotEmpty(t, testCases[1].SystemErr)
+	require.NotNil(t, testCases[2].Failure)
+	assert.Contains(t, testCases[2].Failure.Text, "The step was attempted 2 times")
+	assert.Contains(t, testCases[2].Failure.Text, "Exit code: 1")
+	assert.NotNil(t, testCases[3].Skipped)
+	assert.Equal(t, "finally/unlock", testCases[4].Name)
+
+	assert.ErrorIs(t, validateWorkflowReportFormat("html"), ErrWorkflowReport)
+	assert.NoError(t, validateWorkflowReportFormat(WorkflowReportFormatJUnit))
+	assert.Equal(t, fmt.Sprintf("%.3f", 0.0), formatJUnitTime(run.StartedAt, &run.StartedAt))
+}
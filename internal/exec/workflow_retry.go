@@ -0,0 +1,288 @@
+package exec
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	log "github.com/charmbracelet/log"
+
+	"github.com/cloudposse/atmos/pkg/schema"
+)
+
+// The backoff strategies of the workflow step retries.
+const (
+	WorkflowRetryBackoffConstant    = "constant"
+	WorkflowRetryBackoffLinear      = "linear"
+	WorkflowRetryBackoffExponential = "exponential"
+)
+
+// workflowStepPolicy holds the parsed `retry` and `timeout` attributes of a workflow step.
+type workflowStepPolicy struct {
+	attempts int
+	delay    time.Duration
+	backoff  string
+	timeout  time.Duration
+}
+
+// workflowStepAttempt is the result of an attempt to execute a workflow step.
+type workflowStepAttempt struct {
+	err      error
+	duration time.Duration
+}
+
+// getWorkflowStepPolicy parses the `retry` and `timeout` attributes of a workflow step.
+func getWorkflowStepPolicy(step schema.WorkflowStep) (workflowStepPolicy, error) {
+	policy := workflowStepPolicy{attempts: 1, backoff: WorkflowRetryBackoffConstant}
+
+	if step.Timeout != "" {
+		timeout, err := time.ParseDuration(step.Timeout)
+		if err != nil || timeout <= 0 {
+			return policy, fmt.Errorf("%w: the step `%s` has an invalid `timeout` `%s`", ErrInvalidWorkflowStepRetry, step.Name, step.Timeout)
+		}
+		policy.timeout = timeout
+	}
+
+	if step.Retry == nil {
+		return policy, nil
+	}
+
+	if step.Retry.Attempts < 0 {
+		return policy, fmt.Errorf("%w: the step `%s` has an invalid number of `retry.attempts` `%d`", ErrInvalidWorkflowStepRetry, step.Name, step.Retry.Attempts)
+	}
+	if step.Retry.Attempts > 0 {
+		policy.attempts = step.Retry.Attempts
+	}
+
+	if step.Retry.Delay != "" {
+		delay, err := time.ParseDuration(step.Retry.Delay)
+		if err != nil || delay < 0 {
+			return policy, fmt.Errorf("%w: the step `%s` has an invalid `retry.delay` `%s`", ErrInvalidWorkflowStepRetry, step.Name, step.Retry.Delay)
+		}
+		policy.delay = delay
+	}
+
+	switch step.Retry.Backoff {
+	case "", WorkflowRetryBackoffConstant:
+	case WorkflowRetryBackoffLinear, WorkflowRetryBackoffExponential:
+		policy.backoff = step.Retry.Backoff
+	default:
+		return policy, fmt.Errorf("%w: the step `%s` has an invalid `retry.backoff` `%s`. Supported values: `constant`, `linear`, `exponential`",
+			ErrInvalidWorkflowStepRetry, step.Name, step.Retry.Backoff)
+	}
+
+	return policy, nil
+}
+
+// retryDelay returns the delay before the given retry (the first retry is 1).
+func (p workflowStepPolicy) retryDelay(retry int) time.Duration {
+	switch p.backoff {
+	case WorkflowRetryBackoffLinear:
+		return p.delay * time.Duration(retry)
+	case WorkflowRetryBackoffExponential:
+		return p.delay * time.Duration(math.Pow(2, float64(retry-1)))
+	default:
+		return p.delay
+	}
+}
+
+// executeWorkflowStepWithRetry executes a workflow step, retrying it according to the `retry` attribute of the step.
+// Each attempt is stopped after the `timeout` of the step. It returns the attempts, and the error of the last attempt.
+func executeWorkflowStepWithRetry(
+	policy workflowStepPolicy,
+	step schema.WorkflowStep,
+	execute func(ctx context.Context) error,
+) ([]workflowStepAttempt, error) {
+	var attempts []workflowStepAttempt
+
+	for attempt := 1; ; attempt++ {
+		start := time.Now()
+		err := executeWorkflowStepAttempt(policy, execute)
+		attempts = append(attempts, workflowStepAttempt{err: err, duration: time.Since(start)})
+
+		if err == nil || attempt >= policy.attempts {
+			return attempts, err
+		}
+
+		delay := policy.retryDelay(attempt)
+		log.Warn("Workflow step failed, retrying", "step", step.Name, "attempt", attempt, "attempts", policy.attempts, "delay", delay, "error", err)
+		time.Sleep(delay)
+	}
+}
+
+func executeWorkflowStepAttempt(policy workflowStepPolicy, execute func(ctx context.Context) error) error {
+	if policy.timeout == 0 {
+		return execute(context.Background())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), policy.timeout)
+	defer cancel()
+
+	err := execute(ctx)
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w after %s", ErrWorkflowStepTimeout, policy.timeout)
+	}
+	return err
+}
+
+// formatWorkflowStepAttempts formats the attempts to execute a workflow step as a markdown bullet list.
+func formatWorkflowStepAttempts(attempts []workflowStepAttempt) string {
+	var result strings.Builder
+	for i, attempt := range attempts {
+		status := "succeeded"
+		if attempt.err != nil {
+			status = fmt.Sprintf("failed: %v", attempt.err)
+		}
+		result.WriteString(fmt.Sprintf("- Attempt %d %s (%s)\n", i+1, status, attempt.duration.Round(time.Millisecond)))
+	}
+	return result.String()
+}
+
+// executeWorkflowStepWithPolicy executes a workflow step with its `retry`, `timeout` and `continue_on_error` attributes,
+// and records the outputs of the step in the workflow state.
+// It returns the step with its templates processed, the attempts to execute the step, and the error of the last attempt,
+// unless the step continues on error.
+func executeWorkflowStepWithPolicy(
+	atmosConfig schema.AtmosConfiguration,
+	commandName string,
+	workflowDefinition *schema.WorkflowDefinition,
+	step schema.WorkflowStep,
+	dryRun bool,
+	commandLineStack string,
+	streams ShellIO,
+	state *workflowState,
+) (schema.WorkflowStep, []workflowStepAttempt, error) {
+	// The policy is validated before the steps are executed
+	policy, err := getWorkflowStepPolicy(step)
+	if err != nil {
+		return step, nil, err
+	}
+
+	var attempts []workflowStepAttempt
+
+	// The step is skipped if its `if` condition is false
+	if err := checkWorkflowStepCondition(&atmosConfig, workflowDefinition, step, dryRun, commandLineStack, state); err != nil {
+		return step, nil, err
+	}
+
+	// In dry-run mode, the steps don't have outputs, so the missing template values are ignored
+	if state.templates {
+		step, err = renderWorkflowStep(step, state.templateData(step.Name), dryRun)
+	}
+
+	if err == nil {
+		// The standard output of the steps with `outputs` is captured, while still being written to the terminal
+		var stdout bytes.Buffer
+		attempts, err = executeWorkflowStepWithRetry(policy, step, func(ctx context.Context) error {
+			stdout.Reset()
+			stepStreams := streams
+			if len(step.Outputs) > 0 {
+				stepStreams.Stdout = io.MultiWriter(streams.Stdout, &stdout)
+			}
+
+			// The steps of the called workflows are logged with the names of the calling steps (e.g. `deploy/eks`)
+			logFunc := log.Debug
+			if dryRun {
+				logFunc = log.Info
+			}
+			logFunc("Executing workflow step", "name", state.call.stepName(step.Name), "command", strings.TrimSpace(step.Command))
+
+			switch getWorkflowStepType(step) {
+			case "workflow":
+				return executeCalledWorkflow(atmosConfig, step, dryRun, commandLineStack, stepStreams, state)
+			case "approval":
+				return executeWorkflowApprovalStep(ctx, step, dryRun, stepStreams, state)
+			}
+			return executeWorkflowStep(ctx, atmosConfig, commandName, workflowDefinition, step, dryRun, commandLineStack, stepStreams)
+		})
+
+		if err == nil && len(step.Outputs) > 0 && !dryRun {
+			var outputs map[string]any
+			if outputs, err = getWorkflowStepOutputs(&atmosConfig, step, stdout.String()); err == nil {
+				state.setOutputs(step.Name, outputs)
+			}
+		}
+	}
+
+	if err == nil {
+		state.setStatus(step.Name, WorkflowRunStatusSucceeded)
+		return step, attempts, nil
+	}
+
+	if step.ContinueOnError {
+		log.Warn("Workflow step failed, continuing the workflow", "step", state.call.stepName(step.Name), "error", err)
+		state.setStatus(step.Name, WorkflowRunStatusContinued)
+		return step, attempts, nil
+	}
+
+	log.Debug("Workflow step failed", "step", state.call.stepName(step.Name), "error", err)
+	state.setStatus(step.Name, WorkflowRunStatusFailed)
+	return step, attempts, err
+}
+
+// executeWorkflowCleanupSteps executes the `on_failure` or `finally` steps of a workflow sequentially.
+// All the steps are executed, even if some of them fail. It returns the descriptions of the failed steps.
+func executeWorkflowCleanupSteps(
+	atmosConfig schema.AtmosConfiguration,
+	workflow string,
+	kind string,
+	workflowDefinition *schema.WorkflowDefinition,
+	steps []schema.WorkflowStep,
+	dryRun bool,
+	commandLineStack string,
+	state *workflowState,
+) []string {
+	var failures []string
+
+	for i, step := range steps {
+		log.Debug("Executing workflow cleanup step", "kind", kind, "name", step.Name)
+
+		commandName := fmt.Sprintf("%s-%s-step-%d", workflow, kind, i)
+
+		output := state.call.journal.outputWriter()
+		streams := teeWorkflowStepOutput(state.call.streams, output)
+
+		startedAt := time.Now()
+		renderedStep, attempts, err := executeWorkflowStepWithPolicy(atmosConfig, commandName, workflowDefinition, step, dryRun, commandLineStack, streams, state)
+		state.call.journal.cleanupStepFinished(kind, workflowDefinition, renderedStep, commandLineStack, startedAt, attempts, err, output.String())
+		if err == nil || errors.Is(err, errWorkflowStepSkipped) {
+			continue
+		}
+
+		log.Error("Workflow cleanup step failed", "kind", kind, "step", state.call.stepName(step.Name), "error", err)
+
+		failedCmd := getWorkflowStepFailedCommand(step, getWorkflowStepStack(workflowDefinition, step, commandLineStack))
+		failure := fmt.Sprintf("`%s` step `%s`:\n```\n%s\n```\n", kind, state.call.stepName(step.Name), failedCmd)
+		if len(attempts) > 1 {
+			failure += fmt.Sprintf("The step was attempted %d times:\n%s", len(attempts), formatWorkflowStepAttempts(attempts))
+		}
+		failures = append(failures, failure)
+	}
+
+	return failures
+}
+
+// executeWorkflowCleanup executes the `on_failure` steps of a workflow if the workflow failed, and always executes
+// its `finally` steps. It returns the descriptions of the failed steps.
+func executeWorkflowCleanup(
+	atmosConfig schema.AtmosConfiguration,
+	workflow string,
+	workflowDefinition *schema.WorkflowDefinition,
+	onFailureSteps []schema.WorkflowStep,
+	finallySteps []schema.WorkflowStep,
+	failed bool,
+	dryRun bool,
+	commandLineStack string,
+	state *workflowState,
+) []string {
+	var failures []string
+	if failed {
+		failures = executeWorkflowCleanupSteps(atmosConfig, workflow, "on_failure", workflowDefinition, onFailureSteps, dryRun, commandLineStack, state)
+	}
+	return append(failures, executeWorkflowCleanupSteps(atmosConfig, workflow, "finally", workflowDefinition, finallySteps, dryRun, commandLineStack, state)...)
+}
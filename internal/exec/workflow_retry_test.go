@@ -0,0 +1,156 @@
+package exec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudposse/atmos/pkg/schema"
+)
+
+func TestGetWorkflowStepPolicy(t *testing.T) {
+	policy, err := getWorkflowStepPolicy(schema.WorkflowStep{Name: "vpc"})
+	require.NoError(t, err)
+	assert.Equal(t, workflowStepPolicy{attempts: 1, backoff: WorkflowRetryBackoffConstant}, policy)
+
+	policy, err = getWorkflowStepPolicy(schema.WorkflowStep{
+		Name:    "vpc",
+		Timeout: "10m",
+		Retry:   &schema.WorkflowStepRetry{Attempts: 3, Delay: "10s", Backoff: "exponential"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, workflowStepPolicy{attempts: 3, delay: 10 * time.Second, backoff: WorkflowRetryBackoffExponential, timeout: 10 * time.Minute}, policy)
+
+	invalid := []schema.WorkflowStep{
+		{Name: "vpc", Timeout: "soon"},
+		{Name: "vpc", Timeout: "-1s"},
+		{Name: "vpc", Retry: &schema.WorkflowStepRetry{Attempts: -1}},
+		{Name: "vpc", Retry: &schema.WorkflowStepRetry{Delay: "later"}},
+		{Name: "vpc", Retry: &schema.WorkflowStepRetry{Backoff: "random"}},
+	}
+	for _, step := range invalid {
+		_, err := getWorkflowStepPolicy(step)
+		assert.ErrorIs(t, err, ErrInvalidWorkflowStepRetry)
+	}
+}
+
+func TestWorkflowStepPolicyRetryDelay(t *testing.T) {
+	tests := []struct {
+		backoff  string
+		expected []time.Duration
+	}{
+		{backoff: WorkflowRetryBackoffConstant, expected: []time.Duration{10 * time.Second, 10 * time.Second, 10 * time.Second}},
+		{backoff: WorkflowRetryBackoffLinear, expected: []time.Duration{10 * time.Second, 20 * time.Second, 30 * time.Second}},
+		{backoff: WorkflowRetryBackoffExponential, expected: []time.Duration{10 * time.Second, 20 * time.Second, 40 * time.Second}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.backoff, func(t *testing.T) {
+			policy := workflowStepPolicy{delay: 10 * time.Second, backoff: tt.backoff}
+			for i, expected := range tt.expected {
+				assert.Equal(t, expected, policy.retryDelay(i+1))
+			}
+		})
+	}
+}
+
+func TestExecuteWorkflowStepWithRetry(t *testing.T) {
+	step := schema.WorkflowStep{Name: "vpc"}
+
+	// The step succeeds on the second attempt
+	calls := 0
+	attempts, err := executeWorkflowStepWithRetry(workflowStepPolicy{attempts: 3}, step, func(context.Context) error {
+		calls++
+		if calls == 1 {
+			return errors.New("flaky")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Len(t, attempts, 2)
+	assert.EqualError(t, attempts[0].err, "flaky")
+	assert.NoError(t, attempts[1].err)
+
+	// The step fails on all the attempts
+	attempts, err = executeWorkflowStepWithRetry(workflowStepPolicy{attempts: 2}, step, func(context.Context) error {
+		return errors.New("broken")
+	})
+	assert.EqualError(t, err, "broken")
+	assert.Len(t, attempts, 2)
+	history := formatWorkflowStepAttempts(attempts)
+	assert.Contains(t, history, "- Attempt 1 failed: broken")
+	assert.Contains(t, history, "- Attempt 2 failed: broken")
+
+	// The attempt is stopped after the timeout
+	attempts, err = executeWorkflowStepWithRetry(workflowStepPolicy{attempts: 1, timeout: 50 * time.Millisecond}, step, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	assert.ErrorIs(t, err, ErrWorkflowStepTimeout)
+	assert.Len(t, attempts, 1)
+}
+
+func TestExecuteWorkflow_RetriesAndCleanupSteps(t *testing.T) {
+	dir := t.TempDir()
+	marker := filepath.Join(dir, "marker")
+	logFile := filepath.Join(dir, "log")
+	atmosConfig := schema.AtmosConfiguration{}
+
+	// The `flaky` step fails on the first attempt, the `optional` step always fails but continues on error,
+	// and the `finally` steps run after the steps
+	workflowDefinition := &schema.WorkflowDefinition{
+		Steps: []schema.WorkflowStep{
+			{
+				Name:    "flaky",
+				Type:    "shell",
+				Command: fmt.Sprintf("if [ -f %[1]s ]; then exit 0; fi; echo > %[1]s; exit 1", marker),
+				Retry:   &schema.WorkflowStepRetry{Attempts: 2, Delay: "10ms"},
+			},
+			{Name: "optional", Type: "shell", Command: "exit 1", ContinueOnError: true},
+		},
+		OnFailure: []schema.WorkflowStep{
+			{Type: "shell", Command: fmt.Sprintf("echo on_failure >> %s", logFile)},
+		},
+		Finally: []schema.WorkflowStep{
+			{Type: "shell", Command: fmt.Sprintf("echo finally >> %s", logFile)},
+		},
+	}
+
+	err := ExecuteWorkflow(atmosConfig, "retries", "workflows/test.yaml", workflowDefinition, false, "", "", nil)
+	require.NoError(t, err)
+	content, err := os.ReadFile(logFile)
+	require.NoError(t, err)
+	assert.Equal(t, "finally\n", string(content))
+	assert.Equal(t, "on_failure_step1", workflowDefinition.OnFailure[0].Name)
+	assert.Equal(t, "finally_step1", workflowDefinition.Finally[0].Name)
+
+	// The `on_failure` and `finally` steps run when the workflow fails
+	require.NoError(t, os.Remove(logFile))
+	workflowDefinition.Steps = []schema.WorkflowStep{
+		{Name: "broken", Type: "shell", Command: "exit 1", Retry: &schema.WorkflowStepRetry{Attempts: 2}},
+		{Name: "skipped", Type: "shell", Command: fmt.Sprintf("echo skipped >> %s", logFile)},
+	}
+	err = ExecuteWorkflow(atmosConfig, "retries", "workflows/test.yaml", workflowDefinition, false, "", "", nil)
+	assert.ErrorIs(t, err, ErrWorkflowStepFailed)
+	content, err = os.ReadFile(logFile)
+	require.NoError(t, err)
+	assert.Equal(t, "on_failure\nfinally\n", string(content))
+
+	// The workflow fails if a `finally` step fails
+	workflowDefinition.Steps = []schema.WorkflowStep{{Name: "ok", Type: "shell", Command: "exit 0"}}
+	workflowDefinition.Finally = []schema.WorkflowStep{{Name: "release-lock", Type: "shell", Command: "exit 1"}}
+	err = ExecuteWorkflow(atmosConfig, "retries", "workflows/test.yaml", workflowDefinition, false, "", "", nil)
+	assert.ErrorIs(t, err, ErrWorkflowStepFailed)
+
+	// The retry policy is validated before the steps run
+	workflowDefinition.Steps = []schema.WorkflowStep{{Name: "ok", Type: "shell", Command: "exit 0", Timeout: "soon"}}
+	err = ExecuteWorkflow(atmosConfig, "retries", "workflows/test.yaml", workflowDefinition, false, "", "", nil)
+	assert.ErrorIs(t, err, ErrInvalidWorkflowStepRetry)
+}
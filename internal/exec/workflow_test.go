@@ -207,6 +207,47 @@ func TestExecuteWorkflow(t *testing.T) {
 			wantErr:          true,
 			errMsg:           "workflow step execution failed",
 		},
+		{
+			name:         "workflow with step dependencies",
+			workflow:     "dag-workflow",
+			workflowPath: workflowPath,
+			workflowDef: &schema.WorkflowDefinition{
+				MaxParallel: 2,
+				Steps: []schema.WorkflowStep{
+					{Name: "vpc", Type: "shell", Command: "echo vpc"},
+					{Name: "dns", Type: "shell", Command: "echo dns"},
+					{Name: "eks", Type: "shell", Command: "echo eks", Needs: []string{"vpc", "dns"}},
+				},
+			},
+			fromStep: "dns,vpc",
+			wantErr:  false,
+		},
+		{
+			name:         "workflow with invalid step dependencies",
+			workflow:     "dag-workflow-invalid",
+			workflowPath: workflowPath,
+			workflowDef: &schema.WorkflowDefinition{
+				Steps: []schema.WorkflowStep{
+					{Name: "vpc", Type: "shell", Command: "echo vpc", Needs: []string{"eks"}},
+					{Name: "eks", Type: "shell", Command: "echo eks", Needs: []string{"vpc"}},
+				},
+			},
+			wantErr: true,
+			errMsg:  "invalid workflow step dependencies",
+		},
+		{
+			name:         "failing step with dependencies",
+			workflow:     "dag-workflow-failing",
+			workflowPath: workflowPath,
+			workflowDef: &schema.WorkflowDefinition{
+				Steps: []schema.WorkflowStep{
+					{Name: "vpc", Type: "shell", Command: "exit 1"},
+					{Name: "eks", Type: "shell", Command: "echo eks", Needs: []string{"vpc"}},
+				},
+			},
+			wantErr: true,
+			errMsg:  "workflow step execution failed",
+		},
 	}
 
 	for _, tt := range tests {
@@ -219,6 +260,7 @@ func TestExecuteWorkflow(t *testing.T) {
 				tt.dryRun,
 				tt.commandLineStack,
 				tt.fromStep,
+				nil,
 			)
 
 			if tt.wantErr {
@@ -1,9 +1,11 @@
 package exec
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 
@@ -20,14 +22,27 @@ import (
 
 // Static error definitions.
 var (
-	WorkflowErrTitle           = "Workflow Error"
-	ErrWorkflowNoSteps         = errors.New("workflow has no steps defined")
-	ErrInvalidWorkflowStepType = errors.New("invalid workflow step type")
-	ErrInvalidFromStep         = errors.New("invalid from-step flag")
-	ErrWorkflowStepFailed      = errors.New("workflow step execution failed")
-	ErrWorkflowNoWorkflow      = errors.New("no workflow found")
-	ErrWorkflowFileNotFound    = errors.New("workflow file not found")
-	ErrInvalidWorkflowManifest = errors.New("invalid workflow manifest")
+	WorkflowErrTitle              = "Workflow Error"
+	ErrWorkflowNoSteps            = errors.New("workflow has no steps defined")
+	ErrInvalidWorkflowStepType    = errors.New("invalid workflow step type")
+	ErrInvalidFromStep            = errors.New("invalid from-step flag")
+	ErrWorkflowStepFailed         = errors.New("workflow step execution failed")
+	ErrWorkflowNoWorkflow         = errors.New("no workflow found")
+	ErrWorkflowFileNotFound       = errors.New("workflow file not found")
+	ErrInvalidWorkflowManifest    = errors.New("invalid workflow manifest")
+	ErrInvalidWorkflowStepNeeds   = errors.New("invalid workflow step dependencies")
+	ErrInvalidWorkflowStepRetry   = errors.New("invalid workflow step retry or timeout")
+	ErrWorkflowStepTimeout        = errors.New("workflow step timed out")
+	ErrInvalidWorkflowInput       = errors.New("invalid workflow input")
+	ErrWorkflowStepTemplate       = errors.New("failed to process the workflow step templates")
+	ErrWorkflowStepOutputs        = errors.New("failed to get the workflow step outputs")
+	ErrInvalidWorkflowStepForEach = errors.New("invalid workflow step for_each")
+	ErrInvalidWorkflowCall        = errors.New("invalid workflow call")
+	ErrWorkflowJournal            = errors.New("failed to access the workflow journal")
+	ErrWorkflowResume             = errors.New("failed to resume the workflow")
+	ErrWorkflowReport             = errors.New("failed to write the workflow run report")
+	ErrWorkflowStepCondition      = errors.New("failed to evaluate the workflow step condition")
+	ErrWorkflowStepNotApproved    = errors.New("workflow step was not approved")
 
 	KnownWorkflowErrors = []error{
 		ErrWorkflowNoSteps,
@@ -37,6 +52,19 @@ var (
 		ErrWorkflowNoWorkflow,
 		ErrWorkflowFileNotFound,
 		ErrInvalidWorkflowManifest,
+		ErrInvalidWorkflowStepNeeds,
+		ErrInvalidWorkflowStepRetry,
+		ErrWorkflowStepTimeout,
+		ErrInvalidWorkflowInput,
+		ErrWorkflowStepTemplate,
+		ErrWorkflowStepOutputs,
+		ErrInvalidWorkflowStepForEach,
+		ErrInvalidWorkflowCall,
+		ErrWorkflowJournal,
+		ErrWorkflowResume,
+		ErrWorkflowReport,
+		ErrWorkflowStepCondition,
+		ErrWorkflowStepNotApproved,
 	}
 )
 
@@ -51,6 +79,10 @@ func IsKnownWorkflowError(err error) bool {
 }
 
 // ExecuteWorkflow executes an Atmos workflow.
+// If the steps declare their dependencies with `needs`, the steps whose dependencies have completed run concurrently,
+// up to `max_parallel` steps at a time. Otherwise, the steps run sequentially.
+// The steps with `for_each` are expanded into one step for each item, which can run concurrently.
+// The steps of type `workflow` execute other workflows.
 func ExecuteWorkflow(
 	atmosConfig schema.AtmosConfiguration,
 	workflow string,
@@ -59,10 +91,29 @@ func ExecuteWorkflow(
 	dryRun bool,
 	commandLineStack string,
 	fromStep string,
+	inputValues map[string]string,
 ) error {
-	steps := workflowDefinition.Steps
+	return executeWorkflow(atmosConfig, workflow, workflowPath, workflowDefinition, dryRun, commandLineStack, fromStep, inputValues, nil)
+}
+
+// executeWorkflow executes a workflow. The call is nil for the workflow executed by `atmos workflow`,
+// or describes the step of type `workflow` that called the workflow.
+func executeWorkflow(
+	atmosConfig schema.AtmosConfiguration,
+	workflow string,
+	workflowPath string,
+	workflowDefinition *schema.WorkflowDefinition,
+	dryRun bool,
+	commandLineStack string,
+	fromStep string,
+	inputValues map[string]string,
+	call *workflowCall,
+) error {
+	if call == nil {
+		call = newWorkflowCall(&atmosConfig, workflowPath, workflow)
+	}
 
-	if len(steps) == 0 {
+	if len(workflowDefinition.Steps) == 0 {
 		errUtils.CheckErrorAndPrint(
 			ErrWorkflowNoSteps,
 			WorkflowErrTitle,
@@ -74,7 +125,7 @@ func ExecuteWorkflow(
 	// Check if the workflow steps have the `name` attribute
 	checkAndGenerateWorkflowStepNames(workflowDefinition)
 
-	log.Debug("Executing workflow", "workflow", workflow, "path", workflowPath)
+	log.Debug("Executing workflow", "workflow", workflow, "path", workflowPath, "step", call.stepPath)
 
 	if atmosConfig.Logs.Level == u.LogLevelTrace || atmosConfig.Logs.Level == u.LogLevelDebug {
 		err := u.PrintAsYAMLToFileDescriptor(&atmosConfig, workflowDefinition)
@@ -83,114 +134,309 @@ func ExecuteWorkflow(
 		}
 	}
 
-	// If `--from-step` is specified, skip all the previous steps
-	if fromStep != "" {
-		steps = lo.DropWhile[schema.WorkflowStep](steps, func(step schema.WorkflowStep) bool {
-			return step.Name != fromStep
-		})
+	// The steps declare their dependencies with `needs`, or run sequentially
+	parallel := lo.SomeBy(workflowDefinition.Steps, func(step schema.WorkflowStep) bool { return len(step.Needs) > 0 })
 
-		if len(steps) == 0 {
-			stepNames := lo.Map(workflowDefinition.Steps, func(step schema.WorkflowStep, _ int) string { return step.Name })
-			errUtils.CheckErrorAndPrint(
-				ErrInvalidFromStep,
-				WorkflowErrTitle,
-				fmt.Sprintf("\n## Explanation\nThe `--from-step` flag was set to `%s`, but this step does not exist in workflow `%s`. \n### Available steps:\n%s", fromStep, workflow, FormatList(stepNames)),
-			)
-			return ErrInvalidFromStep
+	expansion, onFailureExpansion, finallyExpansion, err := expandWorkflowDefinition(&atmosConfig, workflow, workflowDefinition, !parallel, commandLineStack)
+	if err != nil {
+		return err
+	}
+
+	// The steps of type `workflow` without a `file` call the workflows in the same manifest
+	workflowFile := getWorkflowFile(&atmosConfig, workflowPath)
+	steps := withWorkflowCallDefaults(expansion.steps, workflowFile)
+	onFailureSteps := withWorkflowCallDefaults(onFailureExpansion.steps, workflowFile)
+	finallySteps := withWorkflowCallDefaults(finallyExpansion.steps, workflowFile)
+
+	if err := checkWorkflowStepCalls(&atmosConfig, workflow, call, steps, onFailureSteps, finallySteps); err != nil {
+		return err
+	}
+
+	graph, err := buildWorkflowGraph(workflow, steps, parallel || len(expansion.expanded) > 0)
+	if err != nil {
+		return err
+	}
+
+	selected, calledFromSteps, err := selectWorkflowSteps(workflow, steps, graph, expansion.expanded, fromStep)
+	if err != nil {
+		return err
+	}
+	selectedSteps := lo.Filter(steps, func(_ schema.WorkflowStep, i int) bool { return selected[i] })
+
+	// Validate the steps to execute, and the `on_failure` and `finally` steps
+	if err := validateWorkflowSteps(workflow, slices.Concat(selectedSteps, onFailureSteps, finallySteps)); err != nil {
+		return err
+	}
+
+	inputs, err := resolveWorkflowDefinitionInputs(workflow, workflowDefinition, inputValues, call)
+	if err != nil {
+		return err
+	}
+	call.journal.setSensitiveInputs(workflowDefinition.Inputs, inputs)
+
+	state := newWorkflowState(workflowDefinition, inputs, lo.Assign(expansion.items, onFailureExpansion.items, finallyExpansion.items))
+	state.call = call
+	state.fromSteps = calledFromSteps
+	state.approvalOutputSteps = getWorkflowApprovalOutputSteps(steps, graph)
+	state.setPending(slices.Concat(steps, onFailureSteps, finallySteps))
+
+	if err := checkWorkflowApprovals(workflow, selectedSteps, dryRun, call); err != nil {
+		return err
+	}
+
+	if workflowDefinition, err = renderWorkflowStack(workflow, workflowDefinition, state, dryRun); err != nil {
+		return err
+	}
+
+	call.journal.start(workflowDefinition, steps, selected, commandLineStack)
+
+	maxParallel := getWorkflowMaxParallel(workflowDefinition, graph, parallel)
+	run, attempts := executeWorkflowGraph(atmosConfig, workflow, workflowDefinition, graph, selected, maxParallel, dryRun, commandLineStack, state)
+
+	cleanupFailures := executeWorkflowCleanup(atmosConfig, workflow, workflowDefinition, onFailureSteps, finallySteps, len(run.failed) > 0, dryRun, commandLineStack, state)
+
+	if len(run.failed) == 0 {
+		if len(cleanupFailures) == 0 {
+			call.journal.finish(WorkflowRunStatusSucceeded, nil)
+			return nil
 		}
+		call.journal.finish(WorkflowRunStatusFailed, nil)
+		return workflowCleanupFailed(cleanupFailures, call)
+	}
+
+	return workflowFailed(workflow, workflowPath, workflowDefinition, steps, expansion.items, run, attempts, cleanupFailures, commandLineStack, call)
+}
+
+// workflowCleanupFailed returns the error of a workflow whose steps succeeded, but whose `finally` steps failed.
+func workflowCleanupFailed(cleanupFailures []string, call *workflowCall) error {
+	explanation := fmt.Sprintf("The workflow steps succeeded, but the following `finally` steps failed to execute:\n%s", strings.Join(cleanupFailures, "\n"))
+	// A called workflow returns the failure to the calling workflow, which includes it in its error message
+	if call.stepPath != "" {
+		return &workflowFailure{details: fmt.Sprintf("The workflow called by the step `%s` failed. %s\n", call.stepPath, explanation)}
 	}
+	errUtils.CheckErrorAndPrint(
+		ErrWorkflowStepFailed,
+		WorkflowErrTitle,
+		"\n## Explanation\n"+explanation,
+	)
+	return ErrWorkflowStepFailed
+}
+
+// workflowFailed records the steps to resume a failed workflow from, and returns the error of the workflow with the failed commands,
+// the retry history of the failed steps, and the command to resume the workflow.
+func workflowFailed(
+	workflow string,
+	workflowPath string,
+	workflowDefinition *schema.WorkflowDefinition,
+	steps []schema.WorkflowStep,
+	items map[string]workflowForEachItem,
+	run *workflowRun,
+	attempts map[int][]workflowStepAttempt,
+	cleanupFailures []string,
+	commandLineStack string,
+	call *workflowCall,
+) error {
+	log.Debug("Workflow failed", "error", run.errors[run.failed[0]])
+
+	workflowFileName := filepath.Base(workflowPath)
+	workflowFileName = strings.TrimSuffix(workflowFileName, filepath.Ext(workflowFileName))
 
-	for stepIdx, step := range steps {
-		command := strings.TrimSpace(step.Command)
-		commandType := strings.TrimSpace(step.Type)
-		finalStack := ""
+	// The workflows called by the failed steps are resumed from their failed steps (e.g. `deploy/eks`)
+	resumeSteps := lo.FlatMap(run.resumeSteps, func(index int, _ int) []string {
+		if failure := getCalledWorkflowFailure(run.errors[index]); failure != nil && len(failure.resumeSteps) > 0 {
+			return lo.Map(failure.resumeSteps, func(name string, _ int) string { return steps[index].Name + "/" + name })
+		}
+		return []string{steps[index].Name}
+	})
+	call.journal.finish(WorkflowRunStatusFailed, resumeSteps)
+
+	resumeCommand := fmt.Sprintf(
+		"%s workflow %s -f %s --from-step %s",
+		config.AtmosCommand,
+		workflow,
+		workflowFileName,
+		strings.Join(resumeSteps, ","),
+	)
+
+	failedCmds := lo.FlatMap(run.failed, func(index int, _ int) []string {
+		if failure := getCalledWorkflowFailure(run.errors[index]); failure != nil && len(failure.failedCommands) > 0 {
+			return failure.failedCommands
+		}
+		return []string{getWorkflowStepFailedCommand(steps[index], getWorkflowStepStack(workflowDefinition, steps[index], commandLineStack))}
+	})
+
+	// The retry history of the failed steps that were attempted more than once, and the failed `on_failure` and `finally` steps
+	var details strings.Builder
+	for _, index := range run.failed {
+		if stepAttempts := attempts[index]; len(stepAttempts) > 1 {
+			details.WriteString(fmt.Sprintf("The step `%s` was attempted %d times:\n%s", call.stepName(steps[index].Name), len(stepAttempts), formatWorkflowStepAttempts(stepAttempts)))
+		}
+		if failure := getCalledWorkflowFailure(run.errors[index]); failure != nil {
+			details.WriteString(failure.details)
+		}
+	}
+	if len(cleanupFailures) > 0 {
+		details.WriteString(fmt.Sprintf("The following `on_failure` and `finally` steps also failed to execute:\n%s\n", strings.Join(cleanupFailures, "\n")))
+	}
 
-		log.Debug("Executing workflow step", "step", stepIdx, "name", step.Name, "command", command)
+	// A called workflow returns the failure to the calling workflow, which includes it in its error message
+	if call.stepPath != "" {
+		return &workflowFailure{failedCommands: failedCmds, resumeSteps: resumeSteps, details: details.String()}
+	}
 
-		if commandType == "" {
-			commandType = "atmos"
+	if len(failedCmds) == 1 {
+		// Add stack parameter to resume command if a stack was used.
+		// The stack selected by a `for_each` and the stack of a called workflow are not added,
+		// since they would override the stacks of all the steps
+		failedStep := steps[run.failed[0]]
+		resumeStack := getWorkflowStepStack(workflowDefinition, failedStep, commandLineStack)
+		if item, ok := items[failedStep.Name]; (ok && item.stack) || getWorkflowStepType(failedStep) == "workflow" {
+			resumeStack = commandLineStack
+		}
+		if resumeStack != "" {
+			resumeCommand = fmt.Sprintf("%s -s %s", resumeCommand, resumeStack)
 		}
 
-		var err error
-		if commandType == "shell" {
-			commandName := fmt.Sprintf("%s-step-%d", workflow, stepIdx)
-			err = ExecuteShell(atmosConfig, command, commandName, ".", []string{}, dryRun)
-		} else if commandType == "atmos" {
-			args := strings.Fields(command)
-
-			workflowStack := strings.TrimSpace(workflowDefinition.Stack)
-			stepStack := strings.TrimSpace(step.Stack)
-
-			// The workflow `stack` attribute overrides the stack in the `command` (if specified)
-			// The step `stack` attribute overrides the stack in the `command` and the workflow `stack` attribute
-			// The stack defined on the command line (`atmos workflow <name> -f <file> -s <stack>`) has the highest priority,
-			// it overrides all other stacks attributes
-			if workflowStack != "" {
-				finalStack = workflowStack
-			}
-			if stepStack != "" {
-				finalStack = stepStack
-			}
-			if commandLineStack != "" {
-				finalStack = commandLineStack
-			}
-
-			if finalStack != "" {
-				args = append(args, []string{"-s", finalStack}...)
-				log.Debug("Using stack", "stack", finalStack)
-			}
-
-			u.PrintfMessageToTUI("Executing command: `atmos %s`\n", command)
-			err = ExecuteShellCommand(atmosConfig, "atmos", args, ".", []string{}, dryRun, "")
-		} else {
+		errUtils.CheckErrorAndPrint(
+			ErrWorkflowStepFailed,
+			WorkflowErrTitle,
+			fmt.Sprintf("\n## Explanation\nThe following command failed to execute:\n```\n%s\n```\n%sTo resume the workflow from this step, run:\n```\n%s\n```", failedCmds[0], details.String(), resumeCommand),
+		)
+		return ErrWorkflowStepFailed
+	}
+
+	if commandLineStack != "" {
+		resumeCommand = fmt.Sprintf("%s -s %s", resumeCommand, commandLineStack)
+	}
+
+	errUtils.CheckErrorAndPrint(
+		ErrWorkflowStepFailed,
+		WorkflowErrTitle,
+		fmt.Sprintf("\n## Explanation\nThe following commands failed to execute:\n```\n%s\n```\n%sTo resume the workflow from these steps, run:\n```\n%s\n```", strings.Join(failedCmds, "\n"), details.String(), resumeCommand),
+	)
+	return ErrWorkflowStepFailed
+}
+
+// validateWorkflowSteps checks the types and the `retry` and `timeout` attributes of the workflow steps.
+func validateWorkflowSteps(workflow string, steps []schema.WorkflowStep) error {
+	for _, step := range steps {
+		commandType := getWorkflowStepType(step)
+		if commandType != "atmos" && commandType != "shell" && commandType != "workflow" && commandType != "approval" {
 			errUtils.CheckErrorAndPrint(
 				ErrInvalidWorkflowStepType,
 				WorkflowErrTitle,
-				fmt.Sprintf("\n## Explanation\nStep type `%s` is not supported. Each step must specify a valid type. \n### Available types:\n%s", commandType, FormatList([]string{"atmos", "shell"})),
+				fmt.Sprintf("\n## Explanation\nStep type `%s` is not supported. Each step must specify a valid type. \n### Available types:\n%s", commandType, FormatList([]string{"atmos", "shell", "workflow", "approval"})),
 			)
 			return ErrInvalidWorkflowStepType
 		}
 
-		if err != nil {
-			log.Debug("Workflow failed", "error", err)
-
-			workflowFileName := filepath.Base(workflowPath)
-			workflowFileName = strings.TrimSuffix(workflowFileName, filepath.Ext(workflowFileName))
-
-			resumeCommand := fmt.Sprintf(
-				"%s workflow %s -f %s --from-step %s",
-				config.AtmosCommand,
-				workflow,
-				workflowFileName,
-				step.Name,
+		// The steps of the called workflows have their own timeouts
+		if commandType == "workflow" && strings.TrimSpace(step.Timeout) != "" {
+			errUtils.CheckErrorAndPrint(
+				ErrInvalidWorkflowStepRetry,
+				WorkflowErrTitle,
+				fmt.Sprintf("\n## Explanation\nThe step `%s` of workflow `%s` is invalid: the steps of type `workflow` can't have a `timeout`. Set the `timeout` of the steps of the called workflow instead", step.Name, workflow),
 			)
+			return ErrInvalidWorkflowStepRetry
+		}
 
-			// Add stack parameter to resume command if a stack was used
-			if finalStack != "" {
-				resumeCommand = fmt.Sprintf("%s -s %s", resumeCommand, finalStack)
-			}
-
-			failedCmd := command
-			if commandType == config.AtmosCommand {
-				failedCmd = config.AtmosCommand + " " + command
-				// Add stack parameter to failed command if a stack was used
-				if finalStack != "" {
-					failedCmd = fmt.Sprintf("%s -s %s", failedCmd, finalStack)
-				}
-			}
-
+		if _, err := getWorkflowStepPolicy(step); err != nil {
 			errUtils.CheckErrorAndPrint(
-				ErrWorkflowStepFailed,
+				ErrInvalidWorkflowStepRetry,
 				WorkflowErrTitle,
-				fmt.Sprintf("\n## Explanation\nThe following command failed to execute:\n```\n%s\n```\nTo resume the workflow from this step, run:\n```\n%s\n```", failedCmd, resumeCommand),
+				fmt.Sprintf("\n## Explanation\nThe step `%s` of workflow `%s` is invalid: %s", step.Name, workflow, strings.TrimPrefix(err.Error(), ErrInvalidWorkflowStepRetry.Error()+": ")),
 			)
-			return ErrWorkflowStepFailed
+			return ErrInvalidWorkflowStepRetry
 		}
 	}
-
 	return nil
 }
 
+// executeWorkflowStep executes a step of a workflow with the given standard streams.
+// The step is stopped if the context is done before the step completes.
+func executeWorkflowStep(
+	ctx context.Context,
+	atmosConfig schema.AtmosConfiguration,
+	commandName string,
+	workflowDefinition *schema.WorkflowDefinition,
+	step schema.WorkflowStep,
+	dryRun bool,
+	commandLineStack string,
+	streams ShellIO,
+) error {
+	command := strings.TrimSpace(step.Command)
+	env := getWorkflowStepEnv(step)
+
+	if getWorkflowStepType(step) == "shell" {
+		return ExecuteShellWithContext(ctx, atmosConfig, command, commandName, ".", env, dryRun, &streams)
+	}
+
+	args := strings.Fields(command)
+
+	if finalStack := getWorkflowStepStack(workflowDefinition, step, commandLineStack); finalStack != "" {
+		args = append(args, []string{"-s", finalStack}...)
+		log.Debug("Using stack", "stack", finalStack)
+	}
+
+	_, _ = fmt.Fprintf(streams.Stderr, "Executing command: `atmos %s`\n", command)
+	return ExecuteShellCommandWithContext(ctx, atmosConfig, "atmos", args, ".", env, dryRun, "", &streams)
+}
+
+// getWorkflowStepType returns the type of a workflow step. The default type is `atmos`.
+func getWorkflowStepType(step schema.WorkflowStep) string {
+	commandType := strings.TrimSpace(step.Type)
+	if commandType == "" {
+		return "atmos"
+	}
+	return commandType
+}
+
+// getWorkflowStepStack returns the stack of an `atmos` workflow step, or the stack of the workflow called by a `workflow` step.
+// The workflow `stack` attribute overrides the stack in the `command` (if specified)
+// The step `stack` attribute overrides the stack in the `command` and the workflow `stack` attribute
+// The stack defined on the command line (`atmos workflow <name> -f <file> -s <stack>`) has the highest priority,
+// it overrides all other stacks attributes
+func getWorkflowStepStack(workflowDefinition *schema.WorkflowDefinition, step schema.WorkflowStep, commandLineStack string) string {
+	switch getWorkflowStepType(step) {
+	case "workflow":
+		return getWorkflowCallStack(step, commandLineStack)
+	case "atmos":
+	default:
+		return ""
+	}
+
+	finalStack := ""
+	if workflowStack := strings.TrimSpace(workflowDefinition.Stack); workflowStack != "" {
+		finalStack = workflowStack
+	}
+	if stepStack := strings.TrimSpace(step.Stack); stepStack != "" {
+		finalStack = stepStack
+	}
+	if commandLineStack != "" {
+		finalStack = commandLineStack
+	}
+	return finalStack
+}
+
+// getWorkflowStepFailedCommand returns the command of a failed workflow step, as shown in the error message.
+func getWorkflowStepFailedCommand(step schema.WorkflowStep, finalStack string) string {
+	command := strings.TrimSpace(step.Command)
+	switch getWorkflowStepType(step) {
+	case "workflow":
+		return getWorkflowCallCommand(step, finalStack)
+	case config.AtmosCommand:
+	default:
+		return command
+	}
+
+	failedCmd := config.AtmosCommand + " " + command
+	// Add stack parameter to failed command if a stack was used
+	if finalStack != "" {
+		failedCmd = fmt.Sprintf("%s -s %s", failedCmd, finalStack)
+	}
+	return failedCmd
+}
+
 // FormatList formats a list of strings into a markdown bullet list.
 func FormatList(items []string) string {
 	var result strings.Builder
@@ -272,6 +518,7 @@ func ExecuteDescribeWorkflows(
 			listResult = append(listResult, schema.DescribeWorkflowsItem{
 				File:     k,
 				Workflow: w,
+				Calls:    getWorkflowCalls(allResult[k].Workflows[w], k),
 			})
 		}
 	}
@@ -280,14 +527,14 @@ func ExecuteDescribeWorkflows(
 }
 
 func checkAndGenerateWorkflowStepNames(workflowDefinition *schema.WorkflowDefinition) {
-	steps := workflowDefinition.Steps
-
-	if steps == nil {
-		return
-	}
+	generateWorkflowStepNames(workflowDefinition.Steps, "step")
+	generateWorkflowStepNames(workflowDefinition.OnFailure, "on_failure_step")
+	generateWorkflowStepNames(workflowDefinition.Finally, "finally_step")
+}
 
+func generateWorkflowStepNames(steps []schema.WorkflowStep, prefix string) {
 	// Check if the steps have the `name` attribute.
-	// If not, generate a friendly name consisting of a prefix of `step` and followed by the index of the
+	// If not, generate a friendly name consisting of a prefix (e.g. `step`) and followed by the index of the
 	// step (the index starts with 1, so the first generated step name would be `step1`)
 	for index, step := range steps {
 		if step.Name == "" {
@@ -297,7 +544,7 @@ func checkAndGenerateWorkflowStepNames(workflowDefinition *schema.WorkflowDefini
 			// So doing changes to it will not affect the original elements.
 			// We need to access the element with the index returned from the range iterator and change it there.
 			// https://medium.com/@nsspathirana/common-mistakes-with-go-slices-95f2e9b362a9
-			steps[index].Name = fmt.Sprintf("step%d", index+1)
+			steps[index].Name = fmt.Sprintf("%s%d", prefix, index+1)
 		}
 	}
 }
@@ -0,0 +1,243 @@
+package terraform_backend
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	log "github.com/charmbracelet/log"
+
+	errUtils "github.com/cloudposse/atmos/errors"
+	"github.com/cloudposse/atmos/pkg/schema"
+)
+
+const (
+	// azurermDefaultKey is the default name of the state blob when `key` is not set in the backend config.
+	azurermDefaultKey = "terraform.tfstate"
+	// azurermWorkspaceKeySuffix is appended to the key, followed by the workspace name, for non-default workspaces.
+	// https://developer.hashicorp.com/terraform/language/backend/azurerm#key
+	azurermWorkspaceKeySuffix = "env:"
+)
+
+// azurermStorageSuffixes maps the Azure environments to the storage endpoint suffixes.
+var azurermStorageSuffixes = map[string]string{
+	"public":       "core.windows.net",
+	"usgovernment": "core.usgovcloudapi.net",
+	"china":        "core.chinacloudapi.cn",
+}
+
+// AzureBlobAPI defines an interface for downloading blobs from an Azure Storage container.
+type AzureBlobAPI interface {
+	DownloadStream(ctx context.Context, container string, blob string) (io.ReadCloser, error)
+}
+
+// azureBlobClient implements AzureBlobAPI with the Azure Blob Storage client.
+type azureBlobClient struct {
+	client *azblob.Client
+}
+
+func (c *azureBlobClient) DownloadStream(ctx context.Context, container string, blob string) (io.ReadCloser, error) {
+	response, err := c.client.DownloadStream(ctx, container, blob, nil)
+	if err != nil {
+		return nil, err
+	}
+	return response.Body, nil
+}
+
+// NewAzureBlobClient wraps an Azure Blob Storage client into an AzureBlobAPI.
+func NewAzureBlobClient(client *azblob.Client) AzureBlobAPI {
+	return &azureBlobClient{client: client}
+}
+
+// azureBlobClientCache caches the Azure Blob Storage clients based on a deterministic cache key.
+// It's a map[string]AzureBlobAPI.
+var azureBlobClientCache sync.Map
+
+// getAzurermBackendAttribute returns an attribute from the azurerm backend config.
+// Like Terraform, it falls back to the given `ARM_*` ENV var.
+func getAzurermBackendAttribute(backend *map[string]any, attribute string, envVar string) string {
+	if value := GetBackendAttribute(backend, attribute); value != "" {
+		return value
+	}
+	return os.Getenv(envVar)
+}
+
+// getAzurermBackendBool returns a boolean attribute from the azurerm backend config, falling back to the given ENV var.
+func getAzurermBackendBool(backend *map[string]any, attribute string, envVar string) bool {
+	if value, ok := (*backend)[attribute].(bool); ok {
+		return value
+	}
+	return strings.EqualFold(os.Getenv(envVar), "true")
+}
+
+// GetAzurermBackendServiceURL returns the URL of the Blob service of the storage account from the azurerm backend config.
+// The `endpoint` attribute overrides the URL, e.g. to use the Azurite emulator.
+func GetAzurermBackendServiceURL(backend *map[string]any) string {
+	if endpoint := getAzurermBackendAttribute(backend, "endpoint", "ARM_STORAGE_ENDPOINT"); endpoint != "" {
+		return strings.TrimSuffix(endpoint, "/") + "/"
+	}
+
+	suffix, ok := azurermStorageSuffixes[strings.ToLower(getAzurermBackendAttribute(backend, "environment", "ARM_ENVIRONMENT"))]
+	if !ok {
+		suffix = azurermStorageSuffixes["public"]
+	}
+	return fmt.Sprintf("https://%s.blob.%s/", GetBackendAttribute(backend, "storage_account_name"), suffix)
+}
+
+// GetAzurermBackendBlobName returns the name of the state blob of a workspace.
+// The state of the default workspace is stored in `key`, and the state of the other workspaces in `key` + `env:<workspace>`.
+func GetAzurermBackendBlobName(backend *map[string]any, workspace string) string {
+	key := GetBackendAttribute(backend, "key")
+	if key == "" {
+		key = azurermDefaultKey
+	}
+	if workspace == "" || workspace == "default" {
+		return key
+	}
+	return key + azurermWorkspaceKeySuffix + workspace
+}
+
+// getAzurermTokenCredential returns the Azure AD credential from the azurerm backend config.
+// A service principal with a client secret is used if configured, otherwise the default Azure credential chain
+// (ENV vars, workload identity, managed identity, Azure CLI).
+func getAzurermTokenCredential(backend *map[string]any) (azcore.TokenCredential, error) {
+	tenantID := getAzurermBackendAttribute(backend, "tenant_id", "ARM_TENANT_ID")
+	clientID := getAzurermBackendAttribute(backend, "client_id", "ARM_CLIENT_ID")
+	clientSecret := getAzurermBackendAttribute(backend, "client_secret", "ARM_CLIENT_SECRET")
+
+	if tenantID != "" && clientID != "" && clientSecret != "" {
+		return azidentity.NewClientSecretCredential(tenantID, clientID, clientSecret, nil)
+	}
+
+	if getAzurermBackendBool(backend, "use_msi", "ARM_USE_MSI") {
+		options := &azidentity.ManagedIdentityCredentialOptions{}
+		if clientID != "" {
+			options.ID = azidentity.ClientID(clientID)
+		}
+		return azidentity.NewManagedIdentityCredential(options)
+	}
+
+	return azidentity.NewDefaultAzureCredential(&azidentity.DefaultAzureCredentialOptions{TenantID: tenantID})
+}
+
+// newAzureBlobClient creates an Azure Blob Storage client from the azurerm backend config.
+// Like Terraform, it authenticates with a SAS token or an access key if provided, and with Azure AD otherwise.
+func newAzureBlobClient(backend *map[string]any) (*azblob.Client, error) {
+	serviceURL := GetAzurermBackendServiceURL(backend)
+
+	if sasToken := getAzurermBackendAttribute(backend, "sas_token", "ARM_SAS_TOKEN"); sasToken != "" {
+		return azblob.NewClientWithNoCredential(serviceURL+"?"+strings.TrimPrefix(sasToken, "?"), nil)
+	}
+
+	accessKey := getAzurermBackendAttribute(backend, "access_key", "ARM_ACCESS_KEY")
+	if accessKey != "" && !getAzurermBackendBool(backend, "use_azuread_auth", "ARM_USE_AZUREAD") {
+		credential, err := azblob.NewSharedKeyCredential(GetBackendAttribute(backend, "storage_account_name"), accessKey)
+		if err != nil {
+			return nil, err
+		}
+		return azblob.NewClientWithSharedKeyCredential(serviceURL, credential, nil)
+	}
+
+	credential, err := getAzurermTokenCredential(backend)
+	if err != nil {
+		return nil, err
+	}
+	return azblob.NewClient(serviceURL, credential, nil)
+}
+
+func getCachedAzureBlobClient(backend *map[string]any) (AzureBlobAPI, error) {
+	// Build a deterministic cache key. The secrets are hashed, so that they are not kept in the key.
+	cacheKey := fmt.Sprintf("service_url=%s;sas_token=%x;access_key=%x;use_azuread_auth=%t;tenant_id=%s;client_id=%s;client_secret=%x;use_msi=%t",
+		GetAzurermBackendServiceURL(backend),
+		sha256.Sum256([]byte(getAzurermBackendAttribute(backend, "sas_token", "ARM_SAS_TOKEN"))),
+		sha256.Sum256([]byte(getAzurermBackendAttribute(backend, "access_key", "ARM_ACCESS_KEY"))),
+		getAzurermBackendBool(backend, "use_azuread_auth", "ARM_USE_AZUREAD"),
+		getAzurermBackendAttribute(backend, "tenant_id", "ARM_TENANT_ID"),
+		getAzurermBackendAttribute(backend, "client_id", "ARM_CLIENT_ID"),
+		sha256.Sum256([]byte(getAzurermBackendAttribute(backend, "client_secret", "ARM_CLIENT_SECRET"))),
+		getAzurermBackendBool(backend, "use_msi", "ARM_USE_MSI"),
+	)
+
+	// Check the cache
+	if cached, ok := azureBlobClientCache.Load(cacheKey); ok {
+		return cached.(AzureBlobAPI), nil
+	}
+
+	// Build the Azure Blob Storage client if not cached
+	client, err := newAzureBlobClient(backend)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errUtils.ErrCreateAzureBlobClient, err)
+	}
+
+	azureClient := NewAzureBlobClient(client)
+	azureBlobClientCache.Store(cacheKey, azureClient)
+	return azureClient, nil
+}
+
+// ReadTerraformBackendAzurerm reads the Terraform state file from the configured azurerm backend.
+// If the state file does not exist in the container, the function returns `nil`.
+func ReadTerraformBackendAzurerm(
+	_ *schema.AtmosConfiguration,
+	componentSections *map[string]any,
+) ([]byte, error) {
+	backend := GetComponentBackend(componentSections)
+
+	azureClient, err := getCachedAzureBlobClient(&backend)
+	if err != nil {
+		return nil, err
+	}
+
+	return ReadTerraformBackendAzurermInternal(azureClient, componentSections, &backend)
+}
+
+// ReadTerraformBackendAzurermInternal accepts an Azure Blob Storage client and reads the Terraform state file from the configured azurerm backend.
+func ReadTerraformBackendAzurermInternal(
+	azureClient AzureBlobAPI,
+	componentSections *map[string]any,
+	backend *map[string]any,
+) ([]byte, error) {
+	blobName := GetAzurermBackendBlobName(backend, GetTerraformWorkspace(componentSections))
+	container := GetBackendAttribute(backend, "container_name")
+
+	var lastErr error
+	for attempt := 0; attempt <= maxRetryCount; attempt++ {
+		// 30 sec timeout to read the state file from the storage container.
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		body, err := azureClient.DownloadStream(ctx, container, blobName)
+		if err != nil {
+			// If the state file does not exist (the component in the stack has not been provisioned yet), return a `nil` result and no error.
+			if bloberror.HasCode(err, bloberror.BlobNotFound) {
+				log.Debug("Terraform state file doesn't exist in the Azure storage container; returning 'null'", "file", blobName, "container", container)
+				return nil, nil
+			}
+
+			lastErr = err
+			if attempt < maxRetryCount {
+				log.Debug("Failed to read Terraform state file from the Azure storage container", "attempt", attempt+1, "file", blobName, "container", container, "error", err)
+				time.Sleep(time.Second * 2) // backoff
+				continue
+			}
+			return nil, fmt.Errorf("%w: %v", errUtils.ErrGetBlobFromAzure, lastErr)
+		}
+
+		content, err := io.ReadAll(body)
+		_ = body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errUtils.ErrReadAzureBlobBody, err)
+		}
+		return content, nil
+	}
+
+	return nil, fmt.Errorf("%w: %v", errUtils.ErrGetBlobFromAzure, lastErr)
+}
@@ -0,0 +1,217 @@
+package terraform_backend_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errUtils "github.com/cloudposse/atmos/errors"
+	tb "github.com/cloudposse/atmos/internal/terraform_backend"
+)
+
+const (
+	// The well-known account name and key of the Azurite emulator.
+	azuriteAccountName = "devstoreaccount1"
+	azuriteAccountKey  = "Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw=="
+)
+
+// newFakeAzuriteServer starts a fake Azurite server that serves the given blobs, keyed by `<container>/<blob>`.
+func newFakeAzuriteServer(t *testing.T, blobs map[string]string) (string, *[]*http.Request) {
+	var requests []*http.Request
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+
+		blobPath, err := url.PathUnescape(strings.TrimPrefix(r.URL.EscapedPath(), "/"+azuriteAccountName+"/"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		content, ok := blobs[blobPath]
+		if !ok {
+			w.Header().Set("x-ms-error-code", "BlobNotFound")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(content))
+	}))
+	t.Cleanup(server.Close)
+
+	return server.URL + "/" + azuriteAccountName, &requests
+}
+
+func TestReadTerraformBackendAzurermInternal(t *testing.T) {
+	state := `{"version": 4, "terraform_version": "1.5.7", "outputs": {"vnet_id": {"value": "vnet-123", "type": "string"}}}`
+
+	tests := []struct {
+		name              string
+		componentSections map[string]any
+		key               string
+		expectedPath      string
+		expectedContent   string
+	}{
+		{
+			name:              "workspace",
+			componentSections: map[string]any{"workspace": "plat-ue2-dev"},
+			key:               "vnet.terraform.tfstate",
+			expectedPath:      "tfstate/vnet.terraform.tfstateenv:plat-ue2-dev",
+			expectedContent:   state,
+		},
+		{
+			name:              "default workspace",
+			componentSections: map[string]any{"workspace": "default"},
+			key:               "vnet.terraform.tfstate",
+			expectedPath:      "tfstate/vnet.terraform.tfstate",
+			expectedContent:   state,
+		},
+		{
+			name:              "default key",
+			componentSections: map[string]any{},
+			expectedPath:      "tfstate/terraform.tfstate",
+			expectedContent:   state,
+		},
+		{
+			name:              "state file does not exist",
+			componentSections: map[string]any{"workspace": "plat-ue2-prod"},
+			key:               "vnet.terraform.tfstate",
+			expectedPath:      "tfstate/vnet.terraform.tfstateenv:plat-ue2-prod",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceURL, requests := newFakeAzuriteServer(t, map[string]string{
+				"tfstate/vnet.terraform.tfstateenv:plat-ue2-dev": state,
+				"tfstate/vnet.terraform.tfstate":                 state,
+				"tfstate/terraform.tfstate":                      state,
+			})
+
+			credential, err := azblob.NewSharedKeyCredential(azuriteAccountName, azuriteAccountKey)
+			require.NoError(t, err)
+			client, err := azblob.NewClientWithSharedKeyCredential(serviceURL, credential, nil)
+			require.NoError(t, err)
+
+			backend := map[string]any{
+				"storage_account_name": azuriteAccountName,
+				"container_name":       "tfstate",
+				"key":                  tt.key,
+			}
+
+			content, err := tb.ReadTerraformBackendAzurermInternal(tb.NewAzureBlobClient(client), &tt.componentSections, &backend)
+			require.NoError(t, err)
+			require.Len(t, *requests, 1)
+			assert.Equal(t, "/"+azuriteAccountName+"/"+tt.expectedPath, (*requests)[0].URL.Path)
+			assert.True(t, strings.HasPrefix((*requests)[0].Header.Get("Authorization"), "SharedKey "+azuriteAccountName+":"))
+
+			if tt.expectedContent == "" {
+				assert.Nil(t, content)
+				return
+			}
+			assert.Equal(t, tt.expectedContent, string(content))
+
+			outputs, err := tb.ProcessTerraformStateFile(content)
+			require.NoError(t, err)
+			assert.Equal(t, map[string]any{"vnet_id": "vnet-123"}, outputs)
+		})
+	}
+}
+
+func TestReadTerraformBackendAzurerm_SasToken(t *testing.T) {
+	t.Setenv("ARM_ACCESS_KEY", "")
+	t.Setenv("ARM_SAS_TOKEN", "")
+
+	serviceURL, requests := newFakeAzuriteServer(t, map[string]string{
+		"tfstate/terraform.tfstate": `{"version": 4, "outputs": {}}`,
+	})
+
+	componentSections := map[string]any{
+		"backend": map[string]any{
+			"storage_account_name": azuriteAccountName,
+			"container_name":       "tfstate",
+			"endpoint":             serviceURL,
+			"sas_token":            "?sv=2022-11-02&sig=test",
+		},
+	}
+
+	content, err := tb.ReadTerraformBackendAzurerm(nil, &componentSections)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version": 4, "outputs": {}}`, string(content))
+
+	require.Len(t, *requests, 1)
+	assert.Equal(t, "test", (*requests)[0].URL.Query().Get("sig"))
+	assert.Empty(t, (*requests)[0].Header.Get("Authorization"))
+}
+
+// errorAzureBlobClient is an AzureBlobAPI that fails to download any blob.
+type errorAzureBlobClient struct {
+	calls int
+}
+
+func (c *errorAzureBlobClient) DownloadStream(_ context.Context, _ string, _ string) (io.ReadCloser, error) {
+	c.calls++
+	return nil, errors.New("authorization failure")
+}
+
+func TestReadTerraformBackendAzurermInternal_Error(t *testing.T) {
+	client := &errorAzureBlobClient{}
+
+	componentSections := map[string]any{"workspace": "plat-ue2-dev"}
+	backend := map[string]any{"storage_account_name": "tfstate", "container_name": "tfstate"}
+
+	_, err := tb.ReadTerraformBackendAzurermInternal(client, &componentSections, &backend)
+	assert.ErrorIs(t, err, errUtils.ErrGetBlobFromAzure)
+	assert.Equal(t, 3, client.calls)
+}
+
+func TestGetAzurermBackendServiceURL(t *testing.T) {
+	t.Setenv("ARM_STORAGE_ENDPOINT", "")
+	t.Setenv("ARM_ENVIRONMENT", "")
+
+	tests := []struct {
+		name     string
+		backend  map[string]any
+		expected string
+	}{
+		{
+			name:     "public cloud",
+			backend:  map[string]any{"storage_account_name": "tfstate"},
+			expected: "https://tfstate.blob.core.windows.net/",
+		},
+		{
+			name:     "us government cloud",
+			backend:  map[string]any{"storage_account_name": "tfstate", "environment": "usgovernment"},
+			expected: "https://tfstate.blob.core.usgovcloudapi.net/",
+		},
+		{
+			name:     "endpoint",
+			backend:  map[string]any{"storage_account_name": azuriteAccountName, "endpoint": "http://127.0.0.1:10000/devstoreaccount1"},
+			expected: "http://127.0.0.1:10000/devstoreaccount1/",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tb.GetAzurermBackendServiceURL(&tt.backend))
+		})
+	}
+}
+
+func TestGetAzurermBackendBlobName(t *testing.T) {
+	backend := map[string]any{"key": "vnet.tfstate"}
+	assert.Equal(t, "vnet.tfstate", tb.GetAzurermBackendBlobName(&backend, ""))
+	assert.Equal(t, "vnet.tfstate", tb.GetAzurermBackendBlobName(&backend, "default"))
+	assert.Equal(t, "vnet.tfstateenv:plat-ue2-dev", tb.GetAzurermBackendBlobName(&backend, "plat-ue2-dev"))
+
+	backend = map[string]any{}
+	assert.Equal(t, "terraform.tfstateenv:plat-ue2-dev", tb.GetAzurermBackendBlobName(&backend, "plat-ue2-dev"))
+}
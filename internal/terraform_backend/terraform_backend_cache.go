@@ -0,0 +1,291 @@
+package terraform_backend
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	log "github.com/charmbracelet/log"
+
+	errUtils "github.com/cloudposse/atmos/errors"
+	cfg "github.com/cloudposse/atmos/pkg/config"
+	"github.com/cloudposse/atmos/pkg/config/go-homedir"
+	"github.com/cloudposse/atmos/pkg/schema"
+)
+
+const (
+	// StateCacheKindState is the kind of the cache entries holding the outputs read from the state files by `!terraform.state`.
+	StateCacheKindState = "state"
+	// StateCacheKindOutput is the kind of the cache entries holding the outputs returned by `terraform output` for `!terraform.output`.
+	StateCacheKindOutput = "output"
+)
+
+// StateCache is a persistent cache of the Terraform outputs of the components, shared by all Atmos invocations.
+// The entries are keyed by the location of the state file in the backend. For the backends that can report the version
+// of the state file (e.g. the ETag of an S3 object), an entry is valid as long as the version doesn't change.
+// For the other backends, an entry is valid for the configured TTL.
+type StateCache struct {
+	dir string
+	ttl time.Duration
+	// refresh bypasses the cached entries, while still writing the entries (requested with `--skip-cache`).
+	refresh bool
+}
+
+// StateCacheKey identifies the state of a component in the cache.
+type StateCacheKey struct {
+	Location string
+	Version  string
+}
+
+type stateCacheEntry struct {
+	Location  string         `json:"location"`
+	Version   string         `json:"version,omitempty"`
+	CreatedAt time.Time      `json:"created_at"`
+	Outputs   map[string]any `json:"outputs"`
+}
+
+// GetStateCacheDir returns the directory of the persistent state cache.
+func GetStateCacheDir(atmosConfig *schema.AtmosConfiguration) (string, error) {
+	if config := atmosConfig.Components.Terraform.StateCache; config != nil && config.Path != "" {
+		return homedir.Expand(config.Path)
+	}
+
+	cacheHome := os.Getenv("XDG_CACHE_HOME")
+	if cacheHome == "" {
+		var err error
+		if cacheHome, err = os.UserCacheDir(); err != nil {
+			return "", fmt.Errorf("%w: %v", errUtils.ErrStateCache, err)
+		}
+	}
+	return filepath.Join(cacheHome, "atmos", "terraform"), nil
+}
+
+// NewStateCache returns the persistent state cache, or `nil` if it's not enabled in `atmos.yaml`.
+// If refresh is `true` (or `--skip-cache` is used), the cached entries are ignored and overwritten.
+func NewStateCache(atmosConfig *schema.AtmosConfiguration, refresh bool) (*StateCache, error) {
+	config := atmosConfig.Components.Terraform.StateCache
+	if config == nil || !config.Enabled {
+		return nil, nil
+	}
+
+	var ttl time.Duration
+	if config.TTL != "" {
+		var err error
+		if ttl, err = time.ParseDuration(config.TTL); err != nil || ttl < 0 {
+			return nil, fmt.Errorf("%w: invalid `ttl` `%s`", errUtils.ErrStateCache, config.TTL)
+		}
+	}
+
+	dir, err := GetStateCacheDir(atmosConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StateCache{dir: dir, ttl: ttl, refresh: refresh || cfg.SkipCache()}, nil
+}
+
+// stateCacheKinds are the kinds of the cache entries, which are stored in the subdirectories of the cache named after them.
+var stateCacheKinds = []string{StateCacheKindState, StateCacheKindOutput}
+
+// CleanStateCache removes all the entries of the persistent state cache and returns the directory of the cache.
+// Only the files written by the cache are removed, and the directories of the cache are only removed if they are empty,
+// so that a misconfigured `path` (e.g. the home directory) is never wiped.
+func CleanStateCache(atmosConfig *schema.AtmosConfiguration) (string, error) {
+	dir, err := GetStateCacheDir(atmosConfig)
+	if err != nil {
+		return "", err
+	}
+
+	for _, kind := range stateCacheKinds {
+		kindDir := filepath.Join(dir, kind)
+		entries, err := os.ReadDir(kindDir)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", errUtils.ErrStateCache, err)
+		}
+		for _, entry := range entries {
+			if !entry.Type().IsRegular() || !isStateCacheFile(entry.Name()) {
+				continue
+			}
+			if err := os.Remove(filepath.Join(kindDir, entry.Name())); err != nil && !os.IsNotExist(err) {
+				return "", fmt.Errorf("%w: %v", errUtils.ErrStateCache, err)
+			}
+		}
+		_ = os.Remove(kindDir)
+	}
+	_ = os.Remove(dir)
+
+	return dir, nil
+}
+
+// isStateCacheFile returns true if a file is a cache entry (`<sha256>.json`), or the temporary file of an entry being written.
+func isStateCacheFile(name string) bool {
+	if strings.HasPrefix(name, ".entry-") {
+		return true
+	}
+	hash, ok := strings.CutSuffix(name, ".json")
+	if !ok || len(hash) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(hash)
+	return err == nil
+}
+
+// Key returns the key of the state of a component in the cache, or `nil` if the outputs of the component can't be cached.
+func (c *StateCache) Key(atmosConfig *schema.AtmosConfiguration, componentSections *map[string]any) *StateCacheKey {
+	backendType := GetComponentBackendType(componentSections)
+	if backendType == "" {
+		backendType = cfg.BackendTypeLocal
+	}
+
+	RegisterTerraformBackends()
+
+	// The local state files are read faster than the cache entries
+	if backendType == cfg.BackendTypeLocal || GetExternalTerraformBackendReader(atmosConfig, backendType) != nil {
+		return nil
+	}
+
+	if reader, ok := GetTerraformBackendReader(backendType).(VersionedTerraformBackendReader); ok {
+		location, version, err := reader.GetStateVersion(atmosConfig, componentSections)
+		if err != nil {
+			log.Debug("Failed to get the version of the Terraform state file; not using the cache", "backend", backendType, "error", err)
+			return nil
+		}
+		// The state file doesn't exist, there is nothing to cache
+		if version == "" {
+			return nil
+		}
+		return &StateCacheKey{Location: location, Version: version}
+	}
+
+	if c.ttl == 0 {
+		return nil
+	}
+
+	// Without a version, the state is identified by the backend config and the workspace
+	backend, err := json.Marshal(GetComponentBackend(componentSections))
+	if err != nil {
+		return nil
+	}
+	backendHash := sha256.Sum256(backend)
+	return &StateCacheKey{
+		Location: fmt.Sprintf("%s://%s/%s", backendType, hex.EncodeToString(backendHash[:]), GetTerraformWorkspace(componentSections)),
+	}
+}
+
+func (c *StateCache) path(kind string, key *StateCacheKey) string {
+	sum := sha256.Sum256([]byte(key.Location))
+	return filepath.Join(c.dir, kind, hex.EncodeToString(sum[:])+".json")
+}
+
+// Get returns the cached outputs of a component, if they are still valid.
+func (c *StateCache) Get(kind string, key *StateCacheKey) (map[string]any, bool) {
+	if key == nil || c.refresh {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(c.path(kind, key))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry stateCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+
+	if entry.Location != key.Location {
+		return nil, false
+	}
+	if key.Version != "" && entry.Version != key.Version {
+		return nil, false
+	}
+	if key.Version == "" && time.Since(entry.CreatedAt) > c.ttl {
+		return nil, false
+	}
+
+	log.Debug("Persistent state cache hit", "kind", kind, "location", key.Location, "version", key.Version)
+	return entry.Outputs, true
+}
+
+// Set caches the outputs of a component. The entries are written to a temporary file and renamed,
+// so that concurrent Atmos processes never read partial entries.
+func (c *StateCache) Set(kind string, key *StateCacheKey, outputs map[string]any) error {
+	if key == nil || outputs == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(stateCacheEntry{
+		Location:  key.Location,
+		Version:   key.Version,
+		CreatedAt: time.Now(),
+		Outputs:   outputs,
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", errUtils.ErrStateCache, err)
+	}
+
+	path := c.path(kind, key)
+	// The outputs can be sensitive, so the cache is only readable by the user
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("%w: %v", errUtils.ErrStateCache, err)
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), ".entry-*")
+	if err != nil {
+		return fmt.Errorf("%w: %v", errUtils.ErrStateCache, err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return fmt.Errorf("%w: %v", errUtils.ErrStateCache, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("%w: %v", errUtils.ErrStateCache, err)
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("%w: %v", errUtils.ErrStateCache, err)
+	}
+
+	log.Debug("Stored the Terraform outputs in the persistent state cache", "kind", kind, "location", key.Location, "version", key.Version)
+	return nil
+}
+
+// GetCachedTerraformBackend reads and processes the Terraform state file from the configured backend,
+// using the persistent state cache if it's enabled. If skipCache is `true`, the cached entry is refreshed.
+func GetCachedTerraformBackend(
+	atmosConfig *schema.AtmosConfiguration,
+	componentSections *map[string]any,
+	skipCache bool,
+) (map[string]any, error) {
+	cache, err := NewStateCache(atmosConfig, skipCache)
+	if err != nil {
+		return nil, err
+	}
+	if cache == nil {
+		return GetTerraformBackend(atmosConfig, componentSections)
+	}
+
+	key := cache.Key(atmosConfig, componentSections)
+	if outputs, found := cache.Get(StateCacheKindState, key); found {
+		return outputs, nil
+	}
+
+	outputs, err := GetTerraformBackend(atmosConfig, componentSections)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cache.Set(StateCacheKindState, key, outputs); err != nil {
+		log.Warn("Failed to write the persistent state cache", "error", err)
+	}
+	return outputs, nil
+}
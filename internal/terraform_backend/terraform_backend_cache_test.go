@@ -0,0 +1,233 @@
+package terraform_backend_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errUtils "github.com/cloudposse/atmos/errors"
+	tb "github.com/cloudposse/atmos/internal/terraform_backend"
+	"github.com/cloudposse/atmos/pkg/schema"
+)
+
+// fakeVersionedBackend serves a state file with a version, and counts the reads of the state file.
+type fakeVersionedBackend struct {
+	state   string
+	version string
+	reads   int
+}
+
+func (b *fakeVersionedBackend) reader() tb.VersionedTerraformBackendReader {
+	return tb.NewVersionedTerraformBackendReader(
+		func(_ *schema.AtmosConfiguration, _ *map[string]any) ([]byte, error) {
+			b.reads++
+			return []byte(b.state), nil
+		},
+		func(_ *schema.AtmosConfiguration, componentSections *map[string]any) (string, string, error) {
+			return "fake://tfstate/" + tb.GetTerraformWorkspace(componentSections), b.version, nil
+		},
+	)
+}
+
+func newStateCacheConfig(t *testing.T, ttl string) schema.AtmosConfiguration {
+	atmosConfig := schema.AtmosConfiguration{}
+	atmosConfig.Components.Terraform.StateCache = &schema.TerraformStateCache{
+		Enabled: true,
+		Path:    t.TempDir(),
+		TTL:     ttl,
+	}
+	return atmosConfig
+}
+
+func TestGetCachedTerraformBackend_Versioned(t *testing.T) {
+	backend := &fakeVersionedBackend{
+		state:   `{"version": 4, "outputs": {"vpc_id": {"value": "vpc-123", "type": "string"}}}`,
+		version: "etag-1",
+	}
+	require.NoError(t, tb.RegisterTerraformBackend("cache-versioned", backend.reader()))
+
+	atmosConfig := newStateCacheConfig(t, "")
+	componentSections := map[string]any{"backend_type": "cache-versioned", "workspace": "plat-ue2-dev"}
+
+	outputs, err := tb.GetCachedTerraformBackend(&atmosConfig, &componentSections, false)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"vpc_id": "vpc-123"}, outputs)
+	assert.Equal(t, 1, backend.reads)
+
+	// The state file didn't change, the outputs are read from the cache
+	outputs, err = tb.GetCachedTerraformBackend(&atmosConfig, &componentSections, false)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"vpc_id": "vpc-123"}, outputs)
+	assert.Equal(t, 1, backend.reads)
+
+	// The state file changed, the cache entry is invalid
+	backend.state = `{"version": 4, "outputs": {"vpc_id": {"value": "vpc-456", "type": "string"}}}`
+	backend.version = "etag-2"
+	outputs, err = tb.GetCachedTerraformBackend(&atmosConfig, &componentSections, false)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"vpc_id": "vpc-456"}, outputs)
+	assert.Equal(t, 2, backend.reads)
+
+	// `--skip-cache` bypasses the cache
+	_, err = tb.GetCachedTerraformBackend(&atmosConfig, &componentSections, true)
+	require.NoError(t, err)
+	assert.Equal(t, 3, backend.reads)
+
+	// The cache is removed by `atmos cache clean`
+	dir, err := tb.CleanStateCache(&atmosConfig)
+	require.NoError(t, err)
+	assert.NoDirExists(t, dir)
+	_, err = tb.GetCachedTerraformBackend(&atmosConfig, &componentSections, false)
+	require.NoError(t, err)
+	assert.Equal(t, 4, backend.reads)
+}
+
+func TestCleanStateCache_KeepsOtherFiles(t *testing.T) {
+	// A cache path that is not a dedicated directory only has the cache entries removed
+	dir := t.TempDir()
+	atmosConfig := schema.AtmosConfiguration{}
+	atmosConfig.Components.Terraform.StateCache = &schema.TerraformStateCache{Enabled: true, Path: dir}
+
+	entry := filepath.Join(dir, tb.StateCacheKindState, strings.Repeat("a", 64)+".json")
+	others := []string{
+		filepath.Join(dir, "main.tf"),
+		filepath.Join(dir, "src", "app.go"),
+		filepath.Join(dir, tb.StateCacheKindOutput, "notes.json"),
+	}
+	for _, file := range append(others, entry) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), 0o700))
+		require.NoError(t, os.WriteFile(file, []byte("{}"), 0o600))
+	}
+
+	cleaned, err := tb.CleanStateCache(&atmosConfig)
+	require.NoError(t, err)
+	assert.Equal(t, dir, cleaned)
+	assert.NoFileExists(t, entry)
+	assert.NoDirExists(t, filepath.Dir(entry))
+	for _, file := range others {
+		assert.FileExists(t, file)
+	}
+}
+
+func TestGetCachedTerraformBackend_Disabled(t *testing.T) {
+	backend := &fakeVersionedBackend{state: `{"version": 4, "outputs": {}}`, version: "etag-1"}
+	require.NoError(t, tb.RegisterTerraformBackend("cache-disabled", backend.reader()))
+
+	atmosConfig := schema.AtmosConfiguration{}
+	componentSections := map[string]any{"backend_type": "cache-disabled"}
+
+	for i := 0; i < 2; i++ {
+		_, err := tb.GetCachedTerraformBackend(&atmosConfig, &componentSections, false)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, backend.reads)
+}
+
+func TestStateCache_TTL(t *testing.T) {
+	reads := 0
+	require.NoError(t, tb.RegisterTerraformBackend("cache-ttl", tb.ReadTerraformBackendFunc(
+		func(_ *schema.AtmosConfiguration, _ *map[string]any) ([]byte, error) {
+			reads++
+			return []byte(`{"version": 4, "outputs": {"vpc_id": {"value": "vpc-123", "type": "string"}}}`), nil
+		},
+	)))
+
+	componentSections := map[string]any{"backend_type": "cache-ttl", "backend": map[string]any{"address": "https://tfstate"}}
+
+	// Without a TTL, the states of the backends without a version are not cached
+	atmosConfig := newStateCacheConfig(t, "")
+	cache, err := tb.NewStateCache(&atmosConfig, false)
+	require.NoError(t, err)
+	assert.Nil(t, cache.Key(&atmosConfig, &componentSections))
+
+	atmosConfig = newStateCacheConfig(t, "1h")
+	cache, err = tb.NewStateCache(&atmosConfig, false)
+	require.NoError(t, err)
+	key := cache.Key(&atmosConfig, &componentSections)
+	require.NotNil(t, key)
+	assert.Empty(t, key.Version)
+
+	for i := 0; i < 2; i++ {
+		_, err := tb.GetCachedTerraformBackend(&atmosConfig, &componentSections, false)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, reads)
+
+	// A different backend config is a different state
+	otherSections := map[string]any{"backend_type": "cache-ttl", "backend": map[string]any{"address": "https://other"}}
+	assert.NotEqual(t, key.Location, cache.Key(&atmosConfig, &otherSections).Location)
+
+	// An expired entry is not used
+	atmosConfig.Components.Terraform.StateCache.TTL = "1ns"
+	cache, err = tb.NewStateCache(&atmosConfig, false)
+	require.NoError(t, err)
+	time.Sleep(time.Millisecond)
+	_, found := cache.Get(tb.StateCacheKindState, key)
+	assert.False(t, found)
+}
+
+func TestStateCache_GetSet(t *testing.T) {
+	atmosConfig := newStateCacheConfig(t, "")
+	cache, err := tb.NewStateCache(&atmosConfig, false)
+	require.NoError(t, err)
+
+	key := &tb.StateCacheKey{Location: "s3://tfstate/vpc/terraform.tfstate", Version: "etag-1"}
+	outputs := map[string]any{"vpc_id": "vpc-123"}
+	require.NoError(t, cache.Set(tb.StateCacheKindOutput, key, outputs))
+
+	cached, found := cache.Get(tb.StateCacheKindOutput, key)
+	assert.True(t, found)
+	assert.Equal(t, outputs, cached)
+
+	// The kinds of entries are separated
+	_, found = cache.Get(tb.StateCacheKindState, key)
+	assert.False(t, found)
+
+	_, found = cache.Get(tb.StateCacheKindOutput, &tb.StateCacheKey{Location: key.Location, Version: "etag-2"})
+	assert.False(t, found)
+
+	_, found = cache.Get(tb.StateCacheKindOutput, nil)
+	assert.False(t, found)
+
+	// The cache is only readable by the user
+	info, err := os.Stat(filepath.Join(atmosConfig.Components.Terraform.StateCache.Path, tb.StateCacheKindOutput))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o700), info.Mode().Perm())
+
+	// A refreshing cache ignores the entries
+	cache, err = tb.NewStateCache(&atmosConfig, true)
+	require.NoError(t, err)
+	_, found = cache.Get(tb.StateCacheKindOutput, key)
+	assert.False(t, found)
+}
+
+func TestNewStateCache(t *testing.T) {
+	atmosConfig := schema.AtmosConfiguration{}
+	cache, err := tb.NewStateCache(&atmosConfig, false)
+	require.NoError(t, err)
+	assert.Nil(t, cache)
+
+	atmosConfig = newStateCacheConfig(t, "soon")
+	_, err = tb.NewStateCache(&atmosConfig, false)
+	assert.ErrorIs(t, err, errUtils.ErrStateCache)
+}
+
+func TestGetStateCacheDir(t *testing.T) {
+	cacheHome := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", cacheHome)
+
+	atmosConfig := schema.AtmosConfiguration{}
+	dir, err := tb.GetStateCacheDir(&atmosConfig)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(cacheHome, "atmos", "terraform"), dir)
+
+	atmosConfig.Components.Terraform.StateCache = &schema.TerraformStateCache{Path: "/tmp/atmos-cache"}
+	dir, err = tb.GetStateCacheDir(&atmosConfig)
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/atmos-cache", dir)
+}
@@ -0,0 +1,207 @@
+package terraform_backend
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	log "github.com/charmbracelet/log"
+	consulapi "github.com/hashicorp/consul/api"
+
+	errUtils "github.com/cloudposse/atmos/errors"
+	cfg "github.com/cloudposse/atmos/pkg/config"
+	"github.com/cloudposse/atmos/pkg/schema"
+)
+
+// consulWorkspaceKeySuffix is appended to the path, followed by the workspace name, for non-default workspaces.
+const consulWorkspaceKeySuffix = "-env:"
+
+// ConsulKVAPI defines an interface for reading keys from the Consul KV store.
+type ConsulKVAPI interface {
+	Get(key string, q *consulapi.QueryOptions) (*consulapi.KVPair, *consulapi.QueryMeta, error)
+}
+
+// consulClientCache caches the Consul KV clients based on a deterministic cache key.
+// It's a map[string]ConsulKVAPI.
+var consulClientCache sync.Map
+
+// consulChunkedState is stored at the state path when Terraform splits a large state into several keys.
+type consulChunkedState struct {
+	CurrentHash string   `json:"current-hash"`
+	Chunks      []string `json:"chunks"`
+}
+
+// GetConsulBackendPath returns the path of the state of a workspace in the Consul KV store.
+// The state of the default workspace is stored in `path`, and the state of the other workspaces in `path` + `-env:<workspace>`.
+func GetConsulBackendPath(backend *map[string]any, workspace string) string {
+	statePath := GetBackendAttribute(backend, "path")
+	if workspace == "" || workspace == "default" {
+		return statePath
+	}
+	return statePath + consulWorkspaceKeySuffix + workspace
+}
+
+// getConsulClientConfig returns the Consul client config from the consul backend config.
+// The defaults, like in Terraform, are read from the `CONSUL_HTTP_*` ENV vars.
+func getConsulClientConfig(backend *map[string]any) *consulapi.Config {
+	config := consulapi.DefaultConfig()
+
+	if address := GetBackendAttribute(backend, "address"); address != "" {
+		config.Address = address
+	}
+	if scheme := GetBackendAttribute(backend, "scheme"); scheme != "" {
+		config.Scheme = scheme
+	}
+	if datacenter := GetBackendAttribute(backend, "datacenter"); datacenter != "" {
+		config.Datacenter = datacenter
+	}
+	if token := GetBackendAttribute(backend, "access_token"); token != "" {
+		config.Token = token
+	}
+	if httpAuth := GetBackendAttribute(backend, "http_auth"); httpAuth != "" {
+		username, password, _ := strings.Cut(httpAuth, ":")
+		config.HttpAuth = &consulapi.HttpBasicAuth{Username: username, Password: password}
+	}
+	if caFile := GetBackendAttribute(backend, "ca_file"); caFile != "" {
+		config.TLSConfig.CAFile = caFile
+	}
+	if certFile := GetBackendAttribute(backend, "cert_file"); certFile != "" {
+		config.TLSConfig.CertFile = certFile
+	}
+	if keyFile := GetBackendAttribute(backend, "key_file"); keyFile != "" {
+		config.TLSConfig.KeyFile = keyFile
+	}
+
+	return config
+}
+
+func getCachedConsulClient(backend *map[string]any) (ConsulKVAPI, error) {
+	config := getConsulClientConfig(backend)
+
+	// Build a deterministic cache key. The secrets are hashed, so that they are not kept in the key.
+	cacheKey := fmt.Sprintf("address=%s;scheme=%s;datacenter=%s;token=%x;http_auth=%x;ca_file=%s;cert_file=%s;key_file=%s",
+		config.Address,
+		config.Scheme,
+		config.Datacenter,
+		sha256.Sum256([]byte(config.Token)),
+		sha256.Sum256([]byte(GetBackendAttribute(backend, "http_auth"))),
+		config.TLSConfig.CAFile,
+		config.TLSConfig.CertFile,
+		config.TLSConfig.KeyFile,
+	)
+
+	// Check the cache
+	if cached, ok := consulClientCache.Load(cacheKey); ok {
+		return cached.(ConsulKVAPI), nil
+	}
+
+	// Build the Consul client if not cached
+	client, err := consulapi.NewClient(config)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errUtils.ErrCreateConsulClient, err)
+	}
+
+	kv := client.KV()
+	consulClientCache.Store(cacheKey, kv)
+	return kv, nil
+}
+
+// ReadTerraformBackendConsul reads the Terraform state file from the configured consul backend.
+// If the state does not exist in the KV store, the function returns `nil`.
+func ReadTerraformBackendConsul(
+	_ *schema.AtmosConfiguration,
+	componentSections *map[string]any,
+) ([]byte, error) {
+	backend := GetComponentBackend(componentSections)
+
+	kv, err := getCachedConsulClient(&backend)
+	if err != nil {
+		return nil, err
+	}
+
+	return ReadTerraformBackendConsulInternal(kv, componentSections, &backend)
+}
+
+// ReadTerraformBackendConsulInternal accepts a Consul KV client and reads the Terraform state file from the configured consul backend.
+func ReadTerraformBackendConsulInternal(
+	kv ConsulKVAPI,
+	componentSections *map[string]any,
+	backend *map[string]any,
+) ([]byte, error) {
+	statePath := GetConsulBackendPath(backend, GetTerraformWorkspace(componentSections))
+
+	content, err := readTerraformStateWithRetry(cfg.BackendTypeConsul, func(ctx context.Context) ([]byte, error) {
+		pair, _, err := kv.Get(statePath, (&consulapi.QueryOptions{}).WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		// If the state does not exist (the component in the stack has not been provisioned yet), return a `nil` result and no error.
+		if pair == nil {
+			log.Debug("Terraform state doesn't exist in Consul; returning 'null'", "path", statePath)
+			return nil, nil
+		}
+
+		payload := pair.Value
+		if chunked, ok := getConsulChunkedState(payload); ok {
+			payload, err = readConsulStateChunks(ctx, kv, chunked)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return decompressConsulState(payload)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errUtils.ErrGetStateFromConsul, err)
+	}
+	return content, nil
+}
+
+// getConsulChunkedState returns the list of chunks if the payload is the index of a state split into several keys.
+func getConsulChunkedState(payload []byte) (*consulChunkedState, bool) {
+	if len(payload) == 0 || payload[0] != '{' {
+		return nil, false
+	}
+
+	var chunked consulChunkedState
+	if err := json.Unmarshal(payload, &chunked); err != nil || chunked.CurrentHash == "" {
+		return nil, false
+	}
+	return &chunked, true
+}
+
+// readConsulStateChunks reads the chunks of a state split into several keys and joins them.
+func readConsulStateChunks(ctx context.Context, kv ConsulKVAPI, chunked *consulChunkedState) ([]byte, error) {
+	var payload []byte
+	for _, chunk := range chunked.Chunks {
+		pair, _, err := kv.Get(chunk, (&consulapi.QueryOptions{}).WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		if pair == nil {
+			return nil, fmt.Errorf("state chunk `%s` not found", chunk)
+		}
+		payload = append(payload, pair.Value...)
+	}
+	return payload, nil
+}
+
+// decompressConsulState decompresses the state if it was written with `gzip = true`.
+func decompressConsulState(payload []byte) ([]byte, error) {
+	if len(payload) == 0 || payload[0] != 0x1f {
+		return payload, nil
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return io.ReadAll(reader)
+}
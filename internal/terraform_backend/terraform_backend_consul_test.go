@@ -0,0 +1,113 @@
+package terraform_backend_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tb "github.com/cloudposse/atmos/internal/terraform_backend"
+)
+
+// newFakeConsulKV starts a fake Consul agent that serves the given keys from the KV store.
+func newFakeConsulKV(t *testing.T, keys map[string][]byte) (tb.ConsulKVAPI, *[]*http.Request) {
+	var requests []*http.Request
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+
+		key := strings.TrimPrefix(r.URL.Path, "/v1/kv/")
+		value, ok := keys[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode([]consulapi.KVPair{{Key: key, Value: value}})
+	}))
+	t.Cleanup(server.Close)
+
+	config := consulapi.DefaultConfig()
+	config.Address = server.URL
+	config.Token = "consul-token"
+	client, err := consulapi.NewClient(config)
+	require.NoError(t, err)
+
+	return client.KV(), &requests
+}
+
+func gzipState(t *testing.T, state string) []byte {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	_, err := writer.Write([]byte(state))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	return buf.Bytes()
+}
+
+func TestReadTerraformBackendConsulInternal(t *testing.T) {
+	state := `{"version": 4, "outputs": {"vpc_id": {"value": "vpc-123", "type": "string"}}}`
+	compressed := gzipState(t, state)
+
+	chunkIndex, err := json.Marshal(map[string]any{
+		"current-hash": "abc",
+		"chunks":       []string{"atmos/chunked/tfstate.abc/0", "atmos/chunked/tfstate.abc/1"},
+	})
+	require.NoError(t, err)
+
+	kv, requests := newFakeConsulKV(t, map[string][]byte{
+		"atmos/vpc":                   []byte(state),
+		"atmos/vpc-env:plat-ue2-dev":  []byte(state),
+		"atmos/gzip":                  compressed,
+		"atmos/chunked":               chunkIndex,
+		"atmos/chunked/tfstate.abc/0": compressed[:10],
+		"atmos/chunked/tfstate.abc/1": compressed[10:],
+	})
+
+	tests := []struct {
+		name            string
+		path            string
+		workspace       string
+		expectedKey     string
+		expectedContent string
+	}{
+		{name: "default workspace", path: "atmos/vpc", workspace: "default", expectedKey: "atmos/vpc", expectedContent: state},
+		{name: "workspace", path: "atmos/vpc", workspace: "plat-ue2-dev", expectedKey: "atmos/vpc-env:plat-ue2-dev", expectedContent: state},
+		{name: "gzip", path: "atmos/gzip", expectedKey: "atmos/gzip", expectedContent: state},
+		{name: "chunked", path: "atmos/chunked", expectedKey: "atmos/chunked", expectedContent: state},
+		{name: "state does not exist", path: "atmos/vpc", workspace: "plat-ue2-prod", expectedKey: "atmos/vpc-env:plat-ue2-prod"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			*requests = nil
+			componentSections := map[string]any{"workspace": tt.workspace}
+			backend := map[string]any{"path": tt.path}
+
+			content, err := tb.ReadTerraformBackendConsulInternal(kv, &componentSections, &backend)
+			require.NoError(t, err)
+			require.NotEmpty(t, *requests)
+			assert.Equal(t, "/v1/kv/"+tt.expectedKey, (*requests)[0].URL.Path)
+			assert.Equal(t, "consul-token", (*requests)[0].Header.Get("X-Consul-Token"))
+
+			if tt.expectedContent == "" {
+				assert.Nil(t, content)
+				return
+			}
+			assert.Equal(t, tt.expectedContent, string(content))
+		})
+	}
+}
+
+func TestGetConsulBackendPath(t *testing.T) {
+	backend := map[string]any{"path": "atmos/vpc"}
+	assert.Equal(t, "atmos/vpc", tb.GetConsulBackendPath(&backend, ""))
+	assert.Equal(t, "atmos/vpc", tb.GetConsulBackendPath(&backend, "default"))
+	assert.Equal(t, "atmos/vpc-env:plat-ue2-dev", tb.GetConsulBackendPath(&backend, "plat-ue2-dev"))
+}
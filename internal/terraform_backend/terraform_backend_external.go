@@ -0,0 +1,105 @@
+package terraform_backend
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	log "github.com/charmbracelet/log"
+
+	errUtils "github.com/cloudposse/atmos/errors"
+	"github.com/cloudposse/atmos/pkg/schema"
+)
+
+// externalReaderDefaultTimeout is the default timeout of an external backend reader.
+const externalReaderDefaultTimeout = 30 * time.Second
+
+// ExternalTerraformBackendReader reads the Terraform state with an external executable configured in
+// `components.terraform.backend_readers` in `atmos.yaml`.
+// The executable receives the `backend` section of the component as JSON on stdin, and prints the raw state file to stdout.
+// If the state does not exist, the executable should print nothing.
+// The backend type, the Terraform component and workspace are passed in the `ATMOS_BACKEND_TYPE`,
+// `ATMOS_COMPONENT` and `ATMOS_TERRAFORM_WORKSPACE` ENV vars.
+type ExternalTerraformBackendReader struct {
+	BackendType string
+	Config      schema.TerraformBackendReaderConfig
+}
+
+// Ensure ExternalTerraformBackendReader implements the TerraformBackendReader interface.
+var _ TerraformBackendReader = (*ExternalTerraformBackendReader)(nil)
+
+// GetExternalTerraformBackendReader returns the external reader configured in `atmos.yaml` for the backend type,
+// or `nil` if there is none.
+func GetExternalTerraformBackendReader(atmosConfig *schema.AtmosConfiguration, backendType string) TerraformBackendReader {
+	if atmosConfig == nil {
+		return nil
+	}
+	config, ok := atmosConfig.Components.Terraform.BackendReaders[backendType]
+	if !ok {
+		return nil
+	}
+	return &ExternalTerraformBackendReader{BackendType: backendType, Config: config}
+}
+
+// ReadState runs the external executable and returns its output.
+func (r *ExternalTerraformBackendReader) ReadState(
+	_ *schema.AtmosConfiguration,
+	componentSections *map[string]any,
+) ([]byte, error) {
+	if r.Config.Command == "" {
+		return nil, fmt.Errorf("%w: `command` is not configured for the backend type `%s`", errUtils.ErrExternalBackendReader, r.BackendType)
+	}
+
+	timeout := externalReaderDefaultTimeout
+	if r.Config.Timeout != "" {
+		var err error
+		if timeout, err = time.ParseDuration(r.Config.Timeout); err != nil {
+			return nil, fmt.Errorf("%w: invalid timeout `%s` for the backend type `%s`: %v", errUtils.ErrExternalBackendReader, r.Config.Timeout, r.BackendType, err)
+		}
+	}
+
+	backend := GetComponentBackend(componentSections)
+	if backend == nil {
+		backend = map[string]any{}
+	}
+	input, err := json.Marshal(backend)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errUtils.ErrExternalBackendReader, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, r.Config.Command, r.Config.Args...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Env = append(os.Environ(),
+		"ATMOS_BACKEND_TYPE="+r.BackendType,
+		"ATMOS_COMPONENT="+GetTerraformComponent(componentSections),
+		"ATMOS_TERRAFORM_WORKSPACE="+GetTerraformWorkspace(componentSections),
+	)
+	for k, v := range r.Config.Env {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	log.Debug("Reading Terraform state with an external reader", "backend", r.BackendType, "command", r.Config.Command)
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%w: `%s`: %v: %s", errUtils.ErrExternalBackendReader, r.Config.Command, err, strings.TrimSpace(stderr.String()))
+	}
+
+	content := bytes.TrimSpace(stdout.Bytes())
+	if len(content) == 0 {
+		log.Debug("External reader returned no Terraform state; returning 'null'", "backend", r.BackendType)
+		return nil, nil
+	}
+	return content, nil
+}
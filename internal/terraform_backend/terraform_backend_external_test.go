@@ -0,0 +1,87 @@
+package terraform_backend_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errUtils "github.com/cloudposse/atmos/errors"
+	tb "github.com/cloudposse/atmos/internal/terraform_backend"
+	"github.com/cloudposse/atmos/pkg/schema"
+)
+
+// writeExternalReader writes a shell script that reads the Terraform state for the `custom` backend type.
+func writeExternalReader(t *testing.T, script string) string {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping the external reader test on Windows")
+	}
+
+	path := filepath.Join(t.TempDir(), "reader.sh")
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0o755))
+	return path
+}
+
+func TestExternalTerraformBackendReader(t *testing.T) {
+	// The reader returns a state with the received backend config and ENV vars as outputs
+	reader := writeExternalReader(t, `
+backend=$(cat)
+echo "{\"version\": 4, \"outputs\": {\"backend\": {\"value\": $backend}, \"workspace\": {\"value\": \"$ATMOS_TERRAFORM_WORKSPACE\"}, \"type\": {\"value\": \"$ATMOS_BACKEND_TYPE\"}, \"region\": {\"value\": \"$REGION\"}}}"
+`)
+
+	atmosConfig := schema.AtmosConfiguration{}
+	atmosConfig.Components.Terraform.BackendReaders = map[string]schema.TerraformBackendReaderConfig{
+		"custom": {Command: reader, Env: map[string]string{"REGION": "us-east-2"}},
+	}
+
+	componentSections := map[string]any{
+		"backend_type": "custom",
+		"backend":      map[string]any{"bucket": "tfstate"},
+		"workspace":    "plat-ue2-dev",
+	}
+
+	outputs, err := tb.GetTerraformBackend(&atmosConfig, &componentSections)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"backend":   map[string]any{"bucket": "tfstate"},
+		"workspace": "plat-ue2-dev",
+		"type":      "custom",
+		"region":    "us-east-2",
+	}, outputs)
+}
+
+func TestExternalTerraformBackendReader_NoState(t *testing.T) {
+	reader := writeExternalReader(t, "cat > /dev/null\n")
+
+	r := &tb.ExternalTerraformBackendReader{BackendType: "custom", Config: schema.TerraformBackendReaderConfig{Command: reader}}
+	componentSections := map[string]any{}
+
+	content, err := r.ReadState(nil, &componentSections)
+	require.NoError(t, err)
+	assert.Nil(t, content)
+}
+
+func TestExternalTerraformBackendReader_Errors(t *testing.T) {
+	reader := writeExternalReader(t, "echo 'access denied' >&2\nexit 1\n")
+	componentSections := map[string]any{}
+
+	r := &tb.ExternalTerraformBackendReader{BackendType: "custom", Config: schema.TerraformBackendReaderConfig{Command: reader}}
+	_, err := r.ReadState(nil, &componentSections)
+	assert.ErrorIs(t, err, errUtils.ErrExternalBackendReader)
+	assert.Contains(t, err.Error(), "access denied")
+
+	r = &tb.ExternalTerraformBackendReader{BackendType: "custom", Config: schema.TerraformBackendReaderConfig{Command: "sleep", Args: []string{"5"}, Timeout: "100ms"}}
+	_, err = r.ReadState(nil, &componentSections)
+	assert.ErrorIs(t, err, errUtils.ErrExternalBackendReader)
+
+	r = &tb.ExternalTerraformBackendReader{BackendType: "custom", Config: schema.TerraformBackendReaderConfig{Command: reader, Timeout: "soon"}}
+	_, err = r.ReadState(nil, &componentSections)
+	assert.ErrorIs(t, err, errUtils.ErrExternalBackendReader)
+
+	r = &tb.ExternalTerraformBackendReader{BackendType: "custom"}
+	_, err = r.ReadState(nil, &componentSections)
+	assert.ErrorIs(t, err, errUtils.ErrExternalBackendReader)
+}
@@ -0,0 +1,303 @@
+package terraform_backend
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/storage"
+	log "github.com/charmbracelet/log"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/impersonate"
+	"google.golang.org/api/option"
+
+	errUtils "github.com/cloudposse/atmos/errors"
+	"github.com/cloudposse/atmos/pkg/schema"
+)
+
+// gcsReadOnlyScope is the OAuth scope used to read the state files when impersonating a service account.
+const gcsReadOnlyScope = "https://www.googleapis.com/auth/devstorage.read_only"
+
+// GCSAPI defines an interface for reading objects from a GCS bucket.
+type GCSAPI interface {
+	NewObjectReader(ctx context.Context, bucket string, object string) (io.ReadCloser, error)
+}
+
+// GCSObjectGenerationAPI defines an interface for reading the generation of GCS objects.
+type GCSObjectGenerationAPI interface {
+	ObjectGeneration(ctx context.Context, bucket string, object string) (int64, error)
+}
+
+// gcsClient implements GCSAPI with the Google Cloud Storage client.
+type gcsClient struct {
+	client *storage.Client
+}
+
+func (c *gcsClient) NewObjectReader(ctx context.Context, bucket string, object string) (io.ReadCloser, error) {
+	return c.client.Bucket(bucket).Object(object).NewReader(ctx)
+}
+
+func (c *gcsClient) ObjectGeneration(ctx context.Context, bucket string, object string) (int64, error) {
+	attrs, err := c.client.Bucket(bucket).Object(object).Attrs(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return attrs.Generation, nil
+}
+
+// NewGCSClient wraps a Google Cloud Storage client into a GCSAPI.
+func NewGCSClient(client *storage.Client) GCSAPI {
+	return &gcsClient{client: client}
+}
+
+// gcsClientCache caches the GCS clients based on a deterministic cache key.
+// It's a map[string]GCSAPI.
+var gcsClientCache sync.Map
+
+// GetGCSBackendCredentials returns the credentials from the GCS backend config.
+// Like Terraform, it falls back to the `GOOGLE_BACKEND_CREDENTIALS` and `GOOGLE_CREDENTIALS` ENV vars.
+// https://developer.hashicorp.com/terraform/language/backend/gcs#credentials
+func GetGCSBackendCredentials(backend *map[string]any) string {
+	if credentials := GetBackendAttribute(backend, "credentials"); credentials != "" {
+		return credentials
+	}
+	if credentials := os.Getenv("GOOGLE_BACKEND_CREDENTIALS"); credentials != "" {
+		return credentials
+	}
+	return os.Getenv("GOOGLE_CREDENTIALS")
+}
+
+// GetGCSBackendImpersonateServiceAccount returns the service account to impersonate from the GCS backend config.
+func GetGCSBackendImpersonateServiceAccount(backend *map[string]any) string {
+	if account := GetBackendAttribute(backend, "impersonate_service_account"); account != "" {
+		return account
+	}
+	if account := os.Getenv("GOOGLE_BACKEND_IMPERSONATE_SERVICE_ACCOUNT"); account != "" {
+		return account
+	}
+	return os.Getenv("GOOGLE_IMPERSONATE_SERVICE_ACCOUNT")
+}
+
+// getGCSBackendDelegates returns the `impersonate_service_account_delegates` from the GCS backend config.
+func getGCSBackendDelegates(backend *map[string]any) []string {
+	var delegates []string
+	switch v := (*backend)["impersonate_service_account_delegates"].(type) {
+	case []string:
+		delegates = v
+	case []any:
+		for _, d := range v {
+			if s, ok := d.(string); ok {
+				delegates = append(delegates, s)
+			}
+		}
+	}
+	return delegates
+}
+
+// getGCSClientOptions returns the options to create a GCS client from the GCS backend config.
+func getGCSClientOptions(ctx context.Context, backend *map[string]any) ([]option.ClientOption, error) {
+	var opts []option.ClientOption
+
+	// `credentials` can be either the path to a service account key file, or its content.
+	if credentials := GetGCSBackendCredentials(backend); credentials != "" {
+		if strings.HasPrefix(strings.TrimSpace(credentials), "{") {
+			opts = append(opts, option.WithCredentialsJSON([]byte(credentials)))
+		} else {
+			opts = append(opts, option.WithCredentialsFile(credentials))
+		}
+	}
+
+	if accessToken := GetBackendAttribute(backend, "access_token"); accessToken != "" {
+		opts = []option.ClientOption{option.WithTokenSource(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: accessToken}))}
+	}
+
+	if account := GetGCSBackendImpersonateServiceAccount(backend); account != "" {
+		tokenSource, err := impersonate.CredentialsTokenSource(ctx, impersonate.CredentialsConfig{
+			TargetPrincipal: account,
+			Scopes:          []string{gcsReadOnlyScope},
+			Delegates:       getGCSBackendDelegates(backend),
+		}, opts...)
+		if err != nil {
+			return nil, err
+		}
+		opts = []option.ClientOption{option.WithTokenSource(tokenSource)}
+	}
+
+	return opts, nil
+}
+
+func getCachedGCSClient(backend *map[string]any) (GCSAPI, error) {
+	credentials := GetGCSBackendCredentials(backend)
+	accessToken := GetBackendAttribute(backend, "access_token")
+	account := GetGCSBackendImpersonateServiceAccount(backend)
+	delegates := getGCSBackendDelegates(backend)
+
+	// Build a deterministic cache key. The credentials are hashed, so that secrets are not kept in the key.
+	cacheKey := fmt.Sprintf("credentials=%x;access_token=%x;impersonate_service_account=%s;delegates=%s",
+		sha256.Sum256([]byte(credentials)),
+		sha256.Sum256([]byte(accessToken)),
+		account,
+		strings.Join(delegates, ","),
+	)
+
+	// Check the cache
+	if cached, ok := gcsClientCache.Load(cacheKey); ok {
+		return cached.(GCSAPI), nil
+	}
+
+	// Build the GCS client if not cached
+	// 30 sec timeout to configure a GCS client (and impersonate a service account if provided).
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	opts, err := getGCSClientOptions(ctx, backend)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errUtils.ErrCreateGCSClient, err)
+	}
+
+	// The client outlives the context used to configure it
+	client, err := storage.NewClient(context.Background(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errUtils.ErrCreateGCSClient, err)
+	}
+
+	gcsClient := NewGCSClient(client)
+	gcsClientCache.Store(cacheKey, gcsClient)
+	return gcsClient, nil
+}
+
+// getGCSBackendStatePath returns the path to the tfstate file in the GCS bucket.
+// The GCS backend stores the state of each workspace in `<prefix>/<workspace>.tfstate`.
+// https://developer.hashicorp.com/terraform/language/backend/gcs#prefix
+func getGCSBackendStatePath(componentSections *map[string]any, backend *map[string]any) string {
+	workspace := GetTerraformWorkspace(componentSections)
+	if workspace == "" {
+		workspace = "default"
+	}
+	return path.Join(GetBackendAttribute(backend, "prefix"), workspace+".tfstate")
+}
+
+// GetTerraformBackendGCSStateVersion returns the location of the Terraform state file in the configured GCS backend,
+// and its generation as the version. If the state file does not exist, the version is empty.
+func GetTerraformBackendGCSStateVersion(
+	_ *schema.AtmosConfiguration,
+	componentSections *map[string]any,
+) (string, string, error) {
+	backend := GetComponentBackend(componentSections)
+
+	gcsClient, err := getCachedGCSClient(&backend)
+	if err != nil {
+		return "", "", err
+	}
+
+	generationClient, ok := gcsClient.(GCSObjectGenerationAPI)
+	if !ok {
+		return "", "", fmt.Errorf("%w: the GCS client does not support reading the object generation", errUtils.ErrGetObjectFromGCS)
+	}
+
+	return GetTerraformBackendGCSStateVersionInternal(generationClient, componentSections, &backend)
+}
+
+// GetTerraformBackendGCSStateVersionInternal accepts a GCS client and returns the location and the generation of the Terraform state file.
+func GetTerraformBackendGCSStateVersionInternal(
+	gcsClient GCSObjectGenerationAPI,
+	componentSections *map[string]any,
+	backend *map[string]any,
+) (string, string, error) {
+	tfStateFilePath := getGCSBackendStatePath(componentSections, backend)
+	bucket := GetBackendAttribute(backend, "bucket")
+	location := fmt.Sprintf("gs://%s/%s", bucket, tfStateFilePath)
+
+	// 30 sec timeout to read the metadata of the state file.
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	generation, err := gcsClient.ObjectGeneration(ctx, bucket, tfStateFilePath)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return location, "", nil
+		}
+		return "", "", fmt.Errorf("%w: %v", errUtils.ErrGetObjectFromGCS, err)
+	}
+
+	return location, strconv.FormatInt(generation, 10), nil
+}
+
+// ReadTerraformBackendGCS reads the Terraform state file from the configured GCS backend.
+// If the state file does not exist in the bucket, the function returns `nil`.
+func ReadTerraformBackendGCS(
+	_ *schema.AtmosConfiguration,
+	componentSections *map[string]any,
+) ([]byte, error) {
+	backend := GetComponentBackend(componentSections)
+
+	gcsClient, err := getCachedGCSClient(&backend)
+	if err != nil {
+		return nil, err
+	}
+
+	return ReadTerraformBackendGCSInternal(gcsClient, componentSections, &backend)
+}
+
+// ReadTerraformBackendGCSInternal accepts a GCS client and reads the Terraform state file from the configured GCS backend.
+func ReadTerraformBackendGCSInternal(
+	gcsClient GCSAPI,
+	componentSections *map[string]any,
+	backend *map[string]any,
+) ([]byte, error) {
+	tfStateFilePath := getGCSBackendStatePath(componentSections, backend)
+
+	bucket := GetBackendAttribute(backend, "bucket")
+
+	var lastErr error
+	for attempt := 0; attempt <= maxRetryCount; attempt++ {
+		content, err := readGCSObject(gcsClient, bucket, tfStateFilePath)
+		if err == nil {
+			return content, nil
+		}
+
+		// If the state file does not exist (the component in the stack has not been provisioned yet), return a `nil` result and no error.
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			log.Debug("Terraform state file doesn't exist in the GCS bucket; returning 'null'", "file", tfStateFilePath, "bucket", bucket)
+			return nil, nil
+		}
+		if errors.Is(err, errUtils.ErrReadGCSObjectBody) {
+			return nil, err
+		}
+
+		lastErr = err
+		if attempt < maxRetryCount {
+			log.Debug("Failed to read Terraform state file from the GCS bucket", "attempt", attempt+1, "file", tfStateFilePath, "bucket", bucket, "error", err)
+			time.Sleep(time.Second * 2) // backoff
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %v", errUtils.ErrGetObjectFromGCS, lastErr)
+}
+
+// readGCSObject makes one attempt to read an object from a GCS bucket. The context of the attempt is canceled when it returns.
+func readGCSObject(gcsClient GCSAPI, bucket string, path string) ([]byte, error) {
+	// 30 sec timeout to read the state file from the GCS bucket.
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	reader, err := gcsClient.NewObjectReader(ctx, bucket, path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = reader.Close() }()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errUtils.ErrReadGCSObjectBody, err)
+	}
+	return content, nil
+}
@@ -0,0 +1,202 @@
+package terraform_backend_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+
+	errUtils "github.com/cloudposse/atmos/errors"
+	tb "github.com/cloudposse/atmos/internal/terraform_backend"
+)
+
+// newFakeGCSClient starts a fake GCS server that serves the given objects, keyed by `<bucket>/<object>`.
+func newFakeGCSClient(t *testing.T, objects map[string]string) (tb.GCSAPI, *[]string) {
+	var requests []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		objectPath, err := url.PathUnescape(strings.TrimPrefix(r.URL.EscapedPath(), "/"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		requests = append(requests, objectPath)
+
+		content, ok := objects[objectPath]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(content))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := storage.NewClient(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	return tb.NewGCSClient(client), &requests
+}
+
+func TestReadTerraformBackendGCSInternal(t *testing.T) {
+	state := `{"version": 4, "terraform_version": "1.5.7", "outputs": {"vpc_id": {"value": "vpc-123", "type": "string"}}}`
+
+	tests := []struct {
+		name              string
+		componentSections map[string]any
+		backend           map[string]any
+		expectedPath      string
+		expectedContent   string
+	}{
+		{
+			name:              "workspace with prefix",
+			componentSections: map[string]any{"workspace": "plat-ue2-dev"},
+			backend:           map[string]any{"bucket": "tfstate", "prefix": "vpc"},
+			expectedPath:      "tfstate/vpc/plat-ue2-dev.tfstate",
+			expectedContent:   state,
+		},
+		{
+			name:              "default workspace",
+			componentSections: map[string]any{},
+			backend:           map[string]any{"bucket": "tfstate", "prefix": "vpc"},
+			expectedPath:      "tfstate/vpc/default.tfstate",
+			expectedContent:   state,
+		},
+		{
+			name:              "no prefix",
+			componentSections: map[string]any{"workspace": "plat-ue2-dev"},
+			backend:           map[string]any{"bucket": "tfstate"},
+			expectedPath:      "tfstate/plat-ue2-dev.tfstate",
+			expectedContent:   state,
+		},
+		{
+			name:              "state file does not exist",
+			componentSections: map[string]any{"workspace": "plat-ue2-prod"},
+			backend:           map[string]any{"bucket": "tfstate", "prefix": "vpc"},
+			expectedPath:      "tfstate/vpc/plat-ue2-prod.tfstate",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, requests := newFakeGCSClient(t, map[string]string{
+				"tfstate/vpc/plat-ue2-dev.tfstate": state,
+				"tfstate/vpc/default.tfstate":      state,
+				"tfstate/plat-ue2-dev.tfstate":     state,
+			})
+
+			content, err := tb.ReadTerraformBackendGCSInternal(client, &tt.componentSections, &tt.backend)
+			require.NoError(t, err)
+			assert.Equal(t, []string{tt.expectedPath}, *requests)
+
+			if tt.expectedContent == "" {
+				assert.Nil(t, content)
+				return
+			}
+			assert.Equal(t, tt.expectedContent, string(content))
+
+			outputs, err := tb.ProcessTerraformStateFile(content)
+			require.NoError(t, err)
+			assert.Equal(t, map[string]any{"vpc_id": "vpc-123"}, outputs)
+		})
+	}
+}
+
+// errorGCSClient is a GCSAPI that fails to read any object.
+type errorGCSClient struct {
+	calls int
+}
+
+func (c *errorGCSClient) NewObjectReader(_ context.Context, _ string, _ string) (io.ReadCloser, error) {
+	c.calls++
+	return nil, errors.New("permission denied")
+}
+
+func TestReadTerraformBackendGCSInternal_Error(t *testing.T) {
+	client := &errorGCSClient{}
+
+	componentSections := map[string]any{"workspace": "plat-ue2-dev"}
+	backend := map[string]any{"bucket": "tfstate", "prefix": "vpc"}
+
+	_, err := tb.ReadTerraformBackendGCSInternal(client, &componentSections, &backend)
+	assert.ErrorIs(t, err, errUtils.ErrGetObjectFromGCS)
+	assert.Equal(t, 3, client.calls)
+}
+
+func TestGetGCSBackendCredentials(t *testing.T) {
+	t.Setenv("GOOGLE_BACKEND_CREDENTIALS", "")
+	t.Setenv("GOOGLE_CREDENTIALS", "")
+
+	backend := map[string]any{"credentials": "/path/to/key.json"}
+	assert.Equal(t, "/path/to/key.json", tb.GetGCSBackendCredentials(&backend))
+
+	backend = map[string]any{}
+	assert.Equal(t, "", tb.GetGCSBackendCredentials(&backend))
+
+	t.Setenv("GOOGLE_CREDENTIALS", "google-credentials")
+	assert.Equal(t, "google-credentials", tb.GetGCSBackendCredentials(&backend))
+
+	t.Setenv("GOOGLE_BACKEND_CREDENTIALS", "backend-credentials")
+	assert.Equal(t, "backend-credentials", tb.GetGCSBackendCredentials(&backend))
+}
+
+func TestGetGCSBackendImpersonateServiceAccount(t *testing.T) {
+	t.Setenv("GOOGLE_BACKEND_IMPERSONATE_SERVICE_ACCOUNT", "")
+	t.Setenv("GOOGLE_IMPERSONATE_SERVICE_ACCOUNT", "")
+
+	backend := map[string]any{"impersonate_service_account": "tf@project.iam.gserviceaccount.com"}
+	assert.Equal(t, "tf@project.iam.gserviceaccount.com", tb.GetGCSBackendImpersonateServiceAccount(&backend))
+
+	backend = map[string]any{}
+	assert.Equal(t, "", tb.GetGCSBackendImpersonateServiceAccount(&backend))
+
+	t.Setenv("GOOGLE_IMPERSONATE_SERVICE_ACCOUNT", "sa@project.iam.gserviceaccount.com")
+	assert.Equal(t, "sa@project.iam.gserviceaccount.com", tb.GetGCSBackendImpersonateServiceAccount(&backend))
+}
+
+// fakeGCSGenerationClient serves the generations of the objects from a map keyed by `<bucket>/<object>`.
+type fakeGCSGenerationClient struct {
+	generations map[string]int64
+	err         error
+}
+
+func (c *fakeGCSGenerationClient) ObjectGeneration(_ context.Context, bucket string, object string) (int64, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	generation, ok := c.generations[bucket+"/"+object]
+	if !ok {
+		return 0, storage.ErrObjectNotExist
+	}
+	return generation, nil
+}
+
+func TestGetTerraformBackendGCSStateVersionInternal(t *testing.T) {
+	client := &fakeGCSGenerationClient{generations: map[string]int64{"tfstate/vpc/plat-ue2-dev.tfstate": 1712345678}}
+	backend := map[string]any{"bucket": "tfstate", "prefix": "vpc"}
+
+	componentSections := map[string]any{"workspace": "plat-ue2-dev"}
+	location, version, err := tb.GetTerraformBackendGCSStateVersionInternal(client, &componentSections, &backend)
+	require.NoError(t, err)
+	assert.Equal(t, "gs://tfstate/vpc/plat-ue2-dev.tfstate", location)
+	assert.Equal(t, "1712345678", version)
+
+	// The state file does not exist
+	componentSections = map[string]any{"workspace": "plat-ue2-prod"}
+	_, version, err = tb.GetTerraformBackendGCSStateVersionInternal(client, &componentSections, &backend)
+	require.NoError(t, err)
+	assert.Empty(t, version)
+
+	client.err = errors.New("permission denied")
+	_, _, err = tb.GetTerraformBackendGCSStateVersionInternal(client, &componentSections, &backend)
+	assert.ErrorIs(t, err, errUtils.ErrGetObjectFromGCS)
+}
@@ -0,0 +1,106 @@
+package terraform_backend
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+
+	log "github.com/charmbracelet/log"
+
+	errUtils "github.com/cloudposse/atmos/errors"
+	cfg "github.com/cloudposse/atmos/pkg/config"
+	"github.com/cloudposse/atmos/pkg/schema"
+)
+
+// HTTPClient defines an interface for sending HTTP requests.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// httpClientCache caches the HTTP clients, with and without TLS certificate verification.
+// It's a map[bool]HTTPClient.
+var httpClientCache sync.Map
+
+func getCachedHTTPClient(skipCertVerification bool) HTTPClient {
+	if cached, ok := httpClientCache.Load(skipCertVerification); ok {
+		return cached.(HTTPClient)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if skipCertVerification {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} //nolint:gosec
+	}
+
+	client := &http.Client{Transport: transport}
+	httpClientCache.Store(skipCertVerification, client)
+	return client
+}
+
+// getHTTPBackendAttribute returns an attribute from the http backend config.
+// Like Terraform, it falls back to the given `TF_HTTP_*` ENV var.
+// https://developer.hashicorp.com/terraform/language/backend/http#configuration-variables
+func getHTTPBackendAttribute(backend *map[string]any, attribute string, envVar string) string {
+	if value := GetBackendAttribute(backend, attribute); value != "" {
+		return value
+	}
+	return os.Getenv(envVar)
+}
+
+// ReadTerraformBackendHTTP reads the Terraform state file from the configured http backend.
+// If the state does not exist, the function returns `nil`.
+func ReadTerraformBackendHTTP(
+	_ *schema.AtmosConfiguration,
+	componentSections *map[string]any,
+) ([]byte, error) {
+	backend := GetComponentBackend(componentSections)
+
+	skipCertVerification, _ := backend["skip_cert_verification"].(bool)
+	return ReadTerraformBackendHTTPInternal(getCachedHTTPClient(skipCertVerification), componentSections, &backend)
+}
+
+// ReadTerraformBackendHTTPInternal accepts an HTTP client and reads the Terraform state file from the configured http backend.
+// The http backend does not support workspaces, so the state is always read from `address`.
+func ReadTerraformBackendHTTPInternal(
+	httpClient HTTPClient,
+	_ *map[string]any,
+	backend *map[string]any,
+) ([]byte, error) {
+	address := getHTTPBackendAttribute(backend, "address", "TF_HTTP_ADDRESS")
+	username := getHTTPBackendAttribute(backend, "username", "TF_HTTP_USERNAME")
+	password := getHTTPBackendAttribute(backend, "password", "TF_HTTP_PASSWORD")
+
+	content, err := readTerraformStateWithRetry(cfg.BackendTypeHTTP, func(ctx context.Context) ([]byte, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, address, nil)
+		if err != nil {
+			return nil, err
+		}
+		if username != "" || password != "" {
+			req.SetBasicAuth(username, password)
+		}
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		switch resp.StatusCode {
+		case http.StatusOK:
+			return io.ReadAll(resp.Body)
+		// Like Terraform, treat `No Content` and `Not Found` as a state that does not exist.
+		case http.StatusNoContent, http.StatusNotFound:
+			log.Debug("Terraform state doesn't exist in the HTTP backend; returning 'null'", "address", address)
+			return nil, nil
+		default:
+			return nil, fmt.Errorf("unexpected HTTP status: %s", resp.Status)
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errUtils.ErrGetStateFromHTTP, err)
+	}
+	return content, nil
+}
@@ -0,0 +1,98 @@
+package terraform_backend_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errUtils "github.com/cloudposse/atmos/errors"
+	tb "github.com/cloudposse/atmos/internal/terraform_backend"
+)
+
+func TestReadTerraformBackendHTTP(t *testing.T) {
+	state := `{"version": 4, "outputs": {"vpc_id": {"value": "vpc-123", "type": "string"}}}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok || username != "atmos" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/state/vpc":
+			_, _ = w.Write([]byte(state))
+		case "/state/empty":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		name            string
+		path            string
+		expectedContent string
+	}{
+		{name: "state exists", path: "/state/vpc", expectedContent: state},
+		{name: "no content", path: "/state/empty"},
+		{name: "not found", path: "/state/missing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			componentSections := map[string]any{
+				"backend": map[string]any{
+					"address":  server.URL + tt.path,
+					"username": "atmos",
+					"password": "secret",
+				},
+			}
+
+			content, err := tb.ReadTerraformBackendHTTP(nil, &componentSections)
+			require.NoError(t, err)
+			if tt.expectedContent == "" {
+				assert.Nil(t, content)
+				return
+			}
+			assert.Equal(t, tt.expectedContent, string(content))
+		})
+	}
+}
+
+func TestReadTerraformBackendHTTP_EnvVars(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, _ := r.BasicAuth()
+		assert.Equal(t, "env-user", username)
+		assert.Equal(t, "env-password", password)
+		_, _ = w.Write([]byte(`{"version": 4}`))
+	}))
+	t.Cleanup(server.Close)
+
+	t.Setenv("TF_HTTP_ADDRESS", server.URL+"/state")
+	t.Setenv("TF_HTTP_USERNAME", "env-user")
+	t.Setenv("TF_HTTP_PASSWORD", "env-password")
+
+	componentSections := map[string]any{"backend": map[string]any{}}
+	content, err := tb.ReadTerraformBackendHTTP(nil, &componentSections)
+	require.NoError(t, err)
+	assert.Equal(t, `{"version": 4}`, string(content))
+}
+
+func TestReadTerraformBackendHTTP_Error(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	t.Cleanup(server.Close)
+
+	componentSections := map[string]any{"backend": map[string]any{"address": server.URL}}
+	_, err := tb.ReadTerraformBackendHTTP(nil, &componentSections)
+	assert.ErrorIs(t, err, errUtils.ErrGetStateFromHTTP)
+	assert.Equal(t, 3, requests)
+}
@@ -0,0 +1,131 @@
+package terraform_backend
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	log "github.com/charmbracelet/log"
+	"github.com/lib/pq"
+
+	errUtils "github.com/cloudposse/atmos/errors"
+	cfg "github.com/cloudposse/atmos/pkg/config"
+	"github.com/cloudposse/atmos/pkg/schema"
+)
+
+// pgDefaultSchemaName is the default schema of the `states` table when `schema_name` is not set in the backend config.
+const pgDefaultSchemaName = "terraform_remote_state"
+
+// PgAPI defines an interface for reading the Terraform state of a workspace from the `states` table of a Postgres database.
+// If the workspace does not exist, QueryState returns `sql.ErrNoRows`.
+type PgAPI interface {
+	QueryState(ctx context.Context, schemaName string, workspace string) ([]byte, error)
+}
+
+// pgClient implements PgAPI with a Postgres database handle.
+type pgClient struct {
+	db *sql.DB
+}
+
+func (c *pgClient) QueryState(ctx context.Context, schemaName string, workspace string) ([]byte, error) {
+	var data []byte
+	query := fmt.Sprintf("SELECT data FROM %s.states WHERE name = $1", pq.QuoteIdentifier(schemaName))
+	if err := c.db.QueryRowContext(ctx, query, workspace).Scan(&data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// NewPgClient wraps a Postgres database handle into a PgAPI.
+func NewPgClient(db *sql.DB) PgAPI {
+	return &pgClient{db: db}
+}
+
+// pgClientCache caches the Postgres clients based on a deterministic cache key.
+// It's a map[string]PgAPI.
+var pgClientCache sync.Map
+
+// getPgBackendAttribute returns an attribute from the pg backend config.
+// Like Terraform, it falls back to the given `PG_*` ENV var.
+// https://developer.hashicorp.com/terraform/language/backend/pg#configuration-variables
+func getPgBackendAttribute(backend *map[string]any, attribute string, envVar string) string {
+	if value := GetBackendAttribute(backend, attribute); value != "" {
+		return value
+	}
+	return os.Getenv(envVar)
+}
+
+func getCachedPgClient(backend *map[string]any) (PgAPI, error) {
+	connStr := getPgBackendAttribute(backend, "conn_str", "PG_CONN_STR")
+
+	// Build a deterministic cache key. The connection string is hashed, since it usually contains a password.
+	cacheKey := fmt.Sprintf("conn_str=%x", sha256.Sum256([]byte(connStr)))
+
+	// Check the cache
+	if cached, ok := pgClientCache.Load(cacheKey); ok {
+		return cached.(PgAPI), nil
+	}
+
+	// Build the Postgres client if not cached.
+	// The connection is established lazily, on the first query.
+	db, err := sql.Open("postgres", connStr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errUtils.ErrCreatePgClient, err)
+	}
+
+	client := NewPgClient(db)
+	pgClientCache.Store(cacheKey, client)
+	return client, nil
+}
+
+// ReadTerraformBackendPg reads the Terraform state file from the configured pg backend.
+// If the state does not exist in the database, the function returns `nil`.
+func ReadTerraformBackendPg(
+	_ *schema.AtmosConfiguration,
+	componentSections *map[string]any,
+) ([]byte, error) {
+	backend := GetComponentBackend(componentSections)
+
+	client, err := getCachedPgClient(&backend)
+	if err != nil {
+		return nil, err
+	}
+
+	return ReadTerraformBackendPgInternal(client, componentSections, &backend)
+}
+
+// ReadTerraformBackendPgInternal accepts a Postgres client and reads the Terraform state file from the configured pg backend.
+// The state of each workspace is stored in a row of the `<schema_name>.states` table.
+func ReadTerraformBackendPgInternal(
+	client PgAPI,
+	componentSections *map[string]any,
+	backend *map[string]any,
+) ([]byte, error) {
+	schemaName := getPgBackendAttribute(backend, "schema_name", "PG_SCHEMA_NAME")
+	if schemaName == "" {
+		schemaName = pgDefaultSchemaName
+	}
+
+	workspace := GetTerraformWorkspace(componentSections)
+	if workspace == "" {
+		workspace = "default"
+	}
+
+	content, err := readTerraformStateWithRetry(cfg.BackendTypePg, func(ctx context.Context) ([]byte, error) {
+		data, err := client.QueryState(ctx, schemaName, workspace)
+		// If the state does not exist (the component in the stack has not been provisioned yet), return a `nil` result and no error.
+		if errors.Is(err, sql.ErrNoRows) {
+			log.Debug("Terraform state doesn't exist in Postgres; returning 'null'", "workspace", workspace, "schema", schemaName)
+			return nil, nil
+		}
+		return data, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errUtils.ErrGetStateFromPg, err)
+	}
+	return content, nil
+}
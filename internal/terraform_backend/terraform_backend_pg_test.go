@@ -0,0 +1,77 @@
+package terraform_backend_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errUtils "github.com/cloudposse/atmos/errors"
+	tb "github.com/cloudposse/atmos/internal/terraform_backend"
+)
+
+// fakePgClient is a PgAPI that serves the states from a map keyed by `<schema>.<workspace>`.
+type fakePgClient struct {
+	states map[string]string
+	err    error
+	calls  int
+}
+
+func (c *fakePgClient) QueryState(_ context.Context, schemaName string, workspace string) ([]byte, error) {
+	c.calls++
+	if c.err != nil {
+		return nil, c.err
+	}
+	state, ok := c.states[schemaName+"."+workspace]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return []byte(state), nil
+}
+
+func TestReadTerraformBackendPgInternal(t *testing.T) {
+	state := `{"version": 4, "outputs": {"vpc_id": {"value": "vpc-123", "type": "string"}}}`
+	client := &fakePgClient{states: map[string]string{
+		"terraform_remote_state.default":      state,
+		"terraform_remote_state.plat-ue2-dev": state,
+		"atmos.plat-ue2-dev":                  state,
+	}}
+
+	tests := []struct {
+		name              string
+		componentSections map[string]any
+		backend           map[string]any
+		expectedContent   string
+	}{
+		{name: "default workspace", componentSections: map[string]any{}, backend: map[string]any{}, expectedContent: state},
+		{name: "workspace", componentSections: map[string]any{"workspace": "plat-ue2-dev"}, backend: map[string]any{}, expectedContent: state},
+		{name: "schema name", componentSections: map[string]any{"workspace": "plat-ue2-dev"}, backend: map[string]any{"schema_name": "atmos"}, expectedContent: state},
+		{name: "state does not exist", componentSections: map[string]any{"workspace": "plat-ue2-prod"}, backend: map[string]any{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := tb.ReadTerraformBackendPgInternal(client, &tt.componentSections, &tt.backend)
+			require.NoError(t, err)
+			if tt.expectedContent == "" {
+				assert.Nil(t, content)
+				return
+			}
+			assert.Equal(t, tt.expectedContent, string(content))
+		})
+	}
+}
+
+func TestReadTerraformBackendPgInternal_Error(t *testing.T) {
+	client := &fakePgClient{err: errors.New("connection refused")}
+
+	componentSections := map[string]any{"workspace": "plat-ue2-dev"}
+	backend := map[string]any{}
+
+	_, err := tb.ReadTerraformBackendPgInternal(client, &componentSections, &backend)
+	assert.ErrorIs(t, err, errUtils.ErrGetStateFromPg)
+	assert.Equal(t, 3, client.calls)
+}
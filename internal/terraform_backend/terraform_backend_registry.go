@@ -1,35 +1,137 @@
 package terraform_backend
 
 import (
+	"fmt"
+	"sort"
+	"sync"
+
+	errUtils "github.com/cloudposse/atmos/errors"
 	cfg "github.com/cloudposse/atmos/pkg/config"
 	"github.com/cloudposse/atmos/pkg/schema"
 )
 
+// TerraformBackendReader reads the raw Terraform state file of a component from a backend.
+// If the state file does not exist (e.g. the component has not been provisioned yet), it returns `nil` and no error.
+type TerraformBackendReader interface {
+	ReadState(atmosConfig *schema.AtmosConfiguration, componentSections *map[string]any) ([]byte, error)
+}
+
 // ReadTerraformBackendFunc defines a function type to read Terraform state from a backend.
 type ReadTerraformBackendFunc func(*schema.AtmosConfiguration, *map[string]any) ([]byte, error)
 
-// terraformBackends is a map of backend types to the functions to read Terraform state.
-var terraformBackends = map[string]ReadTerraformBackendFunc{}
+// ReadState calls f, so that a ReadTerraformBackendFunc can be registered as a TerraformBackendReader.
+func (f ReadTerraformBackendFunc) ReadState(atmosConfig *schema.AtmosConfiguration, componentSections *map[string]any) ([]byte, error) {
+	return f(atmosConfig, componentSections)
+}
+
+// VersionedTerraformBackendReader is implemented by the readers that can get the version of the state file
+// (e.g. the ETag of an S3 object, or the generation of a GCS object) without reading the state file.
+// The persistent state cache uses the version to validate its entries.
+type VersionedTerraformBackendReader interface {
+	TerraformBackendReader
+	// GetStateVersion returns a key that uniquely identifies the location of the state file in the backend,
+	// and the current version of the state file. If the state file does not exist, the version is empty.
+	GetStateVersion(atmosConfig *schema.AtmosConfiguration, componentSections *map[string]any) (string, string, error)
+}
+
+// GetTerraformBackendStateVersionFunc defines a function type to get the location and the version of a state file.
+type GetTerraformBackendStateVersionFunc func(*schema.AtmosConfiguration, *map[string]any) (string, string, error)
+
+// versionedTerraformBackendReader combines the functions to read a state file and to get its version into a VersionedTerraformBackendReader.
+type versionedTerraformBackendReader struct {
+	ReadTerraformBackendFunc
+	getStateVersion GetTerraformBackendStateVersionFunc
+}
+
+func (r versionedTerraformBackendReader) GetStateVersion(atmosConfig *schema.AtmosConfiguration, componentSections *map[string]any) (string, string, error) {
+	return r.getStateVersion(atmosConfig, componentSections)
+}
+
+// NewVersionedTerraformBackendReader returns a VersionedTerraformBackendReader from the functions to read a state file and to get its version.
+func NewVersionedTerraformBackendReader(read ReadTerraformBackendFunc, getStateVersion GetTerraformBackendStateVersionFunc) VersionedTerraformBackendReader {
+	return versionedTerraformBackendReader{ReadTerraformBackendFunc: read, getStateVersion: getStateVersion}
+}
+
+var (
+	// terraformBackends is a map of backend types to the readers of the Terraform state.
+	terraformBackends   = map[string]TerraformBackendReader{}
+	terraformBackendsMu sync.RWMutex
+
+	registerTerraformBackendsOnce sync.Once
+)
 
-// RegisterTerraformBackends registers Terraform backends.
+// builtinTerraformBackends returns the readers of the backend types supported by Atmos.
+func builtinTerraformBackends() map[string]TerraformBackendReader {
+	return map[string]TerraformBackendReader{
+		cfg.BackendTypeLocal:   ReadTerraformBackendFunc(ReadTerraformBackendLocal),
+		cfg.BackendTypeS3:      NewVersionedTerraformBackendReader(ReadTerraformBackendS3, GetTerraformBackendS3StateVersion),
+		cfg.BackendTypeGCS:     NewVersionedTerraformBackendReader(ReadTerraformBackendGCS, GetTerraformBackendGCSStateVersion),
+		cfg.BackendTypeAzurerm: ReadTerraformBackendFunc(ReadTerraformBackendAzurerm),
+		cfg.BackendTypeHTTP:    ReadTerraformBackendFunc(ReadTerraformBackendHTTP),
+		cfg.BackendTypeConsul:  ReadTerraformBackendFunc(ReadTerraformBackendConsul),
+		cfg.BackendTypePg:      ReadTerraformBackendFunc(ReadTerraformBackendPg),
+		cfg.BackendTypeRemote:  ReadTerraformBackendFunc(ReadTerraformBackendRemote),
+		cfg.BackendTypeCloud:   ReadTerraformBackendFunc(ReadTerraformBackendRemote),
+	}
+}
+
+// RegisterTerraformBackends registers the built-in Terraform backends.
+// Readers registered with RegisterTerraformBackend take precedence over the built-in readers.
 func RegisterTerraformBackends() {
-	// Register only once.
-	if len(terraformBackends) > 0 {
-		return
+	registerTerraformBackendsOnce.Do(func() {
+		terraformBackendsMu.Lock()
+		defer terraformBackendsMu.Unlock()
+
+		for backendType, reader := range builtinTerraformBackends() {
+			if _, ok := terraformBackends[backendType]; !ok {
+				terraformBackends[backendType] = reader
+			}
+		}
+	})
+}
+
+// RegisterTerraformBackend registers a reader for a backend type, replacing the reader previously registered for the type.
+// The registry is internal to Atmos. Backend types that are not built in are supported with external readers (see ExternalTerraformBackendReader).
+func RegisterTerraformBackend(backendType string, reader TerraformBackendReader) error {
+	if backendType == "" {
+		return fmt.Errorf("%w: the backend type is required", errUtils.ErrInvalidBackendReader)
+	}
+	if reader == nil {
+		return fmt.Errorf("%w: the reader for the backend type `%s` is nil", errUtils.ErrInvalidBackendReader, backendType)
 	}
 
-	terraformBackends[cfg.BackendTypeLocal] = ReadTerraformBackendLocal
-	terraformBackends[cfg.BackendTypeS3] = ReadTerraformBackendS3
+	terraformBackendsMu.Lock()
+	defer terraformBackendsMu.Unlock()
 
-	// Add other backends once they are implemented.
-	terraformBackends[cfg.BackendTypeAzurerm] = nil
-	terraformBackends[cfg.BackendTypeGCS] = nil
+	terraformBackends[backendType] = reader
+	return nil
+}
+
+// GetTerraformBackendReader accepts a backend type and returns the reader registered for the backend type.
+func GetTerraformBackendReader(backendType string) TerraformBackendReader {
+	terraformBackendsMu.RLock()
+	defer terraformBackendsMu.RUnlock()
+
+	return terraformBackends[backendType]
 }
 
 // GetTerraformBackendReadFunc accepts a backend type and returns a function to read the state file from the backend.
 func GetTerraformBackendReadFunc(backendType string) func(*schema.AtmosConfiguration, *map[string]any) ([]byte, error) {
-	if backendFunc, ok := terraformBackends[backendType]; ok {
-		return backendFunc
+	if reader := GetTerraformBackendReader(backendType); reader != nil {
+		return reader.ReadState
 	}
 	return nil
 }
+
+// GetRegisteredTerraformBackendTypes returns the sorted list of the registered backend types.
+func GetRegisteredTerraformBackendTypes() []string {
+	terraformBackendsMu.RLock()
+	defer terraformBackendsMu.RUnlock()
+
+	backendTypes := make([]string, 0, len(terraformBackends))
+	for backendType := range terraformBackends {
+		backendTypes = append(backendTypes, backendType)
+	}
+	sort.Strings(backendTypes)
+	return backendTypes
+}
@@ -4,9 +4,12 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	errUtils "github.com/cloudposse/atmos/errors"
 	tb "github.com/cloudposse/atmos/internal/terraform_backend"
 	cfg "github.com/cloudposse/atmos/pkg/config"
+	"github.com/cloudposse/atmos/pkg/schema"
 )
 
 func TestGetTerraformBackendReadFunc(t *testing.T) {
@@ -14,4 +17,40 @@ func TestGetTerraformBackendReadFunc(t *testing.T) {
 
 	assert.NotNil(t, tb.GetTerraformBackendReadFunc(cfg.BackendTypeLocal))
 	assert.NotNil(t, tb.GetTerraformBackendReadFunc(cfg.BackendTypeS3))
+	assert.NotNil(t, tb.GetTerraformBackendReadFunc(cfg.BackendTypeGCS))
+	assert.NotNil(t, tb.GetTerraformBackendReadFunc(cfg.BackendTypeAzurerm))
+	assert.NotNil(t, tb.GetTerraformBackendReadFunc(cfg.BackendTypeHTTP))
+	assert.NotNil(t, tb.GetTerraformBackendReadFunc(cfg.BackendTypeConsul))
+	assert.NotNil(t, tb.GetTerraformBackendReadFunc(cfg.BackendTypePg))
+	assert.NotNil(t, tb.GetTerraformBackendReadFunc(cfg.BackendTypeRemote))
+	assert.NotNil(t, tb.GetTerraformBackendReadFunc(cfg.BackendTypeCloud))
+	assert.Nil(t, tb.GetTerraformBackendReadFunc("unknown"))
+}
+
+func TestRegisterTerraformBackend(t *testing.T) {
+	reader := tb.ReadTerraformBackendFunc(func(_ *schema.AtmosConfiguration, componentSections *map[string]any) ([]byte, error) {
+		return []byte(`{"version": 4, "outputs": {"workspace": {"value": "` + tb.GetTerraformWorkspace(componentSections) + `"}}}`), nil
+	})
+	require.NoError(t, tb.RegisterTerraformBackend("test-custom", reader))
+
+	assert.Contains(t, tb.GetRegisteredTerraformBackendTypes(), "test-custom")
+
+	componentSections := map[string]any{
+		"backend_type": "test-custom",
+		"workspace":    "plat-ue2-dev",
+	}
+	outputs, err := tb.GetTerraformBackend(&schema.AtmosConfiguration{}, &componentSections)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"workspace": "plat-ue2-dev"}, outputs)
+
+	assert.ErrorIs(t, tb.RegisterTerraformBackend("", reader), errUtils.ErrInvalidBackendReader)
+	assert.ErrorIs(t, tb.RegisterTerraformBackend("test-nil", nil), errUtils.ErrInvalidBackendReader)
+}
+
+func TestGetTerraformBackend_UnsupportedBackendType(t *testing.T) {
+	componentSections := map[string]any{"backend_type": "unknown"}
+
+	_, err := tb.GetTerraformBackend(&schema.AtmosConfiguration{}, &componentSections)
+	assert.ErrorIs(t, err, errUtils.ErrUnsupportedBackendType)
+	assert.Contains(t, err.Error(), "`consul`")
 }
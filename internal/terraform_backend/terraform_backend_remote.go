@@ -0,0 +1,205 @@
+package terraform_backend
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	log "github.com/charmbracelet/log"
+
+	errUtils "github.com/cloudposse/atmos/errors"
+	cfg "github.com/cloudposse/atmos/pkg/config"
+	"github.com/cloudposse/atmos/pkg/config/go-homedir"
+	"github.com/cloudposse/atmos/pkg/schema"
+)
+
+// terraformCloudDefaultHostname is the hostname of HCP Terraform, used when `hostname` is not set in the backend config.
+const terraformCloudDefaultHostname = "app.terraform.io"
+
+// errTerraformCloudNotFound is returned by the HCP Terraform API calls when the resource does not exist.
+var errTerraformCloudNotFound = errors.New("not found")
+
+// GetRemoteBackendHostname returns the hostname of HCP Terraform or Terraform Enterprise from the remote/cloud backend config.
+func GetRemoteBackendHostname(backend *map[string]any) string {
+	if hostname := GetBackendAttribute(backend, "hostname"); hostname != "" {
+		return hostname
+	}
+	if hostname := os.Getenv("TF_CLOUD_HOSTNAME"); hostname != "" {
+		return hostname
+	}
+	return terraformCloudDefaultHostname
+}
+
+// GetRemoteBackendWorkspaceName returns the name of the HCP Terraform workspace that holds the state of the Terraform workspace.
+// https://developer.hashicorp.com/terraform/language/backend/remote#workspaces
+// https://developer.hashicorp.com/terraform/cli/cloud/settings#workspaces
+func GetRemoteBackendWorkspaceName(backend *map[string]any, workspace string) string {
+	workspaces, _ := (*backend)["workspaces"].(map[string]any)
+	if workspaces != nil {
+		if name := GetBackendAttribute(&workspaces, "name"); name != "" {
+			return name
+		}
+		if prefix := GetBackendAttribute(&workspaces, "prefix"); prefix != "" {
+			return prefix + workspace
+		}
+	}
+	if name := os.Getenv("TF_WORKSPACE"); name != "" {
+		return name
+	}
+	return workspace
+}
+
+// GetRemoteBackendToken returns the API token for the hostname.
+// Like Terraform, it's read from the `token` attribute, the `TF_TOKEN_<hostname>` ENV var,
+// or the `~/.terraform.d/credentials.tfrc.json` file written by `terraform login`.
+func GetRemoteBackendToken(backend *map[string]any, hostname string) string {
+	if token := GetBackendAttribute(backend, "token"); token != "" {
+		return token
+	}
+
+	// https://developer.hashicorp.com/terraform/cli/config/config-file#environment-variable-credentials
+	envVar := "TF_TOKEN_" + strings.ReplaceAll(strings.ReplaceAll(hostname, "-", "__"), ".", "_")
+	if token := os.Getenv(envVar); token != "" {
+		return token
+	}
+
+	credentialsFile, err := homedir.Expand(filepath.Join("~", ".terraform.d", "credentials.tfrc.json"))
+	if err != nil {
+		return ""
+	}
+	data, err := os.ReadFile(credentialsFile)
+	if err != nil {
+		return ""
+	}
+
+	var credentials struct {
+		Credentials map[string]struct {
+			Token string `json:"token"`
+		} `json:"credentials"`
+	}
+	if err := json.Unmarshal(data, &credentials); err != nil {
+		return ""
+	}
+	return credentials.Credentials[hostname].Token
+}
+
+// ReadTerraformBackendRemote reads the Terraform state file from the configured remote or cloud backend.
+// If the workspace or its state does not exist, the function returns `nil`.
+func ReadTerraformBackendRemote(
+	_ *schema.AtmosConfiguration,
+	componentSections *map[string]any,
+) ([]byte, error) {
+	backend := GetComponentBackend(componentSections)
+	baseURL := "https://" + GetRemoteBackendHostname(&backend)
+
+	return ReadTerraformBackendRemoteInternal(getCachedHTTPClient(false), baseURL, componentSections, &backend)
+}
+
+// ReadTerraformBackendRemoteInternal accepts an HTTP client and the base URL of the HCP Terraform API,
+// and reads the current state version of the workspace.
+func ReadTerraformBackendRemoteInternal(
+	httpClient HTTPClient,
+	baseURL string,
+	componentSections *map[string]any,
+	backend *map[string]any,
+) ([]byte, error) {
+	token := GetRemoteBackendToken(backend, GetRemoteBackendHostname(backend))
+	if token == "" {
+		return nil, fmt.Errorf("%w: set the `token` attribute in the backend config or run `terraform login`", errUtils.ErrTerraformCloudTokenRequired)
+	}
+
+	organization := GetBackendAttribute(backend, "organization")
+	if organization == "" {
+		organization = os.Getenv("TF_CLOUD_ORGANIZATION")
+	}
+	workspaceName := GetRemoteBackendWorkspaceName(backend, GetTerraformWorkspace(componentSections))
+
+	content, err := readTerraformStateWithRetry(cfg.BackendTypeRemote, func(ctx context.Context) ([]byte, error) {
+		content, err := readTerraformCloudCurrentState(ctx, httpClient, baseURL, token, organization, workspaceName)
+		// If the workspace or its state does not exist (the component in the stack has not been provisioned yet), return a `nil` result and no error.
+		if errors.Is(err, errTerraformCloudNotFound) {
+			log.Debug("Terraform state doesn't exist in HCP Terraform; returning 'null'", "organization", organization, "workspace", workspaceName)
+			return nil, nil
+		}
+		return content, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errUtils.ErrGetStateFromTerraformCloud, err)
+	}
+	return content, nil
+}
+
+// readTerraformCloudCurrentState downloads the current state version of an HCP Terraform workspace.
+func readTerraformCloudCurrentState(
+	ctx context.Context,
+	httpClient HTTPClient,
+	baseURL string,
+	token string,
+	organization string,
+	workspaceName string,
+) ([]byte, error) {
+	var workspace struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	workspaceURL := fmt.Sprintf("%s/api/v2/organizations/%s/workspaces/%s", baseURL, url.PathEscape(organization), url.PathEscape(workspaceName))
+	if err := getTerraformCloudJSON(ctx, httpClient, token, workspaceURL, &workspace); err != nil {
+		return nil, err
+	}
+
+	var stateVersion struct {
+		Data struct {
+			Attributes struct {
+				DownloadURL string `json:"hosted-state-download-url"`
+			} `json:"attributes"`
+		} `json:"data"`
+	}
+	stateVersionURL := fmt.Sprintf("%s/api/v2/workspaces/%s/current-state-version", baseURL, url.PathEscape(workspace.Data.ID))
+	if err := getTerraformCloudJSON(ctx, httpClient, token, stateVersionURL, &stateVersion); err != nil {
+		return nil, err
+	}
+
+	return getTerraformCloud(ctx, httpClient, token, stateVersion.Data.Attributes.DownloadURL)
+}
+
+// getTerraformCloud sends an authenticated GET request to the HCP Terraform API and returns the response body.
+func getTerraformCloud(ctx context.Context, httpClient HTTPClient, token string, requestURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/vnd.api+json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return io.ReadAll(resp.Body)
+	case http.StatusNotFound:
+		return nil, errTerraformCloudNotFound
+	default:
+		return nil, fmt.Errorf("unexpected HTTP status from `%s`: %s", requestURL, resp.Status)
+	}
+}
+
+// getTerraformCloudJSON sends an authenticated GET request to the HCP Terraform API and decodes the JSON response into v.
+func getTerraformCloudJSON(ctx context.Context, httpClient HTTPClient, token string, requestURL string, v any) error {
+	body, err := getTerraformCloud(ctx, httpClient, token, requestURL)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
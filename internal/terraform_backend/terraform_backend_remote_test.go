@@ -0,0 +1,172 @@
+package terraform_backend_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errUtils "github.com/cloudposse/atmos/errors"
+	tb "github.com/cloudposse/atmos/internal/terraform_backend"
+	"github.com/cloudposse/atmos/pkg/config/go-homedir"
+)
+
+// newFakeTerraformCloud starts a fake HCP Terraform API that serves the state of the given workspaces of the `acme` organization.
+func newFakeTerraformCloud(t *testing.T, states map[string]string) *httptest.Server {
+	mux := http.NewServeMux()
+	var server *httptest.Server
+
+	authorized := func(w http.ResponseWriter, r *http.Request) bool {
+		if r.Header.Get("Authorization") != "Bearer tfc-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return false
+		}
+		return true
+	}
+
+	mux.HandleFunc("/api/v2/organizations/acme/workspaces/{name}", func(w http.ResponseWriter, r *http.Request) {
+		if !authorized(w, r) {
+			return
+		}
+		name := r.PathValue("name")
+		if name == "no-state" {
+			_, _ = fmt.Fprint(w, `{"data": {"id": "ws-no-state"}}`)
+			return
+		}
+		if _, ok := states[name]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"data": {"id": "ws-%s"}}`, name)
+	})
+
+	mux.HandleFunc("/api/v2/workspaces/{id}/current-state-version", func(w http.ResponseWriter, r *http.Request) {
+		if !authorized(w, r) {
+			return
+		}
+		id := r.PathValue("id")
+		if id == "ws-no-state" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"data": {"attributes": {"hosted-state-download-url": "%s/state/%s"}}}`, server.URL, id[len("ws-"):])
+	})
+
+	mux.HandleFunc("/state/{name}", func(w http.ResponseWriter, r *http.Request) {
+		if !authorized(w, r) {
+			return
+		}
+		_, _ = fmt.Fprint(w, states[r.PathValue("name")])
+	})
+
+	server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestReadTerraformBackendRemoteInternal(t *testing.T) {
+	state := `{"version": 4, "outputs": {"vpc_id": {"value": "vpc-123", "type": "string"}}}`
+	server := newFakeTerraformCloud(t, map[string]string{
+		"vpc":              state,
+		"vpc-plat-ue2-dev": state,
+		"plat-ue2-dev":     state,
+	})
+
+	tests := []struct {
+		name              string
+		componentSections map[string]any
+		backend           map[string]any
+		expectedContent   string
+	}{
+		{
+			name:              "workspace name",
+			componentSections: map[string]any{"workspace": "plat-ue2-dev"},
+			backend:           map[string]any{"organization": "acme", "token": "tfc-token", "workspaces": map[string]any{"name": "vpc"}},
+			expectedContent:   state,
+		},
+		{
+			name:              "workspace prefix",
+			componentSections: map[string]any{"workspace": "plat-ue2-dev"},
+			backend:           map[string]any{"organization": "acme", "token": "tfc-token", "workspaces": map[string]any{"prefix": "vpc-"}},
+			expectedContent:   state,
+		},
+		{
+			name:              "workspace tags",
+			componentSections: map[string]any{"workspace": "plat-ue2-dev"},
+			backend:           map[string]any{"organization": "acme", "token": "tfc-token", "workspaces": map[string]any{"tags": []any{"vpc"}}},
+			expectedContent:   state,
+		},
+		{
+			name:              "workspace does not exist",
+			componentSections: map[string]any{"workspace": "plat-ue2-prod"},
+			backend:           map[string]any{"organization": "acme", "token": "tfc-token"},
+		},
+		{
+			name:              "workspace has no state",
+			componentSections: map[string]any{},
+			backend:           map[string]any{"organization": "acme", "token": "tfc-token", "workspaces": map[string]any{"name": "no-state"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := tb.ReadTerraformBackendRemoteInternal(http.DefaultClient, server.URL, &tt.componentSections, &tt.backend)
+			require.NoError(t, err)
+			if tt.expectedContent == "" {
+				assert.Nil(t, content)
+				return
+			}
+			assert.Equal(t, tt.expectedContent, string(content))
+		})
+	}
+}
+
+func TestReadTerraformBackendRemoteInternal_Errors(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("TF_TOKEN_app_terraform_io", "")
+	homedir.Reset()
+	t.Cleanup(homedir.Reset)
+
+	server := newFakeTerraformCloud(t, map[string]string{"vpc": "{}"})
+	componentSections := map[string]any{}
+
+	backend := map[string]any{"organization": "acme", "workspaces": map[string]any{"name": "vpc"}}
+	_, err := tb.ReadTerraformBackendRemoteInternal(http.DefaultClient, server.URL, &componentSections, &backend)
+	assert.ErrorIs(t, err, errUtils.ErrTerraformCloudTokenRequired)
+
+	backend["token"] = "invalid"
+	_, err = tb.ReadTerraformBackendRemoteInternal(http.DefaultClient, server.URL, &componentSections, &backend)
+	assert.ErrorIs(t, err, errUtils.ErrGetStateFromTerraformCloud)
+}
+
+func TestGetRemoteBackendToken(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	homedir.Reset()
+	t.Cleanup(homedir.Reset)
+	t.Setenv("TF_TOKEN_app_terraform_io", "")
+	t.Setenv("TF_TOKEN_tfe_my__company_com", "")
+
+	backend := map[string]any{"token": "backend-token"}
+	assert.Equal(t, "backend-token", tb.GetRemoteBackendToken(&backend, "app.terraform.io"))
+
+	backend = map[string]any{}
+	assert.Equal(t, "", tb.GetRemoteBackendToken(&backend, "app.terraform.io"))
+
+	// The token written by `terraform login`
+	require.NoError(t, os.MkdirAll(filepath.Join(home, ".terraform.d"), 0o755))
+	credentials := `{"credentials": {"app.terraform.io": {"token": "file-token"}}}`
+	require.NoError(t, os.WriteFile(filepath.Join(home, ".terraform.d", "credentials.tfrc.json"), []byte(credentials), 0o600))
+	assert.Equal(t, "file-token", tb.GetRemoteBackendToken(&backend, "app.terraform.io"))
+
+	t.Setenv("TF_TOKEN_app_terraform_io", "env-token")
+	assert.Equal(t, "env-token", tb.GetRemoteBackendToken(&backend, "app.terraform.io"))
+
+	t.Setenv("TF_TOKEN_tfe_my__company_com", "tfe-token")
+	assert.Equal(t, "tfe-token", tb.GetRemoteBackendToken(&backend, "tfe.my-company.com"))
+}
@@ -79,6 +79,70 @@ func getCachedS3Client(backend *map[string]any) (S3API, error) {
 	return s3Client, nil
 }
 
+// S3HeadObjectAPI defines an interface for reading the metadata of S3 objects.
+type S3HeadObjectAPI interface {
+	HeadObject(ctx context.Context, input *s3.HeadObjectInput, optFns ...func(*s3.Options)) (*s3.HeadObjectOutput, error)
+}
+
+// getS3BackendStatePath returns the path to the tfstate file in the s3 bucket.
+func getS3BackendStatePath(componentSections *map[string]any, backend *map[string]any) string {
+	return path.Join(
+		GetBackendAttribute(backend, "workspace_key_prefix"),
+		GetTerraformWorkspace(componentSections),
+		GetBackendAttribute(backend, "key"),
+	)
+}
+
+// GetTerraformBackendS3StateVersion returns the location of the Terraform state file in the configured S3 backend,
+// and its ETag as the version. If the state file does not exist, the version is empty.
+func GetTerraformBackendS3StateVersion(
+	_ *schema.AtmosConfiguration,
+	componentSections *map[string]any,
+) (string, string, error) {
+	backend := GetComponentBackend(componentSections)
+
+	s3Client, err := getCachedS3Client(&backend)
+	if err != nil {
+		return "", "", err
+	}
+
+	headClient, ok := s3Client.(S3HeadObjectAPI)
+	if !ok {
+		return "", "", fmt.Errorf("%w: the S3 client does not support reading the object metadata", errUtils.ErrGetObjectFromS3)
+	}
+
+	return GetTerraformBackendS3StateVersionInternal(headClient, componentSections, &backend)
+}
+
+// GetTerraformBackendS3StateVersionInternal accepts an S3 client and returns the location and the ETag of the Terraform state file.
+func GetTerraformBackendS3StateVersionInternal(
+	s3Client S3HeadObjectAPI,
+	componentSections *map[string]any,
+	backend *map[string]any,
+) (string, string, error) {
+	tfStateFilePath := getS3BackendStatePath(componentSections, backend)
+	bucket := GetBackendAttribute(backend, "bucket")
+	location := fmt.Sprintf("s3://%s/%s", bucket, tfStateFilePath)
+
+	// 30 sec timeout to read the metadata of the state file.
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	output, err := s3Client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(tfStateFilePath),
+	})
+	if err != nil {
+		var notFound *types.NotFound
+		if errors.As(err, &notFound) {
+			return location, "", nil
+		}
+		return "", "", fmt.Errorf("%w: %v", errUtils.ErrGetObjectFromS3, err)
+	}
+
+	return location, aws.ToString(output.ETag), nil
+}
+
 // ReadTerraformBackendS3 reads the Terraform state file from the configured S3 backend.
 // If the state file does not exist in the bucket, the function returns `nil`.
 func ReadTerraformBackendS3(
@@ -102,11 +166,7 @@ func ReadTerraformBackendS3Internal(
 	backend *map[string]any,
 ) ([]byte, error) {
 	// Path to the tfstate file in the s3 bucket.
-	tfStateFilePath := path.Join(
-		GetBackendAttribute(backend, "workspace_key_prefix"),
-		GetTerraformWorkspace(componentSections),
-		GetBackendAttribute(backend, "key"),
-	)
+	tfStateFilePath := getS3BackendStatePath(componentSections, backend)
 
 	bucket := GetBackendAttribute(backend, "bucket")
 
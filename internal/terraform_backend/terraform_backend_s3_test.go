@@ -7,6 +7,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/aws/smithy-go"
@@ -326,3 +327,42 @@ func TestGetS3BackendAssumeRoleArn(t *testing.T) {
 		})
 	}
 }
+
+// mockS3HeadObjectClient implements only the HeadObject method used in GetTerraformBackendS3StateVersionInternal.
+type mockS3HeadObjectClient struct {
+	err error
+}
+
+func (m *mockS3HeadObjectClient) HeadObject(_ context.Context, input *s3.HeadObjectInput, _ ...func(*s3.Options)) (*s3.HeadObjectOutput, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if *input.Bucket == "mock-bucket" && *input.Key == "test-prefix/test-workspace/terraform.tfstate" {
+		return &s3.HeadObjectOutput{ETag: aws.String(`"etag-1"`)}, nil
+	}
+	return nil, &s3types.NotFound{}
+}
+
+func TestGetTerraformBackendS3StateVersionInternal(t *testing.T) {
+	backend := map[string]any{
+		"bucket":               "mock-bucket",
+		"key":                  "terraform.tfstate",
+		"workspace_key_prefix": "test-prefix",
+	}
+
+	componentSections := map[string]any{"workspace": "test-workspace"}
+	location, version, err := tb.GetTerraformBackendS3StateVersionInternal(&mockS3HeadObjectClient{}, &componentSections, &backend)
+	assert.NoError(t, err)
+	assert.Equal(t, "s3://mock-bucket/test-prefix/test-workspace/terraform.tfstate", location)
+	assert.Equal(t, `"etag-1"`, version)
+
+	// The state file does not exist
+	componentSections = map[string]any{"workspace": "other-workspace"}
+	location, version, err = tb.GetTerraformBackendS3StateVersionInternal(&mockS3HeadObjectClient{}, &componentSections, &backend)
+	assert.NoError(t, err)
+	assert.Equal(t, "s3://mock-bucket/test-prefix/other-workspace/terraform.tfstate", location)
+	assert.Empty(t, version)
+
+	_, _, err = tb.GetTerraformBackendS3StateVersionInternal(&mockS3HeadObjectClient{err: errors.New("access denied")}, &componentSections, &backend)
+	assert.ErrorIs(t, err, errUtils.ErrGetObjectFromS3)
+}
@@ -1,9 +1,13 @@
 package terraform_backend
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
+
+	log "github.com/charmbracelet/log"
 
 	errUtils "github.com/cloudposse/atmos/errors"
 	cfg "github.com/cloudposse/atmos/pkg/config"
@@ -51,6 +55,28 @@ func GetBackendAttribute(section *map[string]any, attribute string) string {
 	return ""
 }
 
+// readTerraformStateWithRetry calls read until it succeeds, up to `maxRetryCount` retries with a backoff between attempts,
+// and returns the last error if all attempts fail. Each attempt has a 30 sec timeout.
+// If the state file does not exist, read should return `nil` and no error.
+func readTerraformStateWithRetry(backendType string, read func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	var lastErr error
+	for attempt := 0; attempt <= maxRetryCount; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		content, err := read(ctx)
+		cancel()
+		if err == nil {
+			return content, nil
+		}
+
+		lastErr = err
+		if attempt < maxRetryCount {
+			log.Debug("Failed to read Terraform state file", "backend", backendType, "attempt", attempt+1, "error", err)
+			time.Sleep(time.Second * 2) // backoff
+		}
+	}
+	return nil, lastErr
+}
+
 // GetTerraformBackendVariable returns the output from the configured backend.
 func GetTerraformBackendVariable(
 	atmosConfig *schema.AtmosConfiguration,
@@ -114,12 +140,20 @@ func GetTerraformBackend(
 		backendType = cfg.BackendTypeLocal
 	}
 
-	readBackendStateFunc := GetTerraformBackendReadFunc(backendType)
-	if readBackendStateFunc == nil {
-		return nil, fmt.Errorf("%w: `%s`\nsupported backends: `local`, `s3`", errUtils.ErrUnsupportedBackendType, backendType)
+	// The external readers configured in `atmos.yaml` take precedence over the registered readers.
+	reader := GetExternalTerraformBackendReader(atmosConfig, backendType)
+	if reader == nil {
+		reader = GetTerraformBackendReader(backendType)
+	}
+	if reader == nil {
+		return nil, fmt.Errorf("%w: `%s`\nsupported backends: `%s`\nother backends can be read with the external readers configured in `components.terraform.backend_readers`",
+			errUtils.ErrUnsupportedBackendType,
+			backendType,
+			strings.Join(GetRegisteredTerraformBackendTypes(), "`, `"),
+		)
 	}
 
-	content, err := readBackendStateFunc(atmosConfig, componentSections)
+	content, err := reader.ReadState(atmosConfig, componentSections)
 	if err != nil {
 		return nil, err
 	}
@@ -80,10 +80,20 @@ const (
 	BackendTypeAzurerm                = "azurerm"
 	BackendTypeGCS                    = "gcs"
 	BackendTypeCloud                  = "cloud"
+	BackendTypeRemote                 = "remote"
+	BackendTypeHTTP                   = "http"
+	BackendTypeConsul                 = "consul"
+	BackendTypePg                     = "pg"
 
 	LogsLevelFlag = "--logs-level"
 	LogsFileFlag  = "--logs-file"
 
+	RefreshStoresFlag       = "--refresh-stores"
+	RefreshStoresEnvVarName = "ATMOS_REFRESH_STORES"
+
+	SkipCacheFlag       = "--skip-cache"
+	SkipCacheEnvVarName = "ATMOS_SKIP_CACHE"
+
 	QueryFlag    = "--query"
 	AffectedFlag = "--affected"
 	AllFlag      = "--all"
@@ -283,6 +283,19 @@ func processEnvVars(atmosConfig *schema.AtmosConfiguration) error {
 		atmosConfig.Components.Terraform.Plan.SkipPlanfile = planSkipPlanfileBool
 	}
 
+	componentsTerraformStateCacheEnabled := os.Getenv("ATMOS_COMPONENTS_TERRAFORM_STATE_CACHE_ENABLED")
+	if len(componentsTerraformStateCacheEnabled) > 0 {
+		log.Debug(foundEnvVarMessage, "ATMOS_COMPONENTS_TERRAFORM_STATE_CACHE_ENABLED", componentsTerraformStateCacheEnabled)
+		stateCacheEnabledBool, err := strconv.ParseBool(componentsTerraformStateCacheEnabled)
+		if err != nil {
+			return err
+		}
+		if atmosConfig.Components.Terraform.StateCache == nil {
+			atmosConfig.Components.Terraform.StateCache = &schema.TerraformStateCache{}
+		}
+		atmosConfig.Components.Terraform.StateCache.Enabled = stateCacheEnabledBool
+	}
+
 	componentsTerraformAutoGenerateBackendFile := os.Getenv("ATMOS_COMPONENTS_TERRAFORM_AUTO_GENERATE_BACKEND_FILE")
 	if len(componentsTerraformAutoGenerateBackendFile) > 0 {
 		log.Debug(foundEnvVarMessage, "ATMOS_COMPONENTS_TERRAFORM_AUTO_GENERATE_BACKEND_FILE", componentsTerraformAutoGenerateBackendFile)
@@ -587,7 +600,9 @@ func processStoreConfig(atmosConfig *schema.AtmosConfiguration) error {
 		log.Debug("processStoreConfig", "atmosConfig.StoresConfig", fmt.Sprintf("%v", atmosConfig.StoresConfig))
 	}
 
-	storeRegistry, err := store.NewStoreRegistry(&atmosConfig.StoresConfig)
+	storeRegistry, err := store.NewStoreRegistryWithOptions(&atmosConfig.StoresConfig, store.RegistryOptions{
+		RefreshCache: refreshStores(),
+	})
 	if err != nil {
 		return err
 	}
@@ -596,6 +611,24 @@ func processStoreConfig(atmosConfig *schema.AtmosConfiguration) error {
 	return nil
 }
 
+// refreshStores returns true if the store caches should be bypassed,
+// which is requested with the `--refresh-stores` flag or the `ATMOS_REFRESH_STORES` ENV var
+func refreshStores() bool {
+	if v, ok := parseFlags()[strings.TrimPrefix(RefreshStoresFlag, "--")]; ok {
+		return v != "false"
+	}
+	return os.Getenv(RefreshStoresEnvVarName) == "true"
+}
+
+// SkipCache returns true if the persistent cache of the Terraform state and outputs should be bypassed,
+// which is requested with the `--skip-cache` flag or the `ATMOS_SKIP_CACHE` ENV var
+func SkipCache() bool {
+	if v, ok := parseFlags()[strings.TrimPrefix(SkipCacheFlag, "--")]; ok {
+		return v != "false"
+	}
+	return os.Getenv(SkipCacheEnvVarName) == "true"
+}
+
 // GetContextFromVars creates a context object from the provided variables
 func GetContextFromVars(vars map[string]any) schema.Context {
 	var context schema.Context
@@ -103,3 +103,18 @@ func GetRepoInfo(localRepo *git.Repository) (RepoInfo, error) {
 
 	return response, nil
 }
+
+// GetCurrentCommitSHA returns the SHA of the commit checked out in the local repo.
+func GetCurrentCommitSHA() (string, error) {
+	localRepo, err := GetLocalRepo()
+	if err != nil {
+		return "", err
+	}
+
+	head, err := localRepo.Head()
+	if err != nil {
+		return "", err
+	}
+
+	return head.Hash().String(), nil
+}
@@ -1,9 +1,29 @@
 package hooks
 
-import "github.com/spf13/cobra"
+import (
+	"context"
+	"fmt"
 
-// Command is the interface for all commands that can be run by hooks
+	"github.com/spf13/cobra"
+
+	errUtils "github.com/cloudposse/atmos/errors"
+	e "github.com/cloudposse/atmos/internal/exec"
+	"github.com/cloudposse/atmos/pkg/schema"
+)
+
+// Command is the interface for all commands that can be run by hooks.
+// The context is canceled when the hook times out.
 type Command interface {
 	GetName() string
-	RunE(hook *Hook, event HookEvent, cmd *cobra.Command, args []string) error
+	RunE(ctx context.Context, hook *Hook, payload *EventPayload, cmd *cobra.Command, args []string) error
+}
+
+// render renders a Go template in the hook config (e.g. `run` or a header) against the component's sections.
+// The Atmos template functions (e.g. `atmos.Store`) use the CLI config and the component info.
+func render(atmosConfig *schema.AtmosConfiguration, info *schema.ConfigAndStacksInfo, hook *Hook, sections map[string]any, name string, value string) (string, error) {
+	rendered, err := e.ProcessTmplWithConfig(atmosConfig, info, fmt.Sprintf("hook-%s-%s", hook.Command, name), value, sections, false)
+	if err != nil {
+		return "", fmt.Errorf("%w: failed to render `%s`: %v", errUtils.ErrInvalidHook, name, err)
+	}
+	return rendered, nil
 }
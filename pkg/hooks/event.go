@@ -1,10 +1,55 @@
 package hooks
 
+import "fmt"
+
 type HookEvent string
 
 const (
-	AfterTerraformApply  HookEvent = "after.terraform.apply"
-	BeforeTerraformApply HookEvent = "before.terraform.apply"
-	AfterTerraformPlan   HookEvent = "after.terraform.plan"
-	BeforeTerraformPlan  HookEvent = "before.terraform.plan"
+	AfterTerraformInit       HookEvent = "after.terraform.init"
+	BeforeTerraformInit      HookEvent = "before.terraform.init"
+	AfterTerraformPlan       HookEvent = "after.terraform.plan"
+	BeforeTerraformPlan      HookEvent = "before.terraform.plan"
+	AfterTerraformApply      HookEvent = "after.terraform.apply"
+	BeforeTerraformApply     HookEvent = "before.terraform.apply"
+	AfterTerraformDeploy     HookEvent = "after.terraform.deploy"
+	BeforeTerraformDeploy    HookEvent = "before.terraform.deploy"
+	AfterTerraformDestroy    HookEvent = "after.terraform.destroy"
+	BeforeTerraformDestroy   HookEvent = "before.terraform.destroy"
+	AfterTerraformWorkspace  HookEvent = "after.terraform.workspace"
+	BeforeTerraformWorkspace HookEvent = "before.terraform.workspace"
+
+	AfterHelmfileSync     HookEvent = "after.helmfile.sync"
+	BeforeHelmfileSync    HookEvent = "before.helmfile.sync"
+	AfterHelmfileApply    HookEvent = "after.helmfile.apply"
+	BeforeHelmfileApply   HookEvent = "before.helmfile.apply"
+	AfterHelmfileDestroy  HookEvent = "after.helmfile.destroy"
+	BeforeHelmfileDestroy HookEvent = "before.helmfile.destroy"
+	AfterHelmfileDiff     HookEvent = "after.helmfile.diff"
+	BeforeHelmfileDiff    HookEvent = "before.helmfile.diff"
 )
+
+// hookSubCommands are the sub-commands of each component type that trigger hook events.
+var hookSubCommands = map[string][]string{
+	"terraform": {"init", "plan", "apply", "deploy", "destroy", "workspace"},
+	"helmfile":  {"sync", "apply", "destroy", "diff"},
+}
+
+// eventAliases maps the events to the events that they also trigger. `terraform deploy` runs `terraform apply`,
+// so the hooks configured for `terraform apply` also run for `terraform deploy`.
+var eventAliases = map[HookEvent]HookEvent{
+	AfterTerraformDeploy:  AfterTerraformApply,
+	BeforeTerraformDeploy: BeforeTerraformApply,
+}
+
+// GetHookEvents returns the `before` and `after` events of a sub-command of a component type (e.g. `terraform` and `plan`).
+// It returns `false` if the sub-command does not trigger hook events.
+func GetHookEvents(componentType string, subCommand string) (HookEvent, HookEvent, bool) {
+	for _, c := range hookSubCommands[componentType] {
+		if c == subCommand {
+			return HookEvent(fmt.Sprintf("before.%s.%s", componentType, subCommand)),
+				HookEvent(fmt.Sprintf("after.%s.%s", componentType, subCommand)),
+				true
+		}
+	}
+	return "", "", false
+}
@@ -1,14 +1,65 @@
 package hooks
 
+import "strings"
+
 // Hook is the structure for a hook and is using in the stack config to define
 // a command that should be run when a specific event occurs.
 type Hook struct {
 	Events  []string `yaml:"events"`
 	Command string   `yaml:"command"`
 
+	// Properties supported by all commands
+
+	// OnFailure is what to do if the hook fails: `fail` (the default), `warn` or `ignore`
+	OnFailure string `yaml:"on_failure,omitempty"`
+	// Timeout is the maximum duration of each attempt to run the hook, e.g. `30s`
+	Timeout string `yaml:"timeout,omitempty"`
+	// Retries is the number of times to retry the hook if it fails
+	Retries int `yaml:"retries,omitempty"`
+	// Order is the position of the hook relative to the other hooks of the event. Hooks with a lower order run first
+	Order int `yaml:"order,omitempty"`
+	// DependsOn are the hooks that must run before the hook. The hook is skipped if any of them fails
+	DependsOn []string `yaml:"depends_on,omitempty"`
+	// When is a Go template that must render to `true` for the hook to run
+	When string `yaml:"when,omitempty"`
+
 	// Dynamic command-specific properties
 
 	// store command
 	Name    string            `yaml:"name,omitempty"`    // for store command
 	Outputs map[string]string `yaml:"outputs,omitempty"` // for store command
+	// Include are glob patterns of the outputs to publish under their own names, e.g. `*` for all the outputs
+	Include   []string `yaml:"include,omitempty"`   // for store command
+	Exclude   []string `yaml:"exclude,omitempty"`   // for store command
+	Sensitive string   `yaml:"sensitive,omitempty"` // for store command
+
+	// shell command
+	Run  string   `yaml:"run,omitempty"`  // for shell command
+	Args []string `yaml:"args,omitempty"` // for shell command
+
+	// webhook command
+	URL         string            `yaml:"url,omitempty"`          // for webhook command
+	Headers     map[string]string `yaml:"headers,omitempty"`      // for webhook command
+	Secret      string            `yaml:"secret,omitempty"`       // for webhook command
+	PlanSummary bool              `yaml:"plan_summary,omitempty"` // for webhook command
+}
+
+// MatchesEvent returns true if the hook should run for the event. Events can be configured with either dots or
+// dashes as separators, e.g. `after.terraform.apply` or `after-terraform-apply`. Hooks without events run after
+// `terraform apply`. The hooks configured for an event also run for the events that alias it (e.g. `terraform deploy`
+// runs the hooks configured for `terraform apply`).
+func (h *Hook) MatchesEvent(event HookEvent) bool {
+	alias, hasAlias := eventAliases[event]
+
+	if len(h.Events) == 0 {
+		return event == AfterTerraformApply || (hasAlias && alias == AfterTerraformApply)
+	}
+
+	for _, e := range h.Events {
+		configured := HookEvent(strings.ReplaceAll(e, "-", "."))
+		if configured == event || (hasAlias && configured == alias) {
+			return true
+		}
+	}
+	return false
 }
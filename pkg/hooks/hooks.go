@@ -4,11 +4,13 @@ import (
 	"fmt"
 
 	log "github.com/charmbracelet/log"
+	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v2"
 
 	errUtils "github.com/cloudposse/atmos/errors"
 	e "github.com/cloudposse/atmos/internal/exec"
+	cfg "github.com/cloudposse/atmos/pkg/config"
 	"github.com/cloudposse/atmos/pkg/schema"
 )
 
@@ -16,6 +18,12 @@ type Hooks struct {
 	config *schema.AtmosConfiguration
 	info   *schema.ConfigAndStacksInfo
 	items  map[string]Hook
+	// payload holds the properties of the component shared by the payloads of all the events
+	payload EventPayload
+	// sections are the component's sections, used by the hook commands
+	sections map[string]any
+	// env are the ENV vars from the component's `env` section
+	env []string
 }
 
 func (h Hooks) HasHooks() bool {
@@ -31,12 +39,26 @@ func GetHooks(atmosConfig *schema.AtmosConfiguration, info *schema.ConfigAndStac
 		}, nil
 	}
 
-	sections, err := e.ExecuteDescribeComponent(info.ComponentFromArg, info.Stack, true, true, []string{})
+	componentInfo, err := e.ProcessStacks(*atmosConfig, *info, true, true, true, nil)
 	if err != nil {
-		return &Hooks{}, fmt.Errorf("failed to execute describe component: %w", err)
+		return &Hooks{}, fmt.Errorf("%w: %w", errUtils.ErrDescribeComponent, err)
 	}
 
-	hooksSection := sections["hooks"].(map[string]any)
+	hooks, err := NewHooks(atmosConfig, &componentInfo)
+	if err != nil {
+		return nil, err
+	}
+	hooks.info = info
+
+	return hooks, nil
+}
+
+// NewHooks returns the hooks of a component from the sections of the component already processed from the stacks.
+func NewHooks(atmosConfig *schema.AtmosConfiguration, componentInfo *schema.ConfigAndStacksInfo) (*Hooks, error) {
+	hooksSection, ok := componentInfo.ComponentSection[cfg.HooksSectionName].(map[string]any)
+	if !ok {
+		hooksSection = map[string]any{}
+	}
 
 	yamlData, err := yaml.Marshal(hooksSection)
 	if err != nil {
@@ -49,35 +71,112 @@ func GetHooks(atmosConfig *schema.AtmosConfiguration, info *schema.ConfigAndStac
 		return nil, fmt.Errorf("failed to unmarshal to Hooks: %w", err)
 	}
 
+	payload := EventPayload{
+		ComponentType: componentInfo.ComponentType,
+		Component:     componentInfo.ComponentFromArg,
+		Stack:         componentInfo.Stack,
+		Workspace:     componentInfo.TerraformWorkspace,
+		ComponentPath: e.GetComponentWorkingDir(atmosConfig, componentInfo),
+	}
+	if componentInfo.ComponentType == cfg.TerraformComponentType {
+		payload.PlanFile = e.GetTerraformPlanfilePath(atmosConfig, componentInfo)
+	}
+
 	hooks := Hooks{
-		config: atmosConfig,
-		info:   info,
-		items:  items,
+		config:   atmosConfig,
+		info:     componentInfo,
+		items:    items,
+		payload:  payload,
+		sections: componentInfo.ComponentSection,
+		env:      componentInfo.ComponentEnvList,
 	}
 
 	return &hooks, nil
 }
 
-func (h Hooks) RunAll(event HookEvent, atmosConfig *schema.AtmosConfiguration, info *schema.ConfigAndStacksInfo, cmd *cobra.Command, args []string) error {
-	log.Debug("Running hooks", "count", len(h.items))
+// NewEventPayload returns the payload of an event for the component of the hooks, with the exit code of the command.
+func (h Hooks) NewEventPayload(event HookEvent, exitCode int) *EventPayload {
+	payload := h.payload
+	payload.Event = event
+	payload.ExitCode = exitCode
+	return &payload
+}
 
-	for _, hook := range h.items {
-		switch hook.Command {
-		case "store":
-			storeCmd := &StoreCommand{
-				Name:        "store",
-				atmosConfig: atmosConfig,
-				info:        info,
-			}
-			err := storeCmd.RunE(&hook, event, cmd, args)
-			if err != nil {
-				errUtils.CheckErrorPrintAndExit(err, "", "")
+// RunAll runs the hooks configured for the event of the payload, in the order defined by their `order` and
+// `depends_on`. It returns the error of the first hook that fails with `on_failure: fail`.
+func (h Hooks) RunAll(payload *EventPayload, atmosConfig *schema.AtmosConfiguration, info *schema.ConfigAndStacksInfo, cmd *cobra.Command, args []string) error {
+	var names []string
+	for name, hook := range h.items {
+		if !hook.MatchesEvent(payload.Event) {
+			log.Debug("Skipping hook. Event not configured", "hook", name, "event", payload.Event)
+			continue
+		}
+		if err := hook.validate(name); err != nil {
+			return err
+		}
+		names = append(names, name)
+	}
+
+	ordered, err := orderHooks(h.items, names)
+	if err != nil {
+		return err
+	}
+
+	log.Debug("Running hooks", "event", payload.Event, "hooks", ordered)
+
+	failed := map[string]bool{}
+	for _, name := range ordered {
+		hook := h.items[name]
+
+		if dependency, ok := lo.Find(hook.DependsOn, func(d string) bool { return failed[d] }); ok {
+			log.Warn("Skipping hook. A hook it depends on failed", "hook", name, "dependency", dependency)
+			failed[name] = true
+			continue
+		}
+
+		run, err := h.evaluateCondition(name, &hook, payload, atmosConfig, info)
+		if err != nil {
+			return err
+		}
+		if !run {
+			log.Debug("Skipping hook. The `when` condition is false", "hook", name, "event", payload.Event)
+			continue
+		}
+
+		command, err := h.newCommand(name, &hook, atmosConfig, info)
+		if err != nil {
+			return err
+		}
+
+		if err := runHook(command, name, &hook, payload, cmd, args); err != nil {
+			failed[name] = true
+			switch hook.OnFailure {
+			case OnFailureWarn:
+				log.Warn("Hook failed", "hook", name, "event", payload.Event, "error", err)
+			case OnFailureIgnore:
+				log.Debug("Hook failed, ignoring", "hook", name, "event", payload.Event, "error", err)
+			default:
+				return err
 			}
 		}
 	}
 	return nil
 }
 
+// newCommand returns the command that runs the hook.
+func (h Hooks) newCommand(name string, hook *Hook, atmosConfig *schema.AtmosConfiguration, info *schema.ConfigAndStacksInfo) (Command, error) {
+	switch hook.Command {
+	case "store":
+		return NewStoreCommand(atmosConfig, info, name)
+	case "shell":
+		return NewShellCommand(atmosConfig, info, h.sections, h.env)
+	case "webhook":
+		return NewWebhookCommand(atmosConfig, info, h.sections)
+	default:
+		return nil, fmt.Errorf("%w: `%s` in the hook `%s`", errUtils.ErrUnsupportedHookCommand, hook.Command, name)
+	}
+}
+
 func (h Hooks) ConvertToHooks(input map[string]any) (Hooks, error) {
 	return Hooks{}, nil
 }
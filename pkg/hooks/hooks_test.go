@@ -1,10 +1,16 @@
 package hooks
 
 import (
+	"fmt"
+	"path/filepath"
 	"testing"
 
+	"github.com/alicebob/miniredis/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	errUtils "github.com/cloudposse/atmos/errors"
+	cfg "github.com/cloudposse/atmos/pkg/config"
 	"github.com/cloudposse/atmos/pkg/schema"
 )
 
@@ -50,3 +56,165 @@ func TestGetHooks(t *testing.T) {
 		})
 	}
 }
+
+func TestHookMatchesEvent(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []string
+		event  HookEvent
+		want   bool
+	}{
+		{name: "dashed event", events: []string{"after-terraform-apply"}, event: AfterTerraformApply, want: true},
+		{name: "dotted event", events: []string{"after.terraform.destroy"}, event: AfterTerraformDestroy, want: true},
+		{name: "other event", events: []string{"after-terraform-apply"}, event: AfterTerraformDestroy, want: false},
+		{name: "no events defaults to apply", events: nil, event: AfterTerraformApply, want: true},
+		{name: "no events skips destroy", events: nil, event: AfterTerraformDestroy, want: false},
+		{name: "no events runs after deploy", events: nil, event: AfterTerraformDeploy, want: true},
+		{name: "apply event runs after deploy", events: []string{"after-terraform-apply"}, event: AfterTerraformDeploy, want: true},
+		{name: "deploy event skips apply", events: []string{"after.terraform.deploy"}, event: AfterTerraformApply, want: false},
+		{name: "helmfile event", events: []string{"before-helmfile-sync"}, event: BeforeHelmfileSync, want: true},
+		{name: "no events skips helmfile", events: nil, event: AfterHelmfileApply, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook := Hook{Events: tt.events}
+			assert.Equal(t, tt.want, hook.MatchesEvent(tt.event))
+		})
+	}
+}
+
+func TestGetHookEvents(t *testing.T) {
+	tests := []struct {
+		componentType string
+		subCommand    string
+		before        HookEvent
+		after         HookEvent
+		ok            bool
+	}{
+		{componentType: "terraform", subCommand: "init", before: BeforeTerraformInit, after: AfterTerraformInit, ok: true},
+		{componentType: "terraform", subCommand: "plan", before: BeforeTerraformPlan, after: AfterTerraformPlan, ok: true},
+		{componentType: "terraform", subCommand: "deploy", before: BeforeTerraformDeploy, after: AfterTerraformDeploy, ok: true},
+		{componentType: "terraform", subCommand: "workspace", before: BeforeTerraformWorkspace, after: AfterTerraformWorkspace, ok: true},
+		{componentType: "helmfile", subCommand: "diff", before: BeforeHelmfileDiff, after: AfterHelmfileDiff, ok: true},
+		{componentType: "helmfile", subCommand: "destroy", before: BeforeHelmfileDestroy, after: AfterHelmfileDestroy, ok: true},
+		{componentType: "terraform", subCommand: "output", ok: false},
+		{componentType: "helmfile", subCommand: "plan", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.componentType+" "+tt.subCommand, func(t *testing.T) {
+			before, after, ok := GetHookEvents(tt.componentType, tt.subCommand)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.before, before)
+			assert.Equal(t, tt.after, after)
+		})
+	}
+}
+
+func TestGetHooks_EventPayload(t *testing.T) {
+	s := miniredis.RunT(t)
+	t.Setenv("ATMOS_REDIS_URL", fmt.Sprintf("redis://%s", s.Addr()))
+	t.Chdir("../../tests/fixtures/scenarios/hooks-test")
+
+	info := schema.ConfigAndStacksInfo{
+		ComponentFromArg: "component1",
+		Stack:            "test",
+		ComponentType:    cfg.TerraformComponentType,
+		SubCommand:       "apply",
+	}
+
+	atmosConfig, err := cfg.InitCliConfig(info, true)
+	require.NoError(t, err)
+
+	hooks, err := GetHooks(&atmosConfig, &info)
+	require.NoError(t, err)
+	assert.True(t, hooks.HasHooks())
+	assert.Equal(t, "store", hooks.items["store-outputs"].Command)
+
+	payload := hooks.NewEventPayload(AfterTerraformApply, 1)
+	assert.Equal(t, AfterTerraformApply, payload.Event)
+	assert.Equal(t, "component1", payload.Component)
+	assert.Equal(t, "test", payload.Stack)
+	assert.Equal(t, "test-component1", payload.Workspace)
+	assert.Equal(t, 1, payload.ExitCode)
+	assert.Equal(t, filepath.Join("components", "terraform", "hook-and-store"), payload.ComponentPath)
+	assert.Equal(t, filepath.Join("components", "terraform", "hook-and-store", "test-component1.planfile"), payload.PlanFile)
+
+	assert.Contains(t, payload.Env(), "ATMOS_HOOK_EVENT=after.terraform.apply")
+	assert.Contains(t, payload.Env(), "ATMOS_HOOK_EXIT_CODE=1")
+}
+
+func TestNewHooks(t *testing.T) {
+	atmosConfig := &schema.AtmosConfiguration{}
+	info := &schema.ConfigAndStacksInfo{
+		ComponentFromArg:   "vpc",
+		Stack:              "dev",
+		ComponentType:      cfg.HelmfileComponentType,
+		TerraformWorkspace: "dev",
+		ComponentSection: map[string]any{
+			cfg.HooksSectionName: map[string]any{
+				"notify": map[string]any{
+					"events":  []any{"after-helmfile-sync"},
+					"command": "shell",
+					"run":     "echo synced",
+				},
+			},
+		},
+		ComponentEnvList: []string{"FOO=bar"},
+	}
+
+	hooks, err := NewHooks(atmosConfig, info)
+	require.NoError(t, err)
+	assert.True(t, hooks.HasHooks())
+	assert.Equal(t, "shell", hooks.items["notify"].Command)
+	assert.Equal(t, []string{"FOO=bar"}, hooks.env)
+
+	payload := hooks.NewEventPayload(AfterHelmfileSync, 0)
+	assert.Equal(t, "vpc", payload.Component)
+	assert.Equal(t, "dev", payload.Stack)
+	assert.Empty(t, payload.PlanFile)
+
+	// A component without hooks has no hooks
+	hooks, err = NewHooks(atmosConfig, &schema.ConfigAndStacksInfo{ComponentSection: map[string]any{}})
+	require.NoError(t, err)
+	assert.False(t, hooks.HasHooks())
+}
+
+func TestGetHooks_StackTemplates(t *testing.T) {
+	t.Chdir("../../tests/fixtures/scenarios/hooks-templates")
+
+	info := schema.ConfigAndStacksInfo{
+		ComponentFromArg: "component1",
+		Stack:            "prod",
+		ComponentType:    cfg.TerraformComponentType,
+		SubCommand:       "apply",
+	}
+
+	atmosConfig, err := cfg.InitCliConfig(info, true)
+	require.NoError(t, err)
+	require.True(t, atmosConfig.Templates.Settings.Enabled)
+
+	hooks, err := GetHooks(&atmosConfig, &info)
+	require.NoError(t, err)
+
+	// The templates in the stack manifests are processed, except the templates rendered by the hooks
+	vars, ok := hooks.sections[cfg.VarsSectionName].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "prod-random", vars["random"])
+	assert.Equal(t, `{{ and .plan.has_changes (filepath.Match "*prod" .event.stack) }}`, hooks.items["notify"].When)
+	assert.Equal(t, []string{"{{ .vars.stage }}"}, hooks.items["notify"].Args)
+
+	prodOnly, notify := hooks.items["prod-only"], hooks.items["notify"]
+	ok, err = hooks.evaluateCondition("prod-only", &prodOnly, hooks.NewEventPayload(AfterTerraformApply, 0), &atmosConfig, &info)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = hooks.evaluateCondition("notify", &notify, hooks.NewEventPayload(AfterTerraformApply, 0), &atmosConfig, &info)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// A typo in the condition fails instead of rendering `<no value>`
+	_, err = hooks.evaluateCondition("typo", &Hook{When: "{{ .plan.has_chagnes }}"}, hooks.NewEventPayload(AfterTerraformApply, 0), &atmosConfig, &info)
+	assert.ErrorIs(t, err, errUtils.ErrInvalidHook)
+}
@@ -0,0 +1,83 @@
+package hooks
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	e "github.com/cloudposse/atmos/internal/exec"
+	"github.com/cloudposse/atmos/pkg/schema"
+)
+
+// getPlanSummary is a variable so that it can be replaced in tests.
+var getPlanSummary = e.GetTerraformPlanSummary
+
+const (
+	// StatusStarted is the status of the `before` events.
+	StatusStarted = "started"
+	// StatusSucceeded is the status of the `after` events of the commands that succeeded.
+	StatusSucceeded = "succeeded"
+	// StatusFailed is the status of the `after` events of the commands that failed.
+	StatusFailed = "failed"
+)
+
+// EventPayload describes the event that triggered the hooks. It is passed to the hook commands.
+type EventPayload struct {
+	Event         HookEvent
+	ComponentType string
+	Component     string
+	Stack         string
+	Workspace     string
+	ComponentPath string
+	// PlanFile is the path to the Terraform planfile of the component (Terraform components only).
+	PlanFile string
+	// ExitCode is the exit code of the command. It's always 0 for the `before` events.
+	ExitCode int
+	// Duration is the time it took to execute the command. It's always 0 for the `before` events.
+	Duration time.Duration
+
+	// planSummary caches the summary of the planfile, shared by the hooks of the event
+	planSummary *e.TerraformPlanSummary
+}
+
+// Status returns the status of the command: `started` for the `before` events, and `succeeded` or `failed`
+// depending on the exit code for the `after` events.
+func (p *EventPayload) Status() string {
+	if strings.HasPrefix(string(p.Event), "before.") {
+		return StatusStarted
+	}
+	if p.ExitCode != 0 {
+		return StatusFailed
+	}
+	return StatusSucceeded
+}
+
+// Env returns the payload as `ATMOS_HOOK_*` environment variables, for the processes executed by the hook commands.
+func (p *EventPayload) Env() []string {
+	return []string{
+		fmt.Sprintf("ATMOS_HOOK_EVENT=%s", p.Event),
+		fmt.Sprintf("ATMOS_HOOK_COMPONENT_TYPE=%s", p.ComponentType),
+		fmt.Sprintf("ATMOS_HOOK_COMPONENT=%s", p.Component),
+		fmt.Sprintf("ATMOS_HOOK_STACK=%s", p.Stack),
+		fmt.Sprintf("ATMOS_HOOK_WORKSPACE=%s", p.Workspace),
+		fmt.Sprintf("ATMOS_HOOK_COMPONENT_PATH=%s", p.ComponentPath),
+		fmt.Sprintf("ATMOS_HOOK_PLAN_FILE=%s", p.PlanFile),
+		fmt.Sprintf("ATMOS_HOOK_EXIT_CODE=%d", p.ExitCode),
+	}
+}
+
+// getPlanSummary returns the number of resources that the planfile adds, changes and destroys. The summary is only
+// available after a successful `terraform plan`, and is `nil` for the other events.
+func (p *EventPayload) getPlanSummary(atmosConfig *schema.AtmosConfiguration, info *schema.ConfigAndStacksInfo) (*e.TerraformPlanSummary, error) {
+	if p.Event != AfterTerraformPlan || p.ExitCode != 0 {
+		return nil, nil
+	}
+	if p.planSummary == nil {
+		summary, err := getPlanSummary(atmosConfig, info, p.ComponentPath, p.PlanFile)
+		if err != nil {
+			return nil, err
+		}
+		p.planSummary = summary
+	}
+	return p.planSummary, nil
+}
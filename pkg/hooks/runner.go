@@ -0,0 +1,231 @@
+package hooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+
+	log "github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+
+	errUtils "github.com/cloudposse/atmos/errors"
+	e "github.com/cloudposse/atmos/internal/exec"
+	"github.com/cloudposse/atmos/pkg/schema"
+)
+
+const (
+	// OnFailureFail fails the command if the hook fails. It's the default.
+	OnFailureFail = "fail"
+	// OnFailureWarn logs a warning if the hook fails, and continues.
+	OnFailureWarn = "warn"
+	// OnFailureIgnore continues silently if the hook fails.
+	OnFailureIgnore = "ignore"
+)
+
+// hookRetryBackoff is the delay before the first retry of a hook. It's doubled before each subsequent retry.
+var hookRetryBackoff = time.Second
+
+// validate checks the properties of the hook that are common to all the commands.
+func (h *Hook) validate(name string) error {
+	switch h.OnFailure {
+	case "", OnFailureFail, OnFailureWarn, OnFailureIgnore:
+	default:
+		return fmt.Errorf("%w: `%s`: `on_failure` must be one of `fail`, `warn` or `ignore`, got `%s`", errUtils.ErrInvalidHook, name, h.OnFailure)
+	}
+
+	if h.Timeout != "" {
+		if timeout, err := time.ParseDuration(h.Timeout); err != nil || timeout <= 0 {
+			return fmt.Errorf("%w: `%s`: invalid `timeout` `%s`", errUtils.ErrInvalidHook, name, h.Timeout)
+		}
+	}
+
+	if h.Retries < 0 {
+		return fmt.Errorf("%w: `%s`: `retries` must not be negative", errUtils.ErrInvalidHook, name)
+	}
+	return nil
+}
+
+// orderHooks returns the names of the hooks in the order in which they must run. A hook runs after the hooks in its
+// `depends_on`, and the hooks that don't depend on each other run by `order`, then by name.
+// Dependencies on hooks that are not in the list (e.g. hooks configured for other events) are ignored.
+func orderHooks(items map[string]Hook, names []string) ([]string, error) {
+	selected := make(map[string]bool, len(names))
+	for _, name := range names {
+		selected[name] = true
+	}
+
+	// The number of dependencies of each hook that have not run yet, and the hooks that depend on each hook
+	pending := make(map[string]int, len(names))
+	dependents := make(map[string][]string, len(names))
+	for _, name := range names {
+		for _, dependency := range items[name].DependsOn {
+			if _, ok := items[dependency]; !ok {
+				return nil, fmt.Errorf("%w: `%s` depends on the hook `%s`, which is not defined", errUtils.ErrInvalidHook, name, dependency)
+			}
+			if selected[dependency] {
+				pending[name]++
+				dependents[dependency] = append(dependents[dependency], name)
+			}
+		}
+	}
+
+	less := func(ready []string) func(i, j int) bool {
+		return func(i, j int) bool {
+			if items[ready[i]].Order != items[ready[j]].Order {
+				return items[ready[i]].Order < items[ready[j]].Order
+			}
+			return ready[i] < ready[j]
+		}
+	}
+
+	var ready []string
+	for _, name := range names {
+		if pending[name] == 0 {
+			ready = append(ready, name)
+		}
+	}
+
+	ordered := make([]string, 0, len(names))
+	for len(ready) > 0 {
+		sort.Slice(ready, less(ready))
+		name := ready[0]
+		ready = ready[1:]
+		ordered = append(ordered, name)
+
+		for _, dependent := range dependents[name] {
+			pending[dependent]--
+			if pending[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	if len(ordered) < len(names) {
+		var cycle []string
+		for _, name := range names {
+			if pending[name] > 0 {
+				cycle = append(cycle, name)
+			}
+		}
+		sort.Strings(cycle)
+		return nil, fmt.Errorf("%w: circular `depends_on` between the hooks `%s`", errUtils.ErrInvalidHook, strings.Join(cycle, "`, `"))
+	}
+	return ordered, nil
+}
+
+// evaluateCondition renders the `when` template of the hook, and returns true if the hook must run.
+// The template data are the component's sections, with the event in `.event` and the plan summary in `.plan`.
+func (h Hooks) evaluateCondition(name string, hook *Hook, payload *EventPayload, atmosConfig *schema.AtmosConfiguration, info *schema.ConfigAndStacksInfo) (bool, error) {
+	if strings.TrimSpace(hook.When) == "" {
+		return true, nil
+	}
+
+	data := make(map[string]any, len(h.sections)+2)
+	for k, v := range h.sections {
+		data[k] = v
+	}
+	data["event"] = map[string]any{
+		"name":           string(payload.Event),
+		"status":         payload.Status(),
+		"exit_code":      payload.ExitCode,
+		"component_type": payload.ComponentType,
+		"component":      payload.Component,
+		"stack":          payload.Stack,
+		"workspace":      payload.Workspace,
+	}
+
+	plan := map[string]any{"add": 0, "change": 0, "destroy": 0, "has_changes": false}
+	// Summarizing the plan runs `terraform show`, so it's only done if the condition uses it
+	if usesPlanSummary(hook.When) {
+		summary, err := payload.getPlanSummary(atmosConfig, info)
+		if err != nil {
+			return false, fmt.Errorf("%w: `%s`: failed to summarize the Terraform plan: %w", errUtils.ErrHookCommandFailed, name, err)
+		}
+		if summary != nil {
+			plan = map[string]any{"add": summary.Add, "change": summary.Change, "destroy": summary.Destroy, "has_changes": summary.HasChanges()}
+		}
+	}
+	data["plan"] = plan
+
+	rendered, err := render(atmosConfig, info, hook, data, "when", hook.When)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := strconv.ParseBool(strings.TrimSpace(rendered))
+	if err != nil {
+		return false, fmt.Errorf("%w: `%s`: `when` must render to `true` or `false`, got `%s`", errUtils.ErrInvalidHook, name, strings.TrimSpace(rendered))
+	}
+	return result, nil
+}
+
+// usesPlanSummary returns true if the `when` template references the plan summary (e.g. `.plan.has_changes`
+// or `index . "plan"`). The functions are not checked, since the template is rendered with the Atmos functions later.
+func usesPlanSummary(when string) bool {
+	trees := map[string]*parse.Tree{}
+	tree := parse.New("when")
+	tree.Mode = parse.SkipFuncCheck
+	if _, err := tree.Parse(when, "", "", trees); err != nil {
+		// The template fails to render anyway
+		return false
+	}
+
+	t := template.New("when")
+	for name, tree := range trees {
+		if _, err := t.AddParseTree(name, tree); err != nil {
+			return false
+		}
+	}
+	for _, field := range e.GetTemplateFields(t) {
+		if len(field) > 0 && field[0] == "plan" {
+			return true
+		}
+	}
+	return false
+}
+
+// runHook runs the command of the hook, retrying it up to `retries` times with an exponential backoff.
+// Each attempt is canceled after the hook's `timeout`.
+func runHook(command Command, name string, hook *Hook, payload *EventPayload, cmd *cobra.Command, args []string) error {
+	var timeout time.Duration
+	if hook.Timeout != "" {
+		timeout, _ = time.ParseDuration(hook.Timeout)
+	}
+
+	backoff := hookRetryBackoff
+	for attempt := 0; ; attempt++ {
+		err := runHookAttempt(command, name, hook, payload, cmd, args, timeout)
+		if err == nil {
+			return nil
+		}
+		// Invalid hook configs fail the same way on every attempt
+		if errors.Is(err, errUtils.ErrInvalidHook) || attempt >= hook.Retries {
+			return err
+		}
+
+		log.Warn("Hook failed, retrying", "hook", name, "attempt", attempt+1, "retries", hook.Retries, "backoff", backoff, "error", err)
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+func runHookAttempt(command Command, name string, hook *Hook, payload *EventPayload, cmd *cobra.Command, args []string, timeout time.Duration) error {
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	err := command.RunE(ctx, hook, payload, cmd, args)
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w: `%s` after %s: %w", errUtils.ErrHookTimeout, name, timeout, err)
+	}
+	return err
+}
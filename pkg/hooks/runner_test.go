@@ -0,0 +1,205 @@
+package hooks
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errUtils "github.com/cloudposse/atmos/errors"
+	e "github.com/cloudposse/atmos/internal/exec"
+	"github.com/cloudposse/atmos/pkg/schema"
+)
+
+func TestOrderHooks(t *testing.T) {
+	items := map[string]Hook{
+		"notify":  {DependsOn: []string{"test", "publish"}},
+		"test":    {Order: 10},
+		"publish": {Order: 5},
+		"audit":   {Order: 20},
+		"cleanup": {DependsOn: []string{"other-event"}},
+		"lint":    {},
+	}
+
+	ordered, err := orderHooks(items, []string{"notify", "test", "publish", "audit", "lint"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"lint", "publish", "test", "notify", "audit"}, ordered)
+
+	// Dependencies on hooks that don't run for the event are ignored
+	items["other-event"] = Hook{}
+	ordered, err = orderHooks(items, []string{"cleanup"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cleanup"}, ordered)
+
+	items["lint"] = Hook{DependsOn: []string{"undefined"}}
+	_, err = orderHooks(items, []string{"lint"})
+	assert.ErrorIs(t, err, errUtils.ErrInvalidHook)
+
+	items["lint"] = Hook{DependsOn: []string{"audit"}}
+	items["audit"] = Hook{DependsOn: []string{"lint"}}
+	_, err = orderHooks(items, []string{"lint", "audit", "test"})
+	assert.ErrorIs(t, err, errUtils.ErrInvalidHook)
+	assert.Contains(t, err.Error(), "`audit`, `lint`")
+}
+
+// newShellHook returns a hook that runs a shell script in the component directory.
+func newShellHook(script string) Hook {
+	return Hook{Events: []string{"after-terraform-apply"}, Command: "shell", Run: "sh", Args: []string{"-c", script}}
+}
+
+func newTestHooks(t *testing.T, items map[string]Hook) (Hooks, *EventPayload, string) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping the shell hook test on Windows")
+	}
+
+	dir := t.TempDir()
+	hooks := Hooks{
+		config:   &schema.AtmosConfiguration{},
+		info:     &schema.ConfigAndStacksInfo{},
+		items:    items,
+		sections: map[string]any{"vars": map[string]any{"stage": "prod"}},
+	}
+	payload := &EventPayload{Event: AfterTerraformApply, Stack: "plat-ue2-prod", ComponentPath: dir}
+	return hooks, payload, dir
+}
+
+func readHookLog(t *testing.T, dir string) string {
+	out, err := os.ReadFile(filepath.Join(dir, "hooks.log"))
+	if os.IsNotExist(err) {
+		return ""
+	}
+	require.NoError(t, err)
+	return string(out)
+}
+
+func TestRunAll_OnFailure(t *testing.T) {
+	items := map[string]Hook{
+		"a-warn":   newShellHook("echo a >> hooks.log; exit 1"),
+		"b-ignore": newShellHook("echo b >> hooks.log; exit 1"),
+		"c-after":  newShellHook("echo c >> hooks.log"),
+		"d-skip":   newShellHook("echo d >> hooks.log"),
+	}
+	items["a-warn"] = withHook(items["a-warn"], func(h *Hook) { h.OnFailure = OnFailureWarn })
+	items["b-ignore"] = withHook(items["b-ignore"], func(h *Hook) { h.OnFailure = OnFailureIgnore })
+	items["d-skip"] = withHook(items["d-skip"], func(h *Hook) { h.DependsOn = []string{"b-ignore"} })
+
+	hooks, payload, dir := newTestHooks(t, items)
+	require.NoError(t, hooks.RunAll(payload, hooks.config, hooks.info, nil, nil))
+	assert.Equal(t, "a\nb\nc\n", readHookLog(t, dir))
+
+	// The first hook that fails with `on_failure: fail` stops the hooks and returns the error
+	hooks.items["b-ignore"] = withHook(items["b-ignore"], func(h *Hook) { h.OnFailure = "" })
+	require.NoError(t, os.Remove(filepath.Join(dir, "hooks.log")))
+	err := hooks.RunAll(payload, hooks.config, hooks.info, nil, nil)
+	assert.ErrorIs(t, err, errUtils.ErrHookCommandFailed)
+	assert.Equal(t, "a\nb\n", readHookLog(t, dir))
+
+	hooks.items["b-ignore"] = withHook(items["b-ignore"], func(h *Hook) { h.OnFailure = "sometimes" })
+	err = hooks.RunAll(payload, hooks.config, hooks.info, nil, nil)
+	assert.ErrorIs(t, err, errUtils.ErrInvalidHook)
+
+	hooks.items["b-ignore"] = Hook{Command: "email"}
+	err = hooks.RunAll(payload, hooks.config, hooks.info, nil, nil)
+	assert.ErrorIs(t, err, errUtils.ErrUnsupportedHookCommand)
+}
+
+func withHook(hook Hook, update func(h *Hook)) Hook {
+	update(&hook)
+	return hook
+}
+
+func TestRunAll_When(t *testing.T) {
+	origGetPlanSummary := getPlanSummary
+	t.Cleanup(func() { getPlanSummary = origGetPlanSummary })
+	planSummaryCalls := 0
+	getPlanSummary = func(*schema.AtmosConfiguration, *schema.ConfigAndStacksInfo, string, string) (*e.TerraformPlanSummary, error) {
+		planSummaryCalls++
+		return &e.TerraformPlanSummary{Change: 1}, nil
+	}
+
+	items := map[string]Hook{
+		"prod-stacks":  withHook(newShellHook("echo prod >> hooks.log"), func(h *Hook) { h.When = `{{ filepath.Match "*-prod" .event.stack }}` }),
+		"dev-stacks":   withHook(newShellHook("echo dev >> hooks.log"), func(h *Hook) { h.When = `{{ filepath.Match "*-dev" .event.stack }}` }),
+		"has-changes":  withHook(newShellHook("echo changes >> hooks.log"), func(h *Hook) { h.When = "{{ .plan.has_changes }}" }),
+		"stage":        withHook(newShellHook("echo stage >> hooks.log"), func(h *Hook) { h.When = `{{ eq .vars.stage "prod" }}` }),
+		"successful":   withHook(newShellHook("echo succeeded >> hooks.log"), func(h *Hook) { h.When = `{{ eq .event.status "succeeded" }}` }),
+		"without-when": newShellHook("echo always >> hooks.log"),
+	}
+	for name, hook := range items {
+		hook.Events = []string{"after-terraform-plan"}
+		items[name] = hook
+	}
+
+	hooks, payload, dir := newTestHooks(t, items)
+	payload.Event = AfterTerraformPlan
+	require.NoError(t, hooks.RunAll(payload, hooks.config, hooks.info, nil, nil))
+	assert.Equal(t, "changes\nprod\nstage\nsucceeded\nalways\n", readHookLog(t, dir))
+	assert.Equal(t, 1, planSummaryCalls)
+
+	// The plan summary is only available after a successful plan
+	require.NoError(t, os.Remove(filepath.Join(dir, "hooks.log")))
+	payload = &EventPayload{Event: AfterTerraformPlan, Stack: "plat-ue2-dev", ComponentPath: dir, ExitCode: 1}
+	require.NoError(t, hooks.RunAll(payload, hooks.config, hooks.info, nil, nil))
+	assert.Equal(t, "dev\nstage\nalways\n", readHookLog(t, dir))
+	assert.Equal(t, 1, planSummaryCalls)
+
+	hooks.items["stage"] = withHook(items["stage"], func(h *Hook) { h.When = "{{ .vars.stage }}" })
+	err := hooks.RunAll(payload, hooks.config, hooks.info, nil, nil)
+	assert.ErrorIs(t, err, errUtils.ErrInvalidHook)
+}
+
+func TestUsesPlanSummary(t *testing.T) {
+	tests := []struct {
+		when     string
+		expected bool
+	}{
+		{when: "{{ .plan.has_changes }}", expected: true},
+		{when: `{{ gt (index . "plan" "add") 0 }}`, expected: true},
+		{when: `{{ with .plan }}{{ .has_changes }}{{ end }}`, expected: true},
+		{when: `{{ and (eq .event.status "succeeded") $.plan.has_changes }}`, expected: true},
+		{when: "{{ .vars.planned }}", expected: false},
+		{when: "{{ .planned }}", expected: false},
+		{when: `{{ eq .event.name "after.terraform.plan" }}`, expected: false},
+		{when: `{{ filepath.Match "*-prod" .event.stack }}`, expected: false},
+		{when: "{{ .plan", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.when, func(t *testing.T) {
+			assert.Equal(t, tt.expected, usesPlanSummary(tt.when))
+		})
+	}
+}
+
+func TestRunAll_RetriesAndTimeout(t *testing.T) {
+	origBackoff := hookRetryBackoff
+	t.Cleanup(func() { hookRetryBackoff = origBackoff })
+	hookRetryBackoff = time.Millisecond
+
+	// The script fails on the first two attempts
+	flaky := newShellHook(`echo attempt >> hooks.log; [ "$(wc -l < hooks.log)" -ge 3 ]`)
+
+	hooks, payload, dir := newTestHooks(t, map[string]Hook{"flaky": withHook(flaky, func(h *Hook) { h.Retries = 2 })})
+	require.NoError(t, hooks.RunAll(payload, hooks.config, hooks.info, nil, nil))
+	assert.Equal(t, "attempt\nattempt\nattempt\n", readHookLog(t, dir))
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "hooks.log")))
+	hooks.items["flaky"] = withHook(flaky, func(h *Hook) { h.Retries = 1 })
+	err := hooks.RunAll(payload, hooks.config, hooks.info, nil, nil)
+	assert.ErrorIs(t, err, errUtils.ErrHookCommandFailed)
+	assert.Equal(t, "attempt\nattempt\n", readHookLog(t, dir))
+
+	hooks.items = map[string]Hook{"slow": withHook(newShellHook("exec sleep 5"), func(h *Hook) { h.Timeout = "100ms" })}
+	start := time.Now()
+	err = hooks.RunAll(payload, hooks.config, hooks.info, nil, nil)
+	assert.ErrorIs(t, err, errUtils.ErrHookTimeout)
+	assert.Less(t, time.Since(start), 4*time.Second)
+
+	hooks.items = map[string]Hook{"slow": withHook(newShellHook("true"), func(h *Hook) { h.Timeout = "soon" })}
+	err = hooks.RunAll(payload, hooks.config, hooks.info, nil, nil)
+	assert.ErrorIs(t, err, errUtils.ErrInvalidHook)
+}
@@ -0,0 +1,72 @@
+package hooks
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+
+	errUtils "github.com/cloudposse/atmos/errors"
+	e "github.com/cloudposse/atmos/internal/exec"
+	"github.com/cloudposse/atmos/pkg/schema"
+)
+
+// assert that ShellCommand implements Command interface
+var _ Command = &ShellCommand{}
+
+// ShellCommand runs an executable in the working directory of the component.
+type ShellCommand struct {
+	Name        string
+	atmosConfig *schema.AtmosConfiguration
+	info        *schema.ConfigAndStacksInfo
+	// sections are the component's sections, used as the data to render the templates in `run` and `args`
+	sections map[string]any
+	// env are the ENV vars from the component's `env` section
+	env []string
+}
+
+func NewShellCommand(atmosConfig *schema.AtmosConfiguration, info *schema.ConfigAndStacksInfo, sections map[string]any, env []string) (*ShellCommand, error) {
+	return &ShellCommand{
+		Name:        "shell",
+		atmosConfig: atmosConfig,
+		info:        info,
+		sections:    sections,
+		env:         env,
+	}, nil
+}
+
+func (c *ShellCommand) GetName() string {
+	return c.Name
+}
+
+// RunE is the entrypoint for the shell command
+func (c *ShellCommand) RunE(ctx context.Context, hook *Hook, payload *EventPayload, cmd *cobra.Command, args []string) error {
+	if hook.Run == "" {
+		return fmt.Errorf("%w: `run` is required for the `shell` command", errUtils.ErrInvalidHook)
+	}
+
+	command, err := render(c.atmosConfig, c.info, hook, c.sections, "run", hook.Run)
+	if err != nil {
+		return err
+	}
+
+	commandArgs := make([]string, 0, len(hook.Args))
+	for i, arg := range hook.Args {
+		renderedArg, err := render(c.atmosConfig, c.info, hook, c.sections, fmt.Sprintf("args[%d]", i), arg)
+		if err != nil {
+			return err
+		}
+		commandArgs = append(commandArgs, renderedArg)
+	}
+
+	// The ENV vars describing the event override the ENV vars from the component's `env` section
+	env := append(append([]string{}, c.env...), payload.Env()...)
+
+	log.Debug("Executing hook", "event", payload.Event, "command", command, "args", commandArgs, "dir", payload.ComponentPath)
+
+	if err := e.ExecuteShellCommandWithContext(ctx, *c.atmosConfig, command, commandArgs, payload.ComponentPath, env, false, "", nil); err != nil {
+		return fmt.Errorf("%w: `%s`: %w", errUtils.ErrHookCommandFailed, command, err)
+	}
+	return nil
+}
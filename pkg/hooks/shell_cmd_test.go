@@ -0,0 +1,62 @@
+package hooks
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errUtils "github.com/cloudposse/atmos/errors"
+	"github.com/cloudposse/atmos/pkg/schema"
+)
+
+func TestShellCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping the shell hook test on Windows")
+	}
+
+	dir := t.TempDir()
+	script := filepath.Join(dir, "hook.sh")
+	require.NoError(t, os.WriteFile(script, []byte(`#!/bin/sh
+echo "$@ $REGION $ATMOS_HOOK_EVENT $ATMOS_HOOK_STACK $ATMOS_HOOK_EXIT_CODE $(pwd)" > hook.out
+`), 0o755))
+
+	shellCmd, err := NewShellCommand(
+		&schema.AtmosConfiguration{},
+		&schema.ConfigAndStacksInfo{},
+		map[string]any{"vars": map[string]any{"name": "vpc"}},
+		[]string{"REGION=us-east-2"},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "shell", shellCmd.GetName())
+
+	hook := &Hook{Command: "shell", Run: script, Args: []string{"--name", "{{ .vars.name }}"}}
+	payload := &EventPayload{Event: AfterTerraformApply, Stack: "plat-ue2-dev", ComponentPath: dir}
+
+	require.NoError(t, shellCmd.RunE(context.Background(), hook, payload, nil, nil))
+
+	out, err := os.ReadFile(filepath.Join(dir, "hook.out"))
+	require.NoError(t, err)
+	realDir, err := filepath.EvalSymlinks(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "--name vpc us-east-2 after.terraform.apply plat-ue2-dev 0 "+realDir+"\n", string(out))
+}
+
+func TestShellCommand_Errors(t *testing.T) {
+	shellCmd, err := NewShellCommand(&schema.AtmosConfiguration{}, &schema.ConfigAndStacksInfo{}, map[string]any{}, nil)
+	require.NoError(t, err)
+	payload := &EventPayload{Event: AfterTerraformApply, ComponentPath: t.TempDir()}
+
+	err = shellCmd.RunE(context.Background(), &Hook{Command: "shell"}, payload, nil, nil)
+	assert.ErrorIs(t, err, errUtils.ErrInvalidHook)
+
+	err = shellCmd.RunE(context.Background(), &Hook{Command: "shell", Run: "echo", Args: []string{"{{ .vars.name"}}, payload, nil, nil)
+	assert.ErrorIs(t, err, errUtils.ErrInvalidHook)
+
+	err = shellCmd.RunE(context.Background(), &Hook{Command: "shell", Run: "false"}, payload, nil, nil)
+	assert.ErrorIs(t, err, errUtils.ErrHookCommandFailed)
+}
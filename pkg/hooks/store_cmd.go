@@ -1,15 +1,43 @@
 package hooks
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"path"
+	"sort"
 	"strings"
 
 	"github.com/charmbracelet/log"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+
+	errUtils "github.com/cloudposse/atmos/errors"
 	e "github.com/cloudposse/atmos/internal/exec"
 	"github.com/cloudposse/atmos/pkg/schema"
-	"github.com/spf13/cobra"
+	"github.com/cloudposse/atmos/pkg/store"
+	u "github.com/cloudposse/atmos/pkg/utils"
+)
+
+const (
+	// SensitiveInclude publishes the values of the sensitive outputs.
+	SensitiveInclude = "include"
+	// SensitiveSkip doesn't publish the sensitive outputs.
+	SensitiveSkip = "skip"
+	// SensitiveMask publishes the sensitive outputs with a masked value.
+	SensitiveMask = "mask"
+
+	// maskedSensitiveValue is the value published for the sensitive outputs with `sensitive: mask`.
+	maskedSensitiveValue = "<sensitive>"
+
+	// publishedKeysKeyFormat is the key in the store that records the keys published by a `store` hook for a component,
+	// so that only these keys are deleted after `terraform destroy`. It's namespaced by the name of the hook.
+	publishedKeysKeyFormat = "atmos_hook_%s_published_keys"
 )
 
+// getTerraformOutputs is a variable so that it can be replaced in tests.
+var getTerraformOutputs = e.GetAllTerraformOutputs
+
 // assert that Command implements Command interface
 var _ Command = &StoreCommand{}
 
@@ -17,13 +45,16 @@ type StoreCommand struct {
 	Name        string
 	atmosConfig *schema.AtmosConfiguration
 	info        *schema.ConfigAndStacksInfo
+	// hookName is the name of the hook in the `hooks` section, which namespaces the keys recorded by the hook
+	hookName string
 }
 
-func NewStoreCommand(atmosConfig *schema.AtmosConfiguration, info *schema.ConfigAndStacksInfo) (*StoreCommand, error) {
+func NewStoreCommand(atmosConfig *schema.AtmosConfiguration, info *schema.ConfigAndStacksInfo, hookName string) (*StoreCommand, error) {
 	return &StoreCommand{
 		Name:        "store",
 		atmosConfig: atmosConfig,
 		info:        info,
+		hookName:    hookName,
 	}, nil
 }
 
@@ -31,52 +62,262 @@ func (c *StoreCommand) GetName() string {
 	return c.Name
 }
 
-func (c *StoreCommand) processStoreCommand(hook *Hook) error {
-	if len(hook.Outputs) == 0 {
+// getStore returns the store configured in the hook
+func (c *StoreCommand) getStore(hook *Hook) (store.Store, error) {
+	log.Debug("checking if the store exists", "store", hook.Name)
+	s := c.atmosConfig.Stores[hook.Name]
+
+	if s == nil {
+		return nil, fmt.Errorf("store %q not found in configuration", hook.Name)
+	}
+	return s, nil
+}
+
+func (c *StoreCommand) processStoreCommand(hook *Hook, payload *EventPayload) error {
+	if len(hook.Outputs) == 0 && len(hook.Include) == 0 {
 		log.Info("Skipping hook. No outputs configured", "hook", hook.Name, "outputs", hook.Outputs)
 		return nil
 	}
 
-	log.Debug("Executing 'after-terraform-apply' hook", "hook", hook.Name, "command", hook.Command)
-	for key, value := range hook.Outputs {
-		outputKey, outputValue := c.getOutputValue(value)
+	s, err := c.getStore(hook)
+	if err != nil {
+		return err
+	}
 
-		err := c.storeOutput(hook, key, outputKey, outputValue)
-		if err != nil {
+	values, err := c.getOutputValues(hook)
+	if err != nil {
+		return err
+	}
+
+	log.Debug("Executing hook", "hook", hook.Name, "event", payload.Event, "command", hook.Command)
+
+	keys := lo.Keys(values)
+	sort.Strings(keys)
+
+	// The keys are recorded before they are published, so that the keys of a partially published component are deleted too
+	if err := c.recordPublishedKeys(s, keys); err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if err := c.storeOutput(s, hook, key, values[key]); err != nil {
 			return err
 		}
 	}
+
 	return nil
 }
 
-// getOutputValue gets an output from terraform
-func (c *StoreCommand) getOutputValue(value string) (string, any) {
-	outputKey := strings.TrimPrefix(value, ".")
-	var outputValue any
+// getOutputValues returns the values to publish, keyed by the keys in the store. The outputs of the component are
+// read with a single `terraform output`, and only if the hook references them.
+func (c *StoreCommand) getOutputValues(hook *Hook) (map[string]any, error) {
+	switch hook.Sensitive {
+	case "", SensitiveInclude, SensitiveSkip, SensitiveMask:
+	default:
+		return nil, fmt.Errorf("%w: `sensitive` must be one of `include`, `skip` or `mask`, got `%s`", errUtils.ErrInvalidHook, hook.Sensitive)
+	}
+
+	usesOutputs := len(hook.Include) > 0
+	for _, value := range hook.Outputs {
+		usesOutputs = usesOutputs || strings.HasPrefix(value, ".")
+	}
+
+	var outputs map[string]any
+	var sensitive map[string]bool
+	if usesOutputs {
+		var err error
+		outputs, sensitive, err = getTerraformOutputs(c.atmosConfig, c.info.ComponentFromArg, c.info.Stack)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	values := map[string]any{}
+
+	// The outputs matching the `include` patterns are published under their own names.
+	// Their sensitive outputs are skipped, unless configured otherwise.
+	included := filterSensitiveOutputs(outputs, sensitive, lo.CoalesceOrEmpty(hook.Sensitive, SensitiveSkip))
+	for name, value := range included {
+		matched, err := matchesAny(hook.Include, name)
+		if err != nil {
+			return nil, err
+		}
+		excluded, err := matchesAny(hook.Exclude, name)
+		if err != nil {
+			return nil, err
+		}
+		if matched && !excluded {
+			values[name] = value
+		}
+	}
+
+	// The outputs explicitly configured in `outputs` are published, including the sensitive outputs unless configured otherwise.
+	// The values starting with `.` are yq expressions evaluated against the outputs, the other values are published as is.
+	selected := filterSensitiveOutputs(outputs, sensitive, lo.CoalesceOrEmpty(hook.Sensitive, SensitiveInclude))
+	for key, value := range hook.Outputs {
+		outputValue, err := c.getOutputValue(selected, value)
+		if err != nil {
+			return nil, err
+		}
+		if outputValue == nil {
+			log.Warn("Skipping output. It has no value", "hook", hook.Name, "key", key, "output", value)
+			delete(values, key)
+			continue
+		}
+		values[key] = outputValue
+	}
+
+	return values, nil
+}
+
+// filterSensitiveOutputs applies the `sensitive` policy to the outputs.
+func filterSensitiveOutputs(outputs map[string]any, sensitive map[string]bool, policy string) map[string]any {
+	filtered := make(map[string]any, len(outputs))
+	for name, value := range outputs {
+		switch {
+		case !sensitive[name] || policy == SensitiveInclude:
+			filtered[name] = value
+		case policy == SensitiveMask:
+			filtered[name] = maskedSensitiveValue
+		}
+	}
+	return filtered
+}
+
+// matchesAny returns true if the name matches any of the glob patterns
+func matchesAny(patterns []string, name string) (bool, error) {
+	for _, pattern := range patterns {
+		matched, err := path.Match(pattern, name)
+		if err != nil {
+			return false, fmt.Errorf("%w: invalid pattern `%s`: %v", errUtils.ErrInvalidHook, pattern, err)
+		}
+		if matched {
+			return true, nil
+		}
+	}
+	return false, nil
+}
 
-	if strings.Index(value, ".") == 0 {
-		outputValue = e.GetTerraformOutput(c.atmosConfig, c.info.Stack, c.info.ComponentFromArg, outputKey, true)
-	} else {
-		outputValue = value
+// getOutputValue gets the value of an output from the terraform outputs using a yq expression (e.g. `.vpc_id` or
+// `.subnets[0].id`), or returns the value as is if it's not an expression
+func (c *StoreCommand) getOutputValue(outputs map[string]any, value string) (any, error) {
+	if !strings.HasPrefix(value, ".") {
+		return value, nil
 	}
-	return outputKey, outputValue
+
+	outputValue, err := u.EvaluateYqExpression(c.atmosConfig, outputs, value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to evaluate `%s` against the outputs: %v", errUtils.ErrInvalidHook, value, err)
+	}
+	return outputValue, nil
 }
 
 // storeOutput puts the value of the output in the store
-func (c *StoreCommand) storeOutput(hook *Hook, key string, outputKey string, outputValue any) error {
-	log.Debug("checking if the store exists", "store", hook.Name)
-	store := c.atmosConfig.Stores[hook.Name]
+func (c *StoreCommand) storeOutput(s store.Store, hook *Hook, key string, outputValue any) error {
+	log.Debug("storing terraform output", "store", hook.Name, "key", key)
 
-	if store == nil {
-		return fmt.Errorf("store %q not found in configuration", hook.Name)
+	return s.Set(c.info.Stack, c.info.ComponentFromArg, key, outputValue)
+}
+
+// processStoreDeleteCommand removes the keys published by the hook from the store
+func (c *StoreCommand) processStoreDeleteCommand(hook *Hook, payload *EventPayload) error {
+	if len(hook.Outputs) == 0 && len(hook.Include) == 0 {
+		log.Info("Skipping hook. No outputs configured", "hook", hook.Name, "outputs", hook.Outputs)
+		return nil
+	}
+
+	s, err := c.getStore(hook)
+	if err != nil {
+		return err
+	}
+
+	published, err := c.getPublishedKeys(s)
+	if err != nil {
+		return err
+	}
+
+	// The keys in `outputs` are published by the hook, even if they were published before the keys were recorded
+	keys := lo.Uniq(append(lo.Keys(hook.Outputs), published...))
+	sort.Strings(keys)
+
+	log.Debug("Executing hook", "hook", hook.Name, "event", payload.Event, "command", hook.Command)
+	for _, key := range keys {
+		if err := c.deleteOutput(s, hook, key); err != nil {
+			return err
+		}
 	}
 
-	log.Debug("storing terraform output", "outputKey", outputKey, "store", hook.Name, "key", key, "value", outputValue)
+	return c.deleteOutput(s, hook, c.publishedKeysKey())
+}
 
-	return store.Set(c.info.Stack, c.info.ComponentFromArg, key, outputValue)
+// publishedKeysKey returns the key in the store that records the keys published by the hook for the component
+func (c *StoreCommand) publishedKeysKey() string {
+	return fmt.Sprintf(publishedKeysKeyFormat, c.hookName)
+}
+
+// getPublishedKeys returns the keys recorded in the store as published by the hook for the component
+func (c *StoreCommand) getPublishedKeys(s store.Store) ([]string, error) {
+	value, err := s.Get(c.info.Stack, c.info.ComponentFromArg, c.publishedKeysKey())
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	list, ok := value.([]any)
+	if !ok {
+		return nil, nil
+	}
+	keys := make([]string, 0, len(list))
+	for _, key := range list {
+		if k, ok := key.(string); ok {
+			keys = append(keys, k)
+		}
+	}
+	return keys, nil
+}
+
+// recordPublishedKeys adds the keys to the keys recorded in the store as published by the hook for the component.
+// The keys published by earlier runs are kept, so that the keys of the outputs that were removed are deleted too
+func (c *StoreCommand) recordPublishedKeys(s store.Store, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	published, err := c.getPublishedKeys(s)
+	if err != nil {
+		return err
+	}
+
+	all := lo.Uniq(append(published, keys...))
+	sort.Strings(all)
+
+	return s.Set(c.info.Stack, c.info.ComponentFromArg, c.publishedKeysKey(), all)
+}
+
+// deleteOutput removes a key from the store. Keys that do not exist are skipped
+func (c *StoreCommand) deleteOutput(s store.Store, hook *Hook, key string) error {
+	log.Debug("deleting key from store", "store", hook.Name, "key", key)
+
+	err := s.Delete(c.info.Stack, c.info.ComponentFromArg, key)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		log.Debug("key not found in store, skipping", "store", hook.Name, "key", key)
+		return nil
+	}
+	return err
 }
 
 // RunE is the entrypoint for the store command
-func (c *StoreCommand) RunE(hook *Hook, event HookEvent, cmd *cobra.Command, args []string) error {
-	return c.processStoreCommand(hook)
+func (c *StoreCommand) RunE(_ context.Context, hook *Hook, payload *EventPayload, cmd *cobra.Command, args []string) error {
+	// The outputs of a failed command are not reliable
+	if payload.ExitCode != 0 {
+		log.Info("Skipping hook. The command failed", "hook", hook.Name, "event", payload.Event, "exitCode", payload.ExitCode)
+		return nil
+	}
+
+	if payload.Event == AfterTerraformDestroy {
+		return c.processStoreDeleteCommand(hook, payload)
+	}
+	return c.processStoreCommand(hook, payload)
 }
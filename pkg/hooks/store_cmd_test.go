@@ -0,0 +1,223 @@
+package hooks
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errUtils "github.com/cloudposse/atmos/errors"
+	"github.com/cloudposse/atmos/pkg/schema"
+	"github.com/cloudposse/atmos/pkg/store"
+)
+
+// memoryStore is an in-memory store.Store keyed by `<stack>/<component>/<key>`.
+type memoryStore struct {
+	data map[string]any
+}
+
+func (s *memoryStore) key(stack string, component string, key string) string {
+	return fmt.Sprintf("%s/%s/%s", stack, component, key)
+}
+
+func (s *memoryStore) Set(stack string, component string, key string, value any) error {
+	s.data[s.key(stack, component, key)] = value
+	return nil
+}
+
+func (s *memoryStore) Get(stack string, component string, key string) (any, error) {
+	value, ok := s.data[s.key(stack, component, key)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", store.ErrResourceNotFound, key)
+	}
+	// The stores return the lists decoded from JSON
+	if list, ok := value.([]string); ok {
+		values := make([]any, 0, len(list))
+		for _, v := range list {
+			values = append(values, v)
+		}
+		return values, nil
+	}
+	return value, nil
+}
+
+func (s *memoryStore) Delete(stack string, component string, key string) error {
+	if _, ok := s.data[s.key(stack, component, key)]; !ok {
+		return fmt.Errorf("%w: %s", store.ErrResourceNotFound, key)
+	}
+	delete(s.data, s.key(stack, component, key))
+	return nil
+}
+
+func (s *memoryStore) List(stack string, component string) ([]string, error) {
+	var keys []string
+	prefix := s.key(stack, component, "")
+	for k := range s.data {
+		if key, ok := strings.CutPrefix(k, prefix); ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (s *memoryStore) Exists(stack string, component string, key string) (bool, error) {
+	_, ok := s.data[s.key(stack, component, key)]
+	return ok, nil
+}
+
+func newTestStoreCommand(t *testing.T) (*StoreCommand, *memoryStore, *int) {
+	origGetTerraformOutputs := getTerraformOutputs
+	t.Cleanup(func() { getTerraformOutputs = origGetTerraformOutputs })
+
+	calls := 0
+	getTerraformOutputs = func(_ *schema.AtmosConfiguration, component string, stack string) (map[string]any, map[string]bool, error) {
+		calls++
+		return map[string]any{
+			"vpc_id":          "vpc-123",
+			"vpc_cidr":        "10.0.0.0/16",
+			"subnet_ids":      []any{"subnet-a", "subnet-b"},
+			"nat":             map[string]any{"ips": []any{"1.2.3.4"}},
+			"db_password":     "s3cr3t",
+			"kubeconfig_data": "apiVersion: v1",
+		}, map[string]bool{
+			"db_password":     true,
+			"kubeconfig_data": true,
+		}, nil
+	}
+
+	s := &memoryStore{data: map[string]any{}}
+	atmosConfig := &schema.AtmosConfiguration{Stores: store.StoreRegistry{"prod/ssm": s}}
+	storeCmd, err := NewStoreCommand(atmosConfig, &schema.ConfigAndStacksInfo{Stack: "plat-ue2-prod", ComponentFromArg: "vpc"}, "store-outputs")
+	require.NoError(t, err)
+	return storeCmd, s, &calls
+}
+
+func TestStoreCommand_Outputs(t *testing.T) {
+	storeCmd, s, calls := newTestStoreCommand(t)
+
+	hook := &Hook{
+		Command: "store",
+		Name:    "prod/ssm",
+		Outputs: map[string]string{
+			"vpc_id":       ".vpc_id",
+			"first_subnet": ".subnet_ids[0]",
+			"nat_ip":       ".nat.ips[0]",
+			"password":     ".db_password",
+			"environment":  "production",
+		},
+	}
+	require.NoError(t, storeCmd.RunE(context.Background(), hook, &EventPayload{Event: AfterTerraformApply}, nil, nil))
+
+	assert.Equal(t, 1, *calls)
+	assert.Equal(t, "vpc-123", s.data["plat-ue2-prod/vpc/vpc_id"])
+	assert.Equal(t, "subnet-a", s.data["plat-ue2-prod/vpc/first_subnet"])
+	assert.Equal(t, "1.2.3.4", s.data["plat-ue2-prod/vpc/nat_ip"])
+	assert.Equal(t, "s3cr3t", s.data["plat-ue2-prod/vpc/password"])
+	assert.Equal(t, "production", s.data["plat-ue2-prod/vpc/environment"])
+	assert.Equal(t, []string{"environment", "first_subnet", "nat_ip", "password", "vpc_id"}, s.data["plat-ue2-prod/vpc/atmos_hook_store-outputs_published_keys"])
+	assert.Len(t, s.data, 6)
+
+	// The sensitive outputs are masked
+	hook.Sensitive = SensitiveMask
+	require.NoError(t, storeCmd.RunE(context.Background(), hook, &EventPayload{Event: AfterTerraformApply}, nil, nil))
+	assert.Equal(t, maskedSensitiveValue, s.data["plat-ue2-prod/vpc/password"])
+
+	// Literal values don't need the outputs
+	*calls = 0
+	hook = &Hook{Command: "store", Name: "prod/ssm", Outputs: map[string]string{"environment": "production"}}
+	require.NoError(t, storeCmd.RunE(context.Background(), hook, &EventPayload{Event: AfterTerraformApply}, nil, nil))
+	assert.Equal(t, 0, *calls)
+}
+
+func TestStoreCommand_Include(t *testing.T) {
+	storeCmd, s, calls := newTestStoreCommand(t)
+
+	hook := &Hook{
+		Command: "store",
+		Name:    "prod/ssm",
+		Include: []string{"*"},
+		Exclude: []string{"nat"},
+		Outputs: map[string]string{"primary_subnet": ".subnet_ids[0]"},
+	}
+	require.NoError(t, storeCmd.RunE(context.Background(), hook, &EventPayload{Event: AfterTerraformApply}, nil, nil))
+
+	assert.Equal(t, 1, *calls)
+	assert.Equal(t, map[string]any{
+		"plat-ue2-prod/vpc/vpc_id":                                  "vpc-123",
+		"plat-ue2-prod/vpc/vpc_cidr":                                "10.0.0.0/16",
+		"plat-ue2-prod/vpc/subnet_ids":                              []any{"subnet-a", "subnet-b"},
+		"plat-ue2-prod/vpc/primary_subnet":                          "subnet-a",
+		"plat-ue2-prod/vpc/atmos_hook_store-outputs_published_keys": []string{"primary_subnet", "subnet_ids", "vpc_cidr", "vpc_id"},
+	}, s.data)
+
+	// With `sensitive: mask`, the sensitive outputs are published with a masked value
+	secretsCmd, err := NewStoreCommand(storeCmd.atmosConfig, storeCmd.info, "store-secrets")
+	require.NoError(t, err)
+	secretsHook := &Hook{Command: "store", Name: "prod/ssm", Include: []string{"db_*", "kubeconfig_*"}, Sensitive: SensitiveMask}
+	require.NoError(t, secretsCmd.RunE(context.Background(), secretsHook, &EventPayload{Event: AfterTerraformApply}, nil, nil))
+	assert.Equal(t, maskedSensitiveValue, s.data["plat-ue2-prod/vpc/db_password"])
+	assert.Equal(t, maskedSensitiveValue, s.data["plat-ue2-prod/vpc/kubeconfig_data"])
+
+	// After destroy, only the keys published by the hook are deleted, not the keys matching `include` written by other
+	// hooks, by `atmos store set` or by other tools
+	require.NoError(t, s.Set("plat-ue2-prod", "vpc", "owner", "platform-team"))
+	require.NoError(t, s.Set("plat-ue2-prod", "eks", "vpc_id", "vpc-123"))
+	require.NoError(t, storeCmd.RunE(context.Background(), hook, &EventPayload{Event: AfterTerraformDestroy}, nil, nil))
+	assert.Equal(t, map[string]any{
+		"plat-ue2-prod/vpc/db_password":                             maskedSensitiveValue,
+		"plat-ue2-prod/vpc/kubeconfig_data":                         maskedSensitiveValue,
+		"plat-ue2-prod/vpc/atmos_hook_store-secrets_published_keys": []string{"db_password", "kubeconfig_data"},
+		"plat-ue2-prod/vpc/owner":                                   "platform-team",
+		"plat-ue2-prod/eks/vpc_id":                                  "vpc-123",
+	}, s.data)
+
+	require.NoError(t, secretsCmd.RunE(context.Background(), secretsHook, &EventPayload{Event: AfterTerraformDestroy}, nil, nil))
+	assert.Equal(t, map[string]any{
+		"plat-ue2-prod/vpc/owner":  "platform-team",
+		"plat-ue2-prod/eks/vpc_id": "vpc-123",
+	}, s.data)
+}
+
+func TestStoreCommand_PublishedKeysAreKept(t *testing.T) {
+	storeCmd, s, _ := newTestStoreCommand(t)
+
+	// The keys of the outputs that are no longer published are deleted after destroy too
+	hook := &Hook{Command: "store", Name: "prod/ssm", Include: []string{"vpc_*"}}
+	require.NoError(t, storeCmd.RunE(context.Background(), hook, &EventPayload{Event: AfterTerraformApply}, nil, nil))
+	hook.Include = []string{"vpc_id"}
+	require.NoError(t, storeCmd.RunE(context.Background(), hook, &EventPayload{Event: AfterTerraformApply}, nil, nil))
+	assert.Equal(t, []string{"vpc_cidr", "vpc_id"}, s.data["plat-ue2-prod/vpc/atmos_hook_store-outputs_published_keys"])
+
+	require.NoError(t, storeCmd.RunE(context.Background(), hook, &EventPayload{Event: AfterTerraformDestroy}, nil, nil))
+	assert.Empty(t, s.data)
+
+	// The keys in `outputs` are deleted even if they were not recorded
+	require.NoError(t, s.Set("plat-ue2-prod", "vpc", "vpc_id", "vpc-123"))
+	hook = &Hook{Command: "store", Name: "prod/ssm", Outputs: map[string]string{"vpc_id": ".vpc_id"}}
+	require.NoError(t, storeCmd.RunE(context.Background(), hook, &EventPayload{Event: AfterTerraformDestroy}, nil, nil))
+	assert.Empty(t, s.data)
+}
+
+func TestStoreCommand_Errors(t *testing.T) {
+	storeCmd, s, _ := newTestStoreCommand(t)
+	payload := &EventPayload{Event: AfterTerraformApply}
+
+	err := storeCmd.RunE(context.Background(), &Hook{Command: "store", Name: "prod/ssm", Include: []string{"*"}, Sensitive: "hide"}, payload, nil, nil)
+	assert.ErrorIs(t, err, errUtils.ErrInvalidHook)
+
+	err = storeCmd.RunE(context.Background(), &Hook{Command: "store", Name: "prod/ssm", Include: []string{"["}}, payload, nil, nil)
+	assert.ErrorIs(t, err, errUtils.ErrInvalidHook)
+
+	err = storeCmd.RunE(context.Background(), &Hook{Command: "store", Name: "dev/ssm", Include: []string{"*"}}, payload, nil, nil)
+	assert.Error(t, err)
+
+	// The outputs of a failed apply are not published
+	payload.ExitCode = 1
+	require.NoError(t, storeCmd.RunE(context.Background(), &Hook{Command: "store", Name: "prod/ssm", Include: []string{"*"}}, payload, nil, nil))
+	assert.Empty(t, s.data)
+}
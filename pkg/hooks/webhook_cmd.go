@@ -0,0 +1,171 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	log "github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+
+	errUtils "github.com/cloudposse/atmos/errors"
+	e "github.com/cloudposse/atmos/internal/exec"
+	"github.com/cloudposse/atmos/pkg/git"
+	"github.com/cloudposse/atmos/pkg/schema"
+)
+
+const (
+	// webhookRequestTimeout is the timeout of the requests sent by the webhook command, if the hook has no `timeout`.
+	webhookRequestTimeout = 30 * time.Second
+	// webhookSignatureHeader is the header holding the HMAC-SHA256 signature of the request body.
+	webhookSignatureHeader = "X-Atmos-Signature-256"
+)
+
+// getCommitSHA is a variable so that it can be replaced in tests.
+var getCommitSHA = git.GetCurrentCommitSHA
+
+// assert that WebhookCommand implements Command interface
+var _ Command = &WebhookCommand{}
+
+// WebhookCommand sends a JSON payload describing the event to an HTTP endpoint.
+type WebhookCommand struct {
+	Name        string
+	atmosConfig *schema.AtmosConfiguration
+	info        *schema.ConfigAndStacksInfo
+	// sections are the component's sections, used as the data to render the templates in `url`, `headers` and `secret`
+	sections map[string]any
+}
+
+// WebhookPayload is the JSON body sent by the webhook command.
+type WebhookPayload struct {
+	Event           HookEvent               `json:"event"`
+	Status          string                  `json:"status"`
+	ComponentType   string                  `json:"component_type"`
+	Component       string                  `json:"component"`
+	Stack           string                  `json:"stack"`
+	Workspace       string                  `json:"workspace,omitempty"`
+	ExitCode        int                     `json:"exit_code"`
+	DurationSeconds float64                 `json:"duration_seconds"`
+	GitSHA          string                  `json:"git_sha,omitempty"`
+	Timestamp       time.Time               `json:"timestamp"`
+	PlanSummary     *e.TerraformPlanSummary `json:"plan_summary,omitempty"`
+}
+
+func NewWebhookCommand(atmosConfig *schema.AtmosConfiguration, info *schema.ConfigAndStacksInfo, sections map[string]any) (*WebhookCommand, error) {
+	return &WebhookCommand{
+		Name:        "webhook",
+		atmosConfig: atmosConfig,
+		info:        info,
+		sections:    sections,
+	}, nil
+}
+
+func (c *WebhookCommand) GetName() string {
+	return c.Name
+}
+
+// RunE is the entrypoint for the webhook command
+func (c *WebhookCommand) RunE(ctx context.Context, hook *Hook, payload *EventPayload, cmd *cobra.Command, args []string) error {
+	if hook.URL == "" {
+		return fmt.Errorf("%w: `url` is required for the `webhook` command", errUtils.ErrInvalidHook)
+	}
+
+	url, err := render(c.atmosConfig, c.info, hook, c.sections, "url", hook.URL)
+	if err != nil {
+		return err
+	}
+
+	headers := make(map[string]string, len(hook.Headers))
+	for name, value := range hook.Headers {
+		if headers[name], err = render(c.atmosConfig, c.info, hook, c.sections, fmt.Sprintf("headers.%s", name), value); err != nil {
+			return err
+		}
+	}
+
+	secret, err := render(c.atmosConfig, c.info, hook, c.sections, "secret", hook.Secret)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(c.newWebhookPayload(hook, payload))
+	if err != nil {
+		return fmt.Errorf("%w: %v", errUtils.ErrHookCommandFailed, err)
+	}
+
+	if secret != "" {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		headers[webhookSignatureHeader] = "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	log.Debug("Sending webhook", "event", payload.Event, "url", url)
+
+	if err := sendWebhook(ctx, url, headers, body); err != nil {
+		return fmt.Errorf("%w: webhook `%s`: %w", errUtils.ErrHookCommandFailed, url, err)
+	}
+	return nil
+}
+
+// newWebhookPayload returns the JSON body describing the event.
+func (c *WebhookCommand) newWebhookPayload(hook *Hook, payload *EventPayload) *WebhookPayload {
+	webhookPayload := &WebhookPayload{
+		Event:           payload.Event,
+		Status:          payload.Status(),
+		ComponentType:   payload.ComponentType,
+		Component:       payload.Component,
+		Stack:           payload.Stack,
+		Workspace:       payload.Workspace,
+		ExitCode:        payload.ExitCode,
+		DurationSeconds: payload.Duration.Seconds(),
+		Timestamp:       time.Now().UTC(),
+	}
+
+	sha, err := getCommitSHA()
+	if err != nil {
+		log.Debug("Failed to get the git commit SHA", "error", err)
+	}
+	webhookPayload.GitSHA = sha
+
+	if hook.PlanSummary {
+		summary, err := payload.getPlanSummary(c.atmosConfig, c.info)
+		if err != nil {
+			log.Warn("Failed to summarize the Terraform plan", "planfile", payload.PlanFile, "error", err)
+		}
+		webhookPayload.PlanSummary = summary
+	}
+
+	return webhookPayload
+}
+
+// sendWebhook POSTs the body to the URL.
+func sendWebhook(ctx context.Context, url string, headers map[string]string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "atmos")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	client := &http.Client{Timeout: webhookRequestTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status `%s`", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package hooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errUtils "github.com/cloudposse/atmos/errors"
+	e "github.com/cloudposse/atmos/internal/exec"
+	"github.com/cloudposse/atmos/pkg/schema"
+	"github.com/cloudposse/atmos/pkg/store"
+)
+
+// stubWebhookDependencies replaces the git SHA and the plan summary for the duration of the test.
+func stubWebhookDependencies(t *testing.T) {
+	origGetCommitSHA, origGetPlanSummary := getCommitSHA, getPlanSummary
+	t.Cleanup(func() {
+		getCommitSHA, getPlanSummary = origGetCommitSHA, origGetPlanSummary
+	})
+
+	getCommitSHA = func() (string, error) { return "0123abcd", nil }
+	getPlanSummary = func(*schema.AtmosConfiguration, *schema.ConfigAndStacksInfo, string, string) (*e.TerraformPlanSummary, error) {
+		return &e.TerraformPlanSummary{Add: 1, Change: 2, Destroy: 3}, nil
+	}
+}
+
+func TestWebhookCommand(t *testing.T) {
+	stubWebhookDependencies(t)
+
+	var body []byte
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		header = r.Header
+	}))
+	defer server.Close()
+
+	webhookCmd, err := NewWebhookCommand(
+		&schema.AtmosConfiguration{},
+		&schema.ConfigAndStacksInfo{},
+		map[string]any{"vars": map[string]any{"token": "s3cr3t"}},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "webhook", webhookCmd.GetName())
+
+	hook := &Hook{
+		Command:     "webhook",
+		URL:         server.URL,
+		Headers:     map[string]string{"Authorization": "Bearer {{ .vars.token }}"},
+		Secret:      "signing-key",
+		PlanSummary: true,
+	}
+	payload := &EventPayload{
+		Event:         AfterTerraformPlan,
+		ComponentType: "terraform",
+		Component:     "vpc",
+		Stack:         "plat-ue2-dev",
+		Workspace:     "plat-ue2-dev",
+		Duration:      1500 * time.Millisecond,
+	}
+
+	require.NoError(t, webhookCmd.RunE(context.Background(), hook, payload, nil, nil))
+
+	assert.Equal(t, "application/json", header.Get("Content-Type"))
+	assert.Equal(t, "Bearer s3cr3t", header.Get("Authorization"))
+
+	mac := hmac.New(sha256.New, []byte("signing-key"))
+	mac.Write(body)
+	assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), header.Get(webhookSignatureHeader))
+
+	var received WebhookPayload
+	require.NoError(t, json.Unmarshal(body, &received))
+	assert.Equal(t, AfterTerraformPlan, received.Event)
+	assert.Equal(t, StatusSucceeded, received.Status)
+	assert.Equal(t, "vpc", received.Component)
+	assert.Equal(t, "plat-ue2-dev", received.Stack)
+	assert.Equal(t, 1.5, received.DurationSeconds)
+	assert.Equal(t, "0123abcd", received.GitSHA)
+	assert.Equal(t, &e.TerraformPlanSummary{Add: 1, Change: 2, Destroy: 3}, received.PlanSummary)
+
+	// The plan summary is only sent after a successful plan
+	payload.ExitCode = 1
+	require.NoError(t, webhookCmd.RunE(context.Background(), hook, payload, nil, nil))
+	received = WebhookPayload{}
+	require.NoError(t, json.Unmarshal(body, &received))
+	assert.Equal(t, StatusFailed, received.Status)
+	assert.Nil(t, received.PlanSummary)
+}
+
+func TestWebhookCommand_Errors(t *testing.T) {
+	stubWebhookDependencies(t)
+	getCommitSHA = func() (string, error) { return "", errors.New("not a git repository") }
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			time.Sleep(time.Second)
+		}
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	webhookCmd, err := NewWebhookCommand(&schema.AtmosConfiguration{}, &schema.ConfigAndStacksInfo{}, map[string]any{})
+	require.NoError(t, err)
+	payload := &EventPayload{Event: AfterTerraformApply}
+
+	err = webhookCmd.RunE(context.Background(), &Hook{Command: "webhook", URL: server.URL}, payload, nil, nil)
+	assert.ErrorIs(t, err, errUtils.ErrHookCommandFailed)
+
+	// The request is canceled with the context
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err = webhookCmd.RunE(ctx, &Hook{Command: "webhook", URL: server.URL + "/slow"}, payload, nil, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	err = webhookCmd.RunE(context.Background(), &Hook{Command: "webhook"}, payload, nil, nil)
+	assert.ErrorIs(t, err, errUtils.ErrInvalidHook)
+
+	err = webhookCmd.RunE(context.Background(), &Hook{Command: "webhook", URL: server.URL, Headers: map[string]string{"X-Token": "{{ .vars"}}, payload, nil, nil)
+	assert.ErrorIs(t, err, errUtils.ErrInvalidHook)
+}
+
+func TestWebhookCommand_StoreHeader(t *testing.T) {
+	stubWebhookDependencies(t)
+
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+	}))
+	defer server.Close()
+
+	s := &memoryStore{data: map[string]any{}}
+	require.NoError(t, s.Set("plat-ue2-prod", "slack", "webhook_token", "t0k3n"))
+	atmosConfig := &schema.AtmosConfiguration{Stores: store.StoreRegistry{"prod/ssm": s}}
+
+	webhookCmd, err := NewWebhookCommand(atmosConfig, &schema.ConfigAndStacksInfo{Stack: "plat-ue2-prod"}, map[string]any{})
+	require.NoError(t, err)
+
+	hook := &Hook{
+		Command: "webhook",
+		URL:     server.URL,
+		Headers: map[string]string{"Authorization": `Bearer {{ atmos.Store "prod/ssm" "plat-ue2-prod" "slack" "webhook_token" }}`},
+	}
+	require.NoError(t, webhookCmd.RunE(context.Background(), hook, &EventPayload{Event: AfterTerraformApply}, nil, nil))
+	assert.Equal(t, "Bearer t0k3n", header.Get("Authorization"))
+}
+
+func TestEventPayloadStatus(t *testing.T) {
+	assert.Equal(t, StatusStarted, (&EventPayload{Event: BeforeTerraformApply}).Status())
+	assert.Equal(t, StatusSucceeded, (&EventPayload{Event: AfterTerraformApply}).Status())
+	assert.Equal(t, StatusFailed, (&EventPayload{Event: AfterTerraformApply, ExitCode: 1}).Status())
+}
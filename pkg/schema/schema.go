@@ -2,6 +2,7 @@ package schema
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/cloudposse/atmos/pkg/store"
 	"gopkg.in/yaml.v3"
@@ -312,6 +313,29 @@ type Terraform struct {
 	Shell                   ShellConfig   `yaml:"shell" json:"shell" mapstructure:"shell"`
 	Init                    TerraformInit `yaml:"init" json:"init" mapstructure:"init"`
 	Plan                    TerraformPlan `yaml:"plan" json:"plan" mapstructure:"plan"`
+	// BackendReaders configures external executables to read the state of the backend types Atmos doesn't support natively.
+	BackendReaders map[string]TerraformBackendReaderConfig `yaml:"backend_readers,omitempty" json:"backend_readers,omitempty" mapstructure:"backend_readers"`
+	// StateCache configures the persistent cache of the Terraform state and outputs read by the `!terraform.state` and `!terraform.output` YAML functions.
+	StateCache *TerraformStateCache `yaml:"state_cache,omitempty" json:"state_cache,omitempty" mapstructure:"state_cache"`
+}
+
+// TerraformStateCache configures the persistent cache of the Terraform state and outputs.
+type TerraformStateCache struct {
+	Enabled bool `yaml:"enabled" json:"enabled" mapstructure:"enabled"`
+	// Path is the directory of the cache. Defaults to `$XDG_CACHE_HOME/atmos/terraform`.
+	Path string `yaml:"path,omitempty" json:"path,omitempty" mapstructure:"path"`
+	// TTL is how long the entries of the backends that can't report the version of the state are valid.
+	// If not set, the state and outputs of these backends are not cached.
+	TTL string `yaml:"ttl,omitempty" json:"ttl,omitempty" mapstructure:"ttl"`
+}
+
+// TerraformBackendReaderConfig configures an external executable that reads the Terraform state from a backend.
+// The executable receives the `backend` section of the component as JSON on stdin, and prints the raw state file to stdout.
+type TerraformBackendReaderConfig struct {
+	Command string            `yaml:"command" json:"command" mapstructure:"command"`
+	Args    []string          `yaml:"args,omitempty" json:"args,omitempty" mapstructure:"args"`
+	Env     map[string]string `yaml:"env,omitempty" json:"env,omitempty" mapstructure:"env"`
+	Timeout string            `yaml:"timeout,omitempty" json:"timeout,omitempty" mapstructure:"timeout"`
 }
 
 type TerraformInit struct {
@@ -351,6 +375,18 @@ type Stacks struct {
 type Workflows struct {
 	BasePath string     `yaml:"base_path" json:"base_path" mapstructure:"base_path"`
 	List     ListConfig `yaml:"list" json:"list" mapstructure:"list"`
+	// Journal configures the journal of the workflow runs used by `atmos workflow --resume` and `atmos workflow history`.
+	Journal WorkflowJournal `yaml:"journal,omitempty" json:"journal,omitempty" mapstructure:"journal"`
+}
+
+// WorkflowJournal configures the journal of the workflow runs.
+type WorkflowJournal struct {
+	// Enabled enables the journal. Defaults to `true`.
+	Enabled *bool `yaml:"enabled,omitempty" json:"enabled,omitempty" mapstructure:"enabled"`
+	// Path is the directory of the journal. Defaults to `$XDG_STATE_HOME/atmos/workflows`.
+	Path string `yaml:"path,omitempty" json:"path,omitempty" mapstructure:"path"`
+	// MaxRuns is the number of runs kept in the journal. Defaults to 100.
+	MaxRuns int `yaml:"max_runs,omitempty" json:"max_runs,omitempty" mapstructure:"max_runs"`
 }
 
 type Logs struct {
@@ -527,12 +563,72 @@ type WorkflowStep struct {
 	Command string `yaml:"command" json:"command" mapstructure:"command"`
 	Stack   string `yaml:"stack,omitempty" json:"stack,omitempty" mapstructure:"stack"`
 	Type    string `yaml:"type,omitempty" json:"type,omitempty" mapstructure:"type"`
+	// Needs are the names of the steps that must complete before the step runs
+	Needs []string `yaml:"needs,omitempty" json:"needs,omitempty" mapstructure:"needs"`
+	// Retry configures the retries of the step when it fails
+	Retry *WorkflowStepRetry `yaml:"retry,omitempty" json:"retry,omitempty" mapstructure:"retry"`
+	// Timeout is the maximum duration of each attempt to execute the step (e.g. `10m`)
+	Timeout string `yaml:"timeout,omitempty" json:"timeout,omitempty" mapstructure:"timeout"`
+	// ContinueOnError continues the workflow if the step fails
+	ContinueOnError bool `yaml:"continue_on_error,omitempty" json:"continue_on_error,omitempty" mapstructure:"continue_on_error"`
+	// Env are the ENV variables of the step command
+	Env map[string]string `yaml:"env,omitempty" json:"env,omitempty" mapstructure:"env"`
+	// Outputs maps the names of the step outputs to YQ expressions evaluated against the output of the step command
+	Outputs map[string]string `yaml:"outputs,omitempty" json:"outputs,omitempty" mapstructure:"outputs"`
+	// ForEach expands the step into one step for each item of a list, or for each stack matching a filter or a query
+	ForEach *WorkflowStepForEach `yaml:"for_each,omitempty" json:"for_each,omitempty" mapstructure:"for_each"`
+	// File is the workflow manifest of the workflow called by a step of type `workflow` (defaults to the manifest of the step)
+	File string `yaml:"file,omitempty" json:"file,omitempty" mapstructure:"file"`
+	// Inputs are the inputs of the workflow called by a step of type `workflow`
+	Inputs map[string]any `yaml:"inputs,omitempty" json:"inputs,omitempty" mapstructure:"inputs"`
+	// If is a condition evaluated before the step runs. The step is skipped if the condition is `false`
+	If string `yaml:"if,omitempty" json:"if,omitempty" mapstructure:"if"`
+	// ShowOutput includes the end of the output of the previous steps in the message of a step of type `approval`
+	ShowOutput bool `yaml:"show_output,omitempty" json:"show_output,omitempty" mapstructure:"show_output"`
+}
+
+type WorkflowStepForEach struct {
+	// Items is a list of items. The templates of the step can reference the current item as `{{ .item }}`
+	Items []any `yaml:"items,omitempty" json:"items,omitempty" mapstructure:"items"`
+	// Stacks maps the names of the context variables (e.g. `tenant`, `stage`) to glob patterns that the stacks must match
+	Stacks map[string]string `yaml:"stacks,omitempty" json:"stacks,omitempty" mapstructure:"stacks"`
+	// Query is a YQ expression evaluated against the Terraform components in the stacks (e.g. `.vars.tags.team == "eks"`)
+	Query string `yaml:"query,omitempty" json:"query,omitempty" mapstructure:"query"`
+	// Components limits the components that the `stacks` filter and the `query` are evaluated against
+	Components []string `yaml:"components,omitempty" json:"components,omitempty" mapstructure:"components"`
+}
+
+type WorkflowStepRetry struct {
+	// Attempts is the maximum number of attempts to execute the step, including the first attempt
+	Attempts int `yaml:"attempts,omitempty" json:"attempts,omitempty" mapstructure:"attempts"`
+	// Delay is the delay before the first retry (e.g. `10s`)
+	Delay string `yaml:"delay,omitempty" json:"delay,omitempty" mapstructure:"delay"`
+	// Backoff is how the delay grows between the retries: `constant` (default), `linear` or `exponential`
+	Backoff string `yaml:"backoff,omitempty" json:"backoff,omitempty" mapstructure:"backoff"`
+}
+
+type WorkflowInput struct {
+	Description string `yaml:"description,omitempty" json:"description,omitempty" mapstructure:"description"`
+	// Type is the type of the input: `string` (default), `number` or `boolean`
+	Type string `yaml:"type,omitempty" json:"type,omitempty" mapstructure:"type"`
+	// Default is the default value of the input. The inputs without a default value are required
+	Default any `yaml:"default,omitempty" json:"default,omitempty" mapstructure:"default"`
+	// Sensitive inputs (e.g. tokens) are not recorded in the workflow journal
+	Sensitive bool `yaml:"sensitive,omitempty" json:"sensitive,omitempty" mapstructure:"sensitive"`
 }
 
 type WorkflowDefinition struct {
 	Description string         `yaml:"description,omitempty" json:"description,omitempty" mapstructure:"description"`
 	Steps       []WorkflowStep `yaml:"steps" json:"steps" mapstructure:"steps"`
 	Stack       string         `yaml:"stack,omitempty" json:"stack,omitempty" mapstructure:"stack"`
+	// MaxParallel is the maximum number of steps that run concurrently in the workflows with `needs` or `for_each`
+	MaxParallel int `yaml:"max_parallel,omitempty" json:"max_parallel,omitempty" mapstructure:"max_parallel"`
+	// OnFailure are the steps that run after the steps if any step failed
+	OnFailure []WorkflowStep `yaml:"on_failure,omitempty" json:"on_failure,omitempty" mapstructure:"on_failure"`
+	// Finally are the steps that always run after the steps (and the `on_failure` steps)
+	Finally []WorkflowStep `yaml:"finally,omitempty" json:"finally,omitempty" mapstructure:"finally"`
+	// Inputs are the parameters of the workflow, set on the command line with `--input`
+	Inputs map[string]WorkflowInput `yaml:"inputs,omitempty" json:"inputs,omitempty" mapstructure:"inputs"`
 }
 
 type WorkflowConfig map[string]WorkflowDefinition
@@ -543,9 +639,67 @@ type WorkflowManifest struct {
 	Workflows   WorkflowConfig `yaml:"workflows" json:"workflows" mapstructure:"workflows"`
 }
 
+// WorkflowRun is a run of a workflow recorded in the workflow journal.
+type WorkflowRun struct {
+	ID       string `yaml:"id" json:"id" mapstructure:"id"`
+	Workflow string `yaml:"workflow" json:"workflow" mapstructure:"workflow"`
+	// File is the workflow manifest, relative to `workflows.base_path`
+	File string `yaml:"file" json:"file" mapstructure:"file"`
+	// Path is the absolute path of the workflow manifest
+	Path string `yaml:"path,omitempty" json:"path,omitempty" mapstructure:"path"`
+	// Stack is the stack defined on the command line
+	Stack string `yaml:"stack,omitempty" json:"stack,omitempty" mapstructure:"stack"`
+	// Inputs are the workflow inputs set on the command line
+	Inputs   map[string]string `yaml:"inputs,omitempty" json:"inputs,omitempty" mapstructure:"inputs"`
+	FromStep string            `yaml:"from_step,omitempty" json:"from_step,omitempty" mapstructure:"from_step"`
+	DryRun   bool              `yaml:"dry_run,omitempty" json:"dry_run,omitempty" mapstructure:"dry_run"`
+	// Status is `running`, `succeeded` or `failed`. A run interrupted before it completed stays `running`
+	Status     string            `yaml:"status" json:"status" mapstructure:"status"`
+	StartedAt  time.Time         `yaml:"started_at" json:"started_at" mapstructure:"started_at"`
+	FinishedAt *time.Time        `yaml:"finished_at,omitempty" json:"finished_at,omitempty" mapstructure:"finished_at"`
+	Steps      []WorkflowRunStep `yaml:"steps" json:"steps" mapstructure:"steps"`
+	// ResumeSteps are the steps to resume the failed run from
+	ResumeSteps []string `yaml:"resume_steps,omitempty" json:"resume_steps,omitempty" mapstructure:"resume_steps"`
+}
+
+// WorkflowRunStep is a step of a workflow run recorded in the workflow journal.
+type WorkflowRunStep struct {
+	Name string `yaml:"name" json:"name" mapstructure:"name"`
+	// Kind is `on_failure` or `finally` for the cleanup steps, and empty for the other steps
+	Kind    string `yaml:"kind,omitempty" json:"kind,omitempty" mapstructure:"kind"`
+	Type    string `yaml:"type" json:"type" mapstructure:"type"`
+	Command string `yaml:"command" json:"command" mapstructure:"command"`
+	// Stack is the final stack of the step
+	Stack string `yaml:"stack,omitempty" json:"stack,omitempty" mapstructure:"stack"`
+	// Status is `pending`, `running`, `succeeded`, `failed`, `continued` (failed with `continue_on_error`) or `skipped`
+	Status     string     `yaml:"status" json:"status" mapstructure:"status"`
+	StartedAt  *time.Time `yaml:"started_at,omitempty" json:"started_at,omitempty" mapstructure:"started_at"`
+	FinishedAt *time.Time `yaml:"finished_at,omitempty" json:"finished_at,omitempty" mapstructure:"finished_at"`
+	Duration   string     `yaml:"duration,omitempty" json:"duration,omitempty" mapstructure:"duration"`
+	// ExitCode is the exit code of the last attempt to execute the step command
+	ExitCode *int   `yaml:"exit_code,omitempty" json:"exit_code,omitempty" mapstructure:"exit_code"`
+	Attempts int    `yaml:"attempts,omitempty" json:"attempts,omitempty" mapstructure:"attempts"`
+	Retries  int    `yaml:"retries,omitempty" json:"retries,omitempty" mapstructure:"retries"`
+	Error    string `yaml:"error,omitempty" json:"error,omitempty" mapstructure:"error"`
+	// Output is the end of the output of the step command. It's only captured for the workflow run reports
+	Output string `yaml:"output,omitempty" json:"output,omitempty" mapstructure:"output"`
+	// ApprovedBy is the user who approved a step of type `approval`
+	ApprovedBy string `yaml:"approved_by,omitempty" json:"approved_by,omitempty" mapstructure:"approved_by"`
+	// AutoApproved is true if a step of type `approval` was approved by the `--auto-approve-gates` flag
+	AutoApproved bool `yaml:"auto_approved,omitempty" json:"auto_approved,omitempty" mapstructure:"auto_approved"`
+}
+
 type DescribeWorkflowsItem struct {
 	File     string `yaml:"file" json:"file" mapstructure:"file"`
 	Workflow string `yaml:"workflow" json:"workflow" mapstructure:"workflow"`
+	// Calls are the workflows called by the steps of type `workflow` of the workflow
+	Calls []DescribeWorkflowsCall `yaml:"calls,omitempty" json:"calls,omitempty" mapstructure:"calls"`
+}
+
+type DescribeWorkflowsCall struct {
+	Step     string `yaml:"step" json:"step" mapstructure:"step"`
+	File     string `yaml:"file" json:"file" mapstructure:"file"`
+	Workflow string `yaml:"workflow" json:"workflow" mapstructure:"workflow"`
 }
 
 // EKS update-kubeconfig
@@ -5,14 +5,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/jfrog/jfrog-client-go/artifactory"
 	"github.com/jfrog/jfrog-client-go/artifactory/auth"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	"github.com/jfrog/jfrog-client-go/config"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
 	al "github.com/jfrog/jfrog-client-go/utils/log"
 
 	log "github.com/charmbracelet/log"
@@ -42,6 +46,9 @@ type ArtifactoryStoreOptions struct {
 type ArtifactoryClient interface {
 	DownloadFiles(...services.DownloadParams) (int, int, error)
 	UploadFiles(artifactory.UploadServiceOptions, ...services.UploadParams) (int, int, error)
+	GetPathsToDelete(services.DeleteParams) (*content.ContentReader, error)
+	DeleteFiles(*content.ContentReader) (int, error)
+	SearchFiles(services.SearchParams) (*content.ContentReader, error)
 }
 
 // Ensure ArtifactoryStore implements the store.Store interface.
@@ -278,3 +285,110 @@ func (s *ArtifactoryStore) Set(stack string, component string, key string, value
 
 	return nil
 }
+
+// Delete removes a key from Artifactory.
+func (s *ArtifactoryStore) Delete(stack string, component string, key string) error {
+	if err := validateKeyArgs(stack, component, key); err != nil {
+		return err
+	}
+
+	paramName, err := s.getKey(stack, component, key)
+	if err != nil {
+		return fmt.Errorf(errFormatWithCause, ErrGetKey, err)
+	}
+
+	deleteParams := services.NewDeleteParams()
+	deleteParams.Pattern = paramName
+	deleteParams.Recursive = false
+
+	reader, err := s.rtManager.GetPathsToDelete(deleteParams)
+	if err != nil {
+		return fmt.Errorf(errFormatWithCause, ErrDeleteParameter, err)
+	}
+	defer reader.Close()
+
+	deleted, err := s.rtManager.DeleteFiles(reader)
+	if err != nil {
+		return fmt.Errorf(errFormatWithCause, ErrDeleteParameter, err)
+	}
+
+	if deleted == 0 {
+		return fmt.Errorf("%w: %s", ErrResourceNotFound, paramName)
+	}
+
+	return nil
+}
+
+// searchFiles returns the paths of the files matching the pattern, relative to the repository root and
+// including the repository name.
+func (s *ArtifactoryStore) searchFiles(pattern string, recursive bool) ([]string, error) {
+	searchParams := services.NewSearchParams()
+	searchParams.Pattern = pattern
+	searchParams.Recursive = recursive
+
+	reader, err := s.rtManager.SearchFiles(searchParams)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	paths := []string{}
+	for item := new(utils.ResultItem); reader.NextRecord(item) == nil; item = new(utils.ResultItem) {
+		paths = append(paths, path.Join(item.Repo, item.Path, item.Name))
+	}
+
+	if err := reader.GetError(); err != nil {
+		return nil, err
+	}
+
+	return paths, nil
+}
+
+// List returns the keys stored in Artifactory for a stack and component.
+func (s *ArtifactoryStore) List(stack string, component string) ([]string, error) {
+	if err := validateStackAndComponent(stack, component); err != nil {
+		return nil, err
+	}
+
+	if s.stackDelimiter == nil {
+		return nil, ErrStackDelimiterNotSet
+	}
+
+	prefix := strings.Join([]string{s.repoName, s.prefix}, "/")
+	keyPrefix, err := getKeyPrefix(prefix, *s.stackDelimiter, stack, component, "/")
+	if err != nil {
+		return nil, fmt.Errorf(errFormatWithCause, ErrGetKey, err)
+	}
+
+	paths, err := s.searchFiles(keyPrefix+"*", true)
+	if err != nil {
+		return nil, fmt.Errorf(errFormatWithCause, ErrListParameters, err)
+	}
+
+	keys := []string{}
+	for _, p := range paths {
+		keys = append(keys, strings.TrimPrefix(p, keyPrefix))
+	}
+
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// Exists checks if a key exists in Artifactory.
+func (s *ArtifactoryStore) Exists(stack string, component string, key string) (bool, error) {
+	if err := validateKeyArgs(stack, component, key); err != nil {
+		return false, err
+	}
+
+	paramName, err := s.getKey(stack, component, key)
+	if err != nil {
+		return false, fmt.Errorf(errFormatWithCause, ErrGetKey, err)
+	}
+
+	paths, err := s.searchFiles(paramName, false)
+	if err != nil {
+		return false, fmt.Errorf(errFormatWithCause, ErrDownloadFile, err)
+	}
+
+	return len(paths) > 0, nil
+}
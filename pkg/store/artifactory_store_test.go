@@ -1,6 +1,7 @@
 package store
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -10,6 +11,8 @@ import (
 	log "github.com/charmbracelet/log"
 	"github.com/jfrog/jfrog-client-go/artifactory"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
 	al "github.com/jfrog/jfrog-client-go/utils/log"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -73,6 +76,38 @@ func (m *MockArtifactoryClient) UploadFiles(options artifactory.UploadServiceOpt
 	return args.Int(0), args.Int(1), args.Error(2)
 }
 
+func (m *MockArtifactoryClient) GetPathsToDelete(params services.DeleteParams) (*content.ContentReader, error) {
+	args := m.Called(params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*content.ContentReader), args.Error(1)
+}
+
+func (m *MockArtifactoryClient) DeleteFiles(reader *content.ContentReader) (int, error) {
+	args := m.Called(reader)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockArtifactoryClient) SearchFiles(params services.SearchParams) (*content.ContentReader, error) {
+	args := m.Called(params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*content.ContentReader), args.Error(1)
+}
+
+// newTestContentReader writes the result items to a file and returns a content reader for it.
+func newTestContentReader(t *testing.T, items ...utils.ResultItem) *content.ContentReader {
+	data, err := json.Marshal(map[string]interface{}{content.DefaultKey: items})
+	assert.NoError(t, err)
+
+	filePath := filepath.Join(t.TempDir(), "results.json")
+	assert.NoError(t, os.WriteFile(filePath, data, 0o600))
+
+	return content.NewContentReader(filePath, content.DefaultKey)
+}
+
 func TestNewArtifactoryStore(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -413,3 +448,104 @@ func TestArtifactoryStore_LoggingConfiguration(t *testing.T) {
 		})
 	}
 }
+
+func TestArtifactoryStore_Delete(t *testing.T) {
+	stackDelimiter := "/"
+
+	tests := []struct {
+		name      string
+		mockSetup func(*MockArtifactoryClient)
+		wantErr   error
+	}{
+		{
+			name: "successful_delete",
+			mockSetup: func(m *MockArtifactoryClient) {
+				reader := content.NewEmptyContentReader(content.DefaultKey)
+				m.On("GetPathsToDelete", mock.MatchedBy(func(p services.DeleteParams) bool {
+					return p.Pattern == "test-repo/prefix/dev/app/key"
+				})).Return(reader, nil)
+				m.On("DeleteFiles", reader).Return(1, nil)
+			},
+		},
+		{
+			name: "not_found",
+			mockSetup: func(m *MockArtifactoryClient) {
+				reader := content.NewEmptyContentReader(content.DefaultKey)
+				m.On("GetPathsToDelete", mock.Anything).Return(reader, nil)
+				m.On("DeleteFiles", reader).Return(0, nil)
+			},
+			wantErr: ErrResourceNotFound,
+		},
+		{
+			name: "delete_error",
+			mockSetup: func(m *MockArtifactoryClient) {
+				m.On("GetPathsToDelete", mock.Anything).Return(nil, fmt.Errorf("connection refused"))
+			},
+			wantErr: ErrDeleteParameter,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := new(MockArtifactoryClient)
+			tt.mockSetup(mockClient)
+
+			store := &ArtifactoryStore{
+				prefix:         "prefix",
+				repoName:       "test-repo",
+				rtManager:      mockClient,
+				stackDelimiter: &stackDelimiter,
+			}
+
+			err := store.Delete("dev", "app", "key")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestArtifactoryStore_ListAndExists(t *testing.T) {
+	stackDelimiter := "/"
+	mockClient := new(MockArtifactoryClient)
+
+	mockClient.On("SearchFiles", mock.MatchedBy(func(p services.SearchParams) bool {
+		return p.Pattern == "test-repo/prefix/dev/app/*"
+	})).Return(newTestContentReader(t,
+		utils.ResultItem{Repo: "test-repo", Path: "prefix/dev/app", Name: "vpc_id"},
+		utils.ResultItem{Repo: "test-repo", Path: "prefix/dev/app", Name: "cidr"},
+	), nil)
+	mockClient.On("SearchFiles", mock.MatchedBy(func(p services.SearchParams) bool {
+		return p.Pattern == "test-repo/prefix/dev/app/vpc_id"
+	})).Return(newTestContentReader(t,
+		utils.ResultItem{Repo: "test-repo", Path: "prefix/dev/app", Name: "vpc_id"},
+	), nil)
+	mockClient.On("SearchFiles", mock.MatchedBy(func(p services.SearchParams) bool {
+		return p.Pattern == "test-repo/prefix/dev/app/missing"
+	})).Return(content.NewEmptyContentReader(content.DefaultKey), nil)
+
+	store := &ArtifactoryStore{
+		prefix:         "prefix",
+		repoName:       "test-repo",
+		rtManager:      mockClient,
+		stackDelimiter: &stackDelimiter,
+	}
+
+	keys, err := store.List("dev", "app")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cidr", "vpc_id"}, keys)
+
+	exists, err := store.Exists("dev", "app", "vpc_id")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = store.Exists("dev", "app", "missing")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	_, err = store.List("", "app")
+	assert.ErrorIs(t, err, ErrEmptyStack)
+}
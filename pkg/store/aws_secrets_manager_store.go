@@ -0,0 +1,365 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	smtypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// SecretsManagerStore is an implementation of the Store interface for AWS Secrets Manager.
+type SecretsManagerStore struct {
+	client                  SecretsManagerClient
+	prefix                  string
+	stackDelimiter          *string
+	awsConfig               *aws.Config
+	readRoleArn             *string
+	writeRoleArn            *string
+	kmsKeyID                *string
+	tags                    map[string]string
+	newSTSClient            func(cfg aws.Config) STSClient
+	newSecretsManagerClient func(cfg aws.Config) SecretsManagerClient
+}
+
+type SecretsManagerStoreOptions struct {
+	Prefix         *string           `mapstructure:"prefix"`
+	Region         string            `mapstructure:"region"`
+	StackDelimiter *string           `mapstructure:"stack_delimiter"`
+	ReadRoleArn    *string           `mapstructure:"read_role_arn"`
+	WriteRoleArn   *string           `mapstructure:"write_role_arn"`
+	KmsKeyID       *string           `mapstructure:"kms_key_id"` // Used when creating new secrets
+	Tags           map[string]string `mapstructure:"tags"`       // Applied when creating new secrets
+	Endpoint       *string           `mapstructure:"endpoint"`   // Endpoint override, e.g. for LocalStack
+}
+
+// Ensure SecretsManagerStore implements the store.Store interface.
+var _ Store = (*SecretsManagerStore)(nil)
+
+// SecretsManagerClient interface allows us to mock the AWS Secrets Manager client.
+type SecretsManagerClient interface {
+	CreateSecret(ctx context.Context, params *secretsmanager.CreateSecretInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.CreateSecretOutput, error)
+	PutSecretValue(ctx context.Context, params *secretsmanager.PutSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.PutSecretValueOutput, error)
+	GetSecretValue(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error)
+	DeleteSecret(ctx context.Context, params *secretsmanager.DeleteSecretInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.DeleteSecretOutput, error)
+	DescribeSecret(ctx context.Context, params *secretsmanager.DescribeSecretInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.DescribeSecretOutput, error)
+	ListSecrets(ctx context.Context, params *secretsmanager.ListSecretsInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.ListSecretsOutput, error)
+}
+
+// NewSecretsManagerStore initializes a new SecretsManagerStore.
+func NewSecretsManagerStore(options SecretsManagerStoreOptions) (Store, error) {
+	ctx := context.TODO()
+
+	if options.Region == "" {
+		return nil, ErrRegionRequired
+	}
+
+	// Load AWS configuration (can be customized using options)
+	awsConfig, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(errWrapFormat, ErrLoadAWSConfig, err)
+	}
+
+	awsConfig.Region = options.Region
+
+	newClient := func(cfg aws.Config) SecretsManagerClient {
+		return secretsmanager.NewFromConfig(cfg, func(o *secretsmanager.Options) {
+			if options.Endpoint != nil && *options.Endpoint != "" {
+				o.BaseEndpoint = options.Endpoint
+			}
+		})
+	}
+
+	store := &SecretsManagerStore{
+		client:    newClient(awsConfig),
+		awsConfig: &awsConfig,
+		kmsKeyID:  options.KmsKeyID,
+		tags:      options.Tags,
+		newSTSClient: func(cfg aws.Config) STSClient {
+			return sts.NewFromConfig(cfg)
+		},
+		newSecretsManagerClient: newClient,
+	}
+
+	if options.Prefix != nil {
+		store.prefix = *options.Prefix
+	}
+
+	if options.StackDelimiter != nil {
+		store.stackDelimiter = options.StackDelimiter
+	} else {
+		store.stackDelimiter = aws.String("-")
+	}
+
+	store.readRoleArn = options.ReadRoleArn
+	store.writeRoleArn = options.WriteRoleArn
+
+	return store, nil
+}
+
+func (s *SecretsManagerStore) getKey(stack string, component string, key string) (string, error) {
+	if s.stackDelimiter == nil {
+		return "", ErrStackDelimiterNotSet
+	}
+
+	return getKey(s.prefix, *s.stackDelimiter, stack, component, key, "/")
+}
+
+// clientForRole returns a Secrets Manager client using the credentials of the specified IAM role.
+// The default client is returned if no role is specified.
+func (s *SecretsManagerStore) clientForRole(ctx context.Context, roleArn *string) (SecretsManagerClient, error) {
+	if roleArn == nil {
+		return s.client, nil
+	}
+
+	stsClient := s.newSTSClient(*s.awsConfig)
+	result, err := stsClient.AssumeRole(ctx, &sts.AssumeRoleInput{
+		RoleArn:         roleArn,
+		RoleSessionName: aws.String("atmos-secrets-manager-session"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to assume role %s: %w", *roleArn, err)
+	}
+
+	cfg := s.awsConfig.Copy()
+	cfg.Credentials = credentials.NewStaticCredentialsProvider(
+		*result.Credentials.AccessKeyId,
+		*result.Credentials.SecretAccessKey,
+		*result.Credentials.SessionToken,
+	)
+
+	return s.newSecretsManagerClient(cfg), nil
+}
+
+// createSecret creates a new secret with the configured KMS key and tags.
+func (s *SecretsManagerStore) createSecret(ctx context.Context, client SecretsManagerClient, secretName string, value string) error {
+	input := &secretsmanager.CreateSecretInput{
+		Name:         aws.String(secretName),
+		SecretString: aws.String(value),
+		KmsKeyId:     s.kmsKeyID,
+	}
+
+	for k, v := range s.tags {
+		input.Tags = append(input.Tags, smtypes.Tag{Key: aws.String(k), Value: aws.String(v)})
+	}
+
+	if _, err := client.CreateSecret(ctx, input); err != nil {
+		return fmt.Errorf(errWrapFormatWithID, ErrCreateSecret, secretName, err)
+	}
+
+	return nil
+}
+
+// Set stores a key-value pair in AWS Secrets Manager. The secret is created if it does not exist yet.
+func (s *SecretsManagerStore) Set(stack string, component string, key string, value interface{}) error {
+	if stack == "" {
+		return ErrEmptyStack
+	}
+	if component == "" {
+		return ErrEmptyComponent
+	}
+	if key == "" {
+		return ErrEmptyKey
+	}
+
+	ctx := context.TODO()
+
+	// Convert value to JSON string
+	jsonValue, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf(errWrapFormat, ErrSerializeJSON, err)
+	}
+	strValue := string(jsonValue)
+
+	secretName, err := s.getKey(stack, component, key)
+	if err != nil {
+		return fmt.Errorf(errWrapFormat, ErrGetKey, err)
+	}
+
+	client, err := s.clientForRole(ctx, s.writeRoleArn)
+	if err != nil {
+		return fmt.Errorf("failed to assume write role: %w", err)
+	}
+
+	_, err = client.PutSecretValue(ctx, &secretsmanager.PutSecretValueInput{
+		SecretId:     aws.String(secretName),
+		SecretString: aws.String(strValue),
+	})
+	if err == nil {
+		return nil
+	}
+
+	var notFound *smtypes.ResourceNotFoundException
+	if !errors.As(err, &notFound) {
+		return fmt.Errorf(errWrapFormatWithID, ErrSetParameter, secretName, err)
+	}
+
+	return s.createSecret(ctx, client, secretName, strValue)
+}
+
+// Get retrieves a value by key from AWS Secrets Manager.
+func (s *SecretsManagerStore) Get(stack string, component string, key string) (interface{}, error) {
+	if stack == "" {
+		return nil, ErrEmptyStack
+	}
+	if component == "" {
+		return nil, ErrEmptyComponent
+	}
+	if key == "" {
+		return nil, ErrEmptyKey
+	}
+
+	ctx := context.TODO()
+
+	secretName, err := s.getKey(stack, component, key)
+	if err != nil {
+		return nil, fmt.Errorf(errWrapFormat, ErrGetKey, err)
+	}
+
+	client, err := s.clientForRole(ctx, s.readRoleArn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to assume read role: %w", err)
+	}
+
+	output, err := client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(secretName),
+	})
+	if err != nil {
+		var notFound *smtypes.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return nil, fmt.Errorf(errWrapFormatWithID, ErrResourceNotFound, secretName, err)
+		}
+		return nil, fmt.Errorf(errWrapFormatWithID, ErrAccessSecret, secretName, err)
+	}
+
+	if output.SecretString == nil {
+		return string(output.SecretBinary), nil
+	}
+
+	// Try to unmarshal the value as JSON
+	var result interface{}
+	if jsonErr := json.Unmarshal([]byte(*output.SecretString), &result); jsonErr != nil {
+		// If it's not valid JSON, return the raw string value
+		return *output.SecretString, nil
+	}
+
+	return result, nil
+}
+
+// Delete removes a key from AWS Secrets Manager. The secret is deleted without a recovery window, so that the same
+// key can be written again right away.
+func (s *SecretsManagerStore) Delete(stack string, component string, key string) error {
+	if err := validateKeyArgs(stack, component, key); err != nil {
+		return err
+	}
+
+	ctx := context.TODO()
+
+	secretName, err := s.getKey(stack, component, key)
+	if err != nil {
+		return fmt.Errorf(errWrapFormat, ErrGetKey, err)
+	}
+
+	client, err := s.clientForRole(ctx, s.writeRoleArn)
+	if err != nil {
+		return fmt.Errorf("failed to assume write role: %w", err)
+	}
+
+	_, err = client.DeleteSecret(ctx, &secretsmanager.DeleteSecretInput{
+		SecretId:                   aws.String(secretName),
+		ForceDeleteWithoutRecovery: aws.Bool(true),
+	})
+	if err != nil {
+		var notFound *smtypes.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return fmt.Errorf(errWrapFormatWithID, ErrResourceNotFound, secretName, err)
+		}
+		return fmt.Errorf(errWrapFormatWithID, ErrDeleteParameter, secretName, err)
+	}
+
+	return nil
+}
+
+// List returns the keys stored in AWS Secrets Manager for a stack and component.
+func (s *SecretsManagerStore) List(stack string, component string) ([]string, error) {
+	if err := validateStackAndComponent(stack, component); err != nil {
+		return nil, err
+	}
+
+	ctx := context.TODO()
+
+	if s.stackDelimiter == nil {
+		return nil, ErrStackDelimiterNotSet
+	}
+
+	namePrefix, err := getKeyPrefix(s.prefix, *s.stackDelimiter, stack, component, "/")
+	if err != nil {
+		return nil, fmt.Errorf(errWrapFormat, ErrGetKey, err)
+	}
+
+	client, err := s.clientForRole(ctx, s.readRoleArn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to assume read role: %w", err)
+	}
+
+	keys := []string{}
+	paginator := secretsmanager.NewListSecretsPaginator(client, &secretsmanager.ListSecretsInput{
+		Filters: []smtypes.Filter{{Key: smtypes.FilterNameStringTypeName, Values: []string{namePrefix}}},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf(errWrapFormatWithID, ErrListParameters, namePrefix, err)
+		}
+		for _, secret := range page.SecretList {
+			// The name filter matches on words of the name, so only keep the secrets under the prefix.
+			name := aws.ToString(secret.Name)
+			if strings.HasPrefix(name, namePrefix) {
+				keys = append(keys, strings.TrimPrefix(name, namePrefix))
+			}
+		}
+	}
+
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// Exists checks if a key exists in AWS Secrets Manager.
+func (s *SecretsManagerStore) Exists(stack string, component string, key string) (bool, error) {
+	if err := validateKeyArgs(stack, component, key); err != nil {
+		return false, err
+	}
+
+	ctx := context.TODO()
+
+	secretName, err := s.getKey(stack, component, key)
+	if err != nil {
+		return false, fmt.Errorf(errWrapFormat, ErrGetKey, err)
+	}
+
+	client, err := s.clientForRole(ctx, s.readRoleArn)
+	if err != nil {
+		return false, fmt.Errorf("failed to assume read role: %w", err)
+	}
+
+	_, err = client.DescribeSecret(ctx, &secretsmanager.DescribeSecretInput{
+		SecretId: aws.String(secretName),
+	})
+	if err != nil {
+		var notFound *smtypes.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf(errWrapFormatWithID, ErrAccessSecret, secretName, err)
+	}
+
+	return true, nil
+}
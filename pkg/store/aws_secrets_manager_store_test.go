@@ -0,0 +1,328 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	smtypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	ststypes "github.com/aws/aws-sdk-go-v2/service/sts/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSecretsManagerClient is a mock implementation of the SecretsManagerClient interface.
+type MockSecretsManagerClient struct {
+	mock.Mock
+}
+
+func (m *MockSecretsManagerClient) CreateSecret(ctx context.Context, params *secretsmanager.CreateSecretInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.CreateSecretOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*secretsmanager.CreateSecretOutput), args.Error(1)
+}
+
+func (m *MockSecretsManagerClient) PutSecretValue(ctx context.Context, params *secretsmanager.PutSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.PutSecretValueOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*secretsmanager.PutSecretValueOutput), args.Error(1)
+}
+
+func (m *MockSecretsManagerClient) GetSecretValue(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*secretsmanager.GetSecretValueOutput), args.Error(1)
+}
+
+func (m *MockSecretsManagerClient) DeleteSecret(ctx context.Context, params *secretsmanager.DeleteSecretInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.DeleteSecretOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*secretsmanager.DeleteSecretOutput), args.Error(1)
+}
+
+func (m *MockSecretsManagerClient) DescribeSecret(ctx context.Context, params *secretsmanager.DescribeSecretInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.DescribeSecretOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*secretsmanager.DescribeSecretOutput), args.Error(1)
+}
+
+func (m *MockSecretsManagerClient) ListSecrets(ctx context.Context, params *secretsmanager.ListSecretsInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.ListSecretsOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*secretsmanager.ListSecretsOutput), args.Error(1)
+}
+
+func newTestSecretsManagerStore(client *MockSecretsManagerClient, assumed *MockSecretsManagerClient, stsClient *MockSTSClient) *SecretsManagerStore {
+	return &SecretsManagerStore{
+		client:         client,
+		prefix:         "/atmos",
+		stackDelimiter: aws.String("-"),
+		awsConfig:      &aws.Config{Region: "us-east-1"},
+		newSTSClient: func(cfg aws.Config) STSClient {
+			return stsClient
+		},
+		newSecretsManagerClient: func(cfg aws.Config) SecretsManagerClient {
+			return assumed
+		},
+	}
+}
+
+func TestSecretsManagerStore_Set(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     interface{}
+		kmsKeyID  *string
+		tags      map[string]string
+		mockSetup func(*MockSecretsManagerClient)
+		wantErr   error
+	}{
+		{
+			name:  "update_existing_secret",
+			value: map[string]interface{}{"a": 1},
+			mockSetup: func(m *MockSecretsManagerClient) {
+				m.On("PutSecretValue", mock.Anything, &secretsmanager.PutSecretValueInput{
+					SecretId:     aws.String("/atmos/dev/ue2/app/config"),
+					SecretString: aws.String(`{"a":1}`),
+				}).Return(&secretsmanager.PutSecretValueOutput{}, nil)
+			},
+		},
+		{
+			name:     "create_missing_secret_with_kms_and_tags",
+			value:    "value",
+			kmsKeyID: aws.String("alias/atmos"),
+			tags:     map[string]string{"owner": "platform"},
+			mockSetup: func(m *MockSecretsManagerClient) {
+				m.On("PutSecretValue", mock.Anything, mock.Anything).
+					Return(nil, &smtypes.ResourceNotFoundException{Message: aws.String("not found")})
+				m.On("CreateSecret", mock.Anything, &secretsmanager.CreateSecretInput{
+					Name:         aws.String("/atmos/dev/ue2/app/config"),
+					SecretString: aws.String(`"value"`),
+					KmsKeyId:     aws.String("alias/atmos"),
+					Tags:         []smtypes.Tag{{Key: aws.String("owner"), Value: aws.String("platform")}},
+				}).Return(&secretsmanager.CreateSecretOutput{}, nil)
+			},
+		},
+		{
+			name:  "put_error",
+			value: "value",
+			mockSetup: func(m *MockSecretsManagerClient) {
+				m.On("PutSecretValue", mock.Anything, mock.Anything).Return(nil, errors.New("aws error"))
+			},
+			wantErr: ErrSetParameter,
+		},
+		{
+			name:  "create_error",
+			value: "value",
+			mockSetup: func(m *MockSecretsManagerClient) {
+				m.On("PutSecretValue", mock.Anything, mock.Anything).Return(nil, &smtypes.ResourceNotFoundException{})
+				m.On("CreateSecret", mock.Anything, mock.Anything).Return(nil, errors.New("aws error"))
+			},
+			wantErr: ErrCreateSecret,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := new(MockSecretsManagerClient)
+			tt.mockSetup(client)
+			store := newTestSecretsManagerStore(client, nil, nil)
+			store.kmsKeyID = tt.kmsKeyID
+			store.tags = tt.tags
+
+			err := store.Set("dev-ue2", "app", "config", tt.value)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			client.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSecretsManagerStore_Set_Validation(t *testing.T) {
+	store := newTestSecretsManagerStore(new(MockSecretsManagerClient), nil, nil)
+
+	assert.ErrorIs(t, store.Set("", "app", "key", "v"), ErrEmptyStack)
+	assert.ErrorIs(t, store.Set("dev", "", "key", "v"), ErrEmptyComponent)
+	assert.ErrorIs(t, store.Set("dev", "app", "", "v"), ErrEmptyKey)
+}
+
+func TestSecretsManagerStore_Get(t *testing.T) {
+	tests := []struct {
+		name      string
+		mockSetup func(*MockSecretsManagerClient)
+		want      interface{}
+		wantErr   error
+	}{
+		{
+			name: "json_value",
+			mockSetup: func(m *MockSecretsManagerClient) {
+				m.On("GetSecretValue", mock.Anything, &secretsmanager.GetSecretValueInput{
+					SecretId: aws.String("/atmos/dev/ue2/app/config"),
+				}).Return(&secretsmanager.GetSecretValueOutput{SecretString: aws.String(`{"a":"b"}`)}, nil)
+			},
+			want: map[string]interface{}{"a": "b"},
+		},
+		{
+			name: "raw_string_value",
+			mockSetup: func(m *MockSecretsManagerClient) {
+				m.On("GetSecretValue", mock.Anything, mock.Anything).
+					Return(&secretsmanager.GetSecretValueOutput{SecretString: aws.String("plain")}, nil)
+			},
+			want: "plain",
+		},
+		{
+			name: "not_found",
+			mockSetup: func(m *MockSecretsManagerClient) {
+				m.On("GetSecretValue", mock.Anything, mock.Anything).Return(nil, &smtypes.ResourceNotFoundException{})
+			},
+			wantErr: ErrResourceNotFound,
+		},
+		{
+			name: "aws_error",
+			mockSetup: func(m *MockSecretsManagerClient) {
+				m.On("GetSecretValue", mock.Anything, mock.Anything).Return(nil, errors.New("aws error"))
+			},
+			wantErr: ErrAccessSecret,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := new(MockSecretsManagerClient)
+			tt.mockSetup(client)
+			store := newTestSecretsManagerStore(client, nil, nil)
+
+			got, err := store.Get("dev-ue2", "app", "config")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSecretsManagerStore_Get_WithReadRole(t *testing.T) {
+	client := new(MockSecretsManagerClient)
+	assumed := new(MockSecretsManagerClient)
+	stsClient := new(MockSTSClient)
+
+	stsClient.On("AssumeRole", mock.Anything, &sts.AssumeRoleInput{
+		RoleArn:         aws.String("arn:aws:iam::123456789012:role/read"),
+		RoleSessionName: aws.String("atmos-secrets-manager-session"),
+	}).Return(&sts.AssumeRoleOutput{
+		Credentials: &ststypes.Credentials{
+			AccessKeyId:     aws.String("AKID"),
+			SecretAccessKey: aws.String("SECRET"),
+			SessionToken:    aws.String("TOKEN"),
+		},
+	}, nil)
+	assumed.On("GetSecretValue", mock.Anything, mock.Anything).
+		Return(&secretsmanager.GetSecretValueOutput{SecretString: aws.String(`42`)}, nil)
+
+	store := newTestSecretsManagerStore(client, assumed, stsClient)
+	store.readRoleArn = aws.String("arn:aws:iam::123456789012:role/read")
+
+	got, err := store.Get("dev", "app", "config")
+	assert.NoError(t, err)
+	assert.Equal(t, float64(42), got)
+	client.AssertNotCalled(t, "GetSecretValue", mock.Anything, mock.Anything)
+	stsClient.AssertExpectations(t)
+}
+
+func TestNewSecretsManagerStore(t *testing.T) {
+	_, err := NewSecretsManagerStore(SecretsManagerStoreOptions{})
+	assert.ErrorIs(t, err, ErrRegionRequired)
+
+	s, err := NewSecretsManagerStore(SecretsManagerStoreOptions{
+		Region:   "us-east-1",
+		Endpoint: aws.String("http://localhost:4566"),
+	})
+	assert.NoError(t, err)
+
+	smStore, ok := s.(*SecretsManagerStore)
+	assert.True(t, ok)
+	assert.Equal(t, "-", *smStore.stackDelimiter)
+
+	client, ok := smStore.client.(*secretsmanager.Client)
+	assert.True(t, ok)
+	assert.Equal(t, "http://localhost:4566", *client.Options().BaseEndpoint)
+}
+
+func TestSecretsManagerStore_Delete(t *testing.T) {
+	client := new(MockSecretsManagerClient)
+	client.On("DeleteSecret", mock.Anything, &secretsmanager.DeleteSecretInput{
+		SecretId:                   aws.String("/atmos/dev/app/config"),
+		ForceDeleteWithoutRecovery: aws.Bool(true),
+	}).Return(&secretsmanager.DeleteSecretOutput{}, nil)
+	client.On("DeleteSecret", mock.Anything, mock.Anything).Return(nil, &smtypes.ResourceNotFoundException{})
+	store := newTestSecretsManagerStore(client, nil, nil)
+
+	assert.NoError(t, store.Delete("dev", "app", "config"))
+	assert.ErrorIs(t, store.Delete("dev", "app", "missing"), ErrResourceNotFound)
+	assert.ErrorIs(t, store.Delete("dev", "app", ""), ErrEmptyKey)
+}
+
+func TestSecretsManagerStore_List(t *testing.T) {
+	client := new(MockSecretsManagerClient)
+	client.On("ListSecrets", mock.Anything, mock.MatchedBy(func(input *secretsmanager.ListSecretsInput) bool {
+		return input.NextToken == nil && input.Filters[0].Values[0] == "/atmos/dev/app/"
+	})).Return(&secretsmanager.ListSecretsOutput{
+		SecretList: []smtypes.SecretListEntry{
+			{Name: aws.String("/atmos/dev/app/b")},
+			{Name: aws.String("/atmos/dev/other/c")},
+		},
+		NextToken: aws.String("page2"),
+	}, nil)
+	client.On("ListSecrets", mock.Anything, mock.MatchedBy(func(input *secretsmanager.ListSecretsInput) bool {
+		return aws.ToString(input.NextToken) == "page2"
+	})).Return(&secretsmanager.ListSecretsOutput{
+		SecretList: []smtypes.SecretListEntry{{Name: aws.String("/atmos/dev/app/a")}},
+	}, nil)
+	store := newTestSecretsManagerStore(client, nil, nil)
+
+	keys, err := store.List("dev", "app")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, keys)
+}
+
+func TestSecretsManagerStore_Exists(t *testing.T) {
+	client := new(MockSecretsManagerClient)
+	client.On("DescribeSecret", mock.Anything, &secretsmanager.DescribeSecretInput{
+		SecretId: aws.String("/atmos/dev/app/config"),
+	}).Return(&secretsmanager.DescribeSecretOutput{}, nil)
+	client.On("DescribeSecret", mock.Anything, &secretsmanager.DescribeSecretInput{
+		SecretId: aws.String("/atmos/dev/app/missing"),
+	}).Return(nil, &smtypes.ResourceNotFoundException{})
+	client.On("DescribeSecret", mock.Anything, mock.Anything).Return(nil, errors.New("aws error"))
+	store := newTestSecretsManagerStore(client, nil, nil)
+
+	exists, err := store.Exists("dev", "app", "config")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = store.Exists("dev", "app", "missing")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	_, err = store.Exists("dev", "app", "broken")
+	assert.ErrorIs(t, err, ErrAccessSecret)
+}
@@ -3,7 +3,10 @@ package store
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
@@ -46,6 +49,8 @@ var _ Store = (*SSMStore)(nil)
 type SSMClient interface {
 	PutParameter(ctx context.Context, params *ssm.PutParameterInput, optFns ...func(*ssm.Options)) (*ssm.PutParameterOutput, error)
 	GetParameter(ctx context.Context, params *ssm.GetParameterInput, optFns ...func(*ssm.Options)) (*ssm.GetParameterOutput, error)
+	DeleteParameter(ctx context.Context, params *ssm.DeleteParameterInput, optFns ...func(*ssm.Options)) (*ssm.DeleteParameterOutput, error)
+	GetParametersByPath(ctx context.Context, params *ssm.GetParametersByPathInput, optFns ...func(*ssm.Options)) (*ssm.GetParametersByPathOutput, error)
 }
 
 // STSClient interface allows us to mock the AWS STS client.
@@ -142,6 +147,25 @@ func (s *SSMStore) assumeRole(ctx context.Context, roleArn *string) (*aws.Config
 	return &cfg, nil
 }
 
+// clientForRole returns an SSM client using the credentials of the specified IAM role.
+// The default client is returned if no role is specified.
+func (s *SSMStore) clientForRole(ctx context.Context, roleArn *string) (SSMClient, error) {
+	if roleArn == nil {
+		return s.client, nil
+	}
+
+	cfg, err := s.assumeRole(ctx, roleArn)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create SSM client with assumed role
+	if s.newSSMClient != nil {
+		return s.newSSMClient(*cfg), nil
+	}
+	return ssm.NewFromConfig(*cfg), nil
+}
+
 // Set stores a key-value pair in AWS SSM Parameter Store.
 func (s *SSMStore) Set(stack string, component string, key string, value interface{}) error {
 	if stack == "" {
@@ -170,22 +194,11 @@ func (s *SSMStore) Set(stack string, component string, key string, value interfa
 	}
 
 	// Assume write role if specified
-	cfg, err := s.assumeRole(ctx, s.writeRoleArn)
+	client, err := s.clientForRole(ctx, s.writeRoleArn)
 	if err != nil {
 		return fmt.Errorf("failed to assume write role: %w", err)
 	}
 
-	// Use the same client if no role was assumed
-	client := s.client
-	if s.writeRoleArn != nil {
-		// Create SSM client with assumed role if applicable
-		if s.newSSMClient != nil {
-			client = s.newSSMClient(*cfg)
-		} else {
-			client = ssm.NewFromConfig(*cfg)
-		}
-	}
-
 	// Put the parameter in SSM Parameter Store
 	_, err = client.PutParameter(ctx, &ssm.PutParameterInput{
 		Name:      aws.String(paramName),
@@ -221,27 +234,20 @@ func (s *SSMStore) Get(stack string, component string, key string) (interface{},
 	}
 
 	// Assume read role if specified
-	cfg, err := s.assumeRole(ctx, s.readRoleArn)
+	client, err := s.clientForRole(ctx, s.readRoleArn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to assume read role: %w", err)
 	}
 
-	// Use the same client if no role was assumed
-	client := s.client
-	if s.readRoleArn != nil {
-		// Create SSM client with assumed role if applicable
-		if s.newSSMClient != nil {
-			client = s.newSSMClient(*cfg)
-		} else {
-			client = ssm.NewFromConfig(*cfg)
-		}
-	}
-
 	// Get the parameter from SSM Parameter Store
 	output, err := client.GetParameter(ctx, &ssm.GetParameterInput{
 		Name: aws.String(paramName),
 	})
 	if err != nil {
+		var notFound *types.ParameterNotFound
+		if errors.As(err, &notFound) {
+			return nil, fmt.Errorf(errWrapFormatWithID, ErrResourceNotFound, paramName, err)
+		}
 		return nil, fmt.Errorf(errWrapFormatWithID, ErrGetParameter, paramName, err)
 	}
 
@@ -255,3 +261,92 @@ func (s *SSMStore) Get(stack string, component string, key string) (interface{},
 
 	return result, nil
 }
+
+// Delete removes a key from AWS SSM Parameter Store.
+func (s *SSMStore) Delete(stack string, component string, key string) error {
+	if err := validateKeyArgs(stack, component, key); err != nil {
+		return err
+	}
+
+	ctx := context.TODO()
+
+	paramName, err := s.getKey(stack, component, key)
+	if err != nil {
+		return fmt.Errorf(errWrapFormat, ErrGetKey, err)
+	}
+
+	// Assume write role if specified
+	client, err := s.clientForRole(ctx, s.writeRoleArn)
+	if err != nil {
+		return fmt.Errorf("failed to assume write role: %w", err)
+	}
+
+	_, err = client.DeleteParameter(ctx, &ssm.DeleteParameterInput{
+		Name: aws.String(paramName),
+	})
+	if err != nil {
+		var notFound *types.ParameterNotFound
+		if errors.As(err, &notFound) {
+			return fmt.Errorf(errWrapFormatWithID, ErrResourceNotFound, paramName, err)
+		}
+		return fmt.Errorf(errWrapFormatWithID, ErrDeleteParameter, paramName, err)
+	}
+
+	return nil
+}
+
+// List returns the keys stored in AWS SSM Parameter Store for a stack and component.
+func (s *SSMStore) List(stack string, component string) ([]string, error) {
+	if err := validateStackAndComponent(stack, component); err != nil {
+		return nil, err
+	}
+
+	ctx := context.TODO()
+
+	if s.stackDelimiter == nil {
+		return nil, ErrStackDelimiterNotSet
+	}
+
+	pathPrefix, err := getKeyPrefix(s.prefix, *s.stackDelimiter, stack, component, "/")
+	if err != nil {
+		return nil, fmt.Errorf(errWrapFormat, ErrGetKey, err)
+	}
+
+	// Assume read role if specified
+	client, err := s.clientForRole(ctx, s.readRoleArn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to assume read role: %w", err)
+	}
+
+	keys := []string{}
+	paginator := ssm.NewGetParametersByPathPaginator(client, &ssm.GetParametersByPathInput{
+		Path:      aws.String(strings.TrimSuffix(pathPrefix, "/")),
+		Recursive: aws.Bool(true),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf(errWrapFormatWithID, ErrListParameters, pathPrefix, err)
+		}
+		for _, param := range page.Parameters {
+			keys = append(keys, strings.TrimPrefix(aws.ToString(param.Name), pathPrefix))
+		}
+	}
+
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// Exists checks if a key exists in AWS SSM Parameter Store.
+func (s *SSMStore) Exists(stack string, component string, key string) (bool, error) {
+	_, err := s.Get(stack, component, key)
+	if err == nil {
+		return true, nil
+	}
+
+	var notFound *types.ParameterNotFound
+	if errors.As(err, &notFound) {
+		return false, nil
+	}
+	return false, err
+}
@@ -37,6 +37,22 @@ func (m *MockSSMClient) GetParameter(ctx context.Context, params *ssm.GetParamet
 	return args.Get(0).(*ssm.GetParameterOutput), args.Error(1)
 }
 
+func (m *MockSSMClient) DeleteParameter(ctx context.Context, params *ssm.DeleteParameterInput, optFns ...func(*ssm.Options)) (*ssm.DeleteParameterOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ssm.DeleteParameterOutput), args.Error(1)
+}
+
+func (m *MockSSMClient) GetParametersByPath(ctx context.Context, params *ssm.GetParametersByPathInput, optFns ...func(*ssm.Options)) (*ssm.GetParametersByPathOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ssm.GetParametersByPathOutput), args.Error(1)
+}
+
 // MockSTSClient is a mock implementation of the STSClient interface.
 type MockSTSClient struct {
 	mock.Mock
@@ -677,3 +693,84 @@ func TestSSMStore_getKey(t *testing.T) {
 		})
 	}
 }
+
+func TestSSMStore_Delete(t *testing.T) {
+	stackDelimiter := "-"
+	mockSSM := new(MockSSMClient)
+	mockSSM.On("DeleteParameter", mock.Anything, &ssm.DeleteParameterInput{
+		Name: aws.String("/test-prefix/dev/usw2/app/config-key"),
+	}).Return(&ssm.DeleteParameterOutput{}, nil)
+	mockSSM.On("DeleteParameter", mock.Anything, &ssm.DeleteParameterInput{
+		Name: aws.String("/test-prefix/dev/usw2/app/missing"),
+	}).Return(nil, &types.ParameterNotFound{})
+	mockSSM.On("DeleteParameter", mock.Anything, mock.Anything).Return(nil, errors.New("aws error"))
+
+	store := &SSMStore{
+		client:         mockSSM,
+		prefix:         "/test-prefix",
+		stackDelimiter: &stackDelimiter,
+		awsConfig:      &aws.Config{Region: "us-west-2"},
+	}
+
+	assert.NoError(t, store.Delete("dev-usw2", "app", "config-key"))
+	assert.ErrorIs(t, store.Delete("dev-usw2", "app", "missing"), ErrResourceNotFound)
+	assert.ErrorIs(t, store.Delete("dev-usw2", "app", "broken"), ErrDeleteParameter)
+	assert.ErrorIs(t, store.Delete("", "app", "config-key"), ErrEmptyStack)
+}
+
+func TestSSMStore_List(t *testing.T) {
+	stackDelimiter := "-"
+	mockSSM := new(MockSSMClient)
+	mockSSM.On("GetParametersByPath", mock.Anything, mock.MatchedBy(func(input *ssm.GetParametersByPathInput) bool {
+		return *input.Path == "/test-prefix/dev/usw2/app" && *input.Recursive && input.NextToken == nil
+	})).Return(&ssm.GetParametersByPathOutput{
+		Parameters: []types.Parameter{{Name: aws.String("/test-prefix/dev/usw2/app/vpc_id")}},
+		NextToken:  aws.String("next"),
+	}, nil)
+	mockSSM.On("GetParametersByPath", mock.Anything, mock.MatchedBy(func(input *ssm.GetParametersByPathInput) bool {
+		return aws.ToString(input.NextToken) == "next"
+	})).Return(&ssm.GetParametersByPathOutput{
+		Parameters: []types.Parameter{{Name: aws.String("/test-prefix/dev/usw2/app/cidr")}},
+	}, nil)
+
+	store := &SSMStore{
+		client:         mockSSM,
+		prefix:         "/test-prefix",
+		stackDelimiter: &stackDelimiter,
+		awsConfig:      &aws.Config{Region: "us-west-2"},
+	}
+
+	keys, err := store.List("dev-usw2", "app")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cidr", "vpc_id"}, keys)
+}
+
+func TestSSMStore_Exists(t *testing.T) {
+	stackDelimiter := "-"
+	mockSSM := new(MockSSMClient)
+	mockSSM.On("GetParameter", mock.Anything, &ssm.GetParameterInput{
+		Name: aws.String("/test-prefix/dev/app/present"),
+	}).Return(&ssm.GetParameterOutput{Parameter: &types.Parameter{Value: aws.String(`"v"`)}}, nil)
+	mockSSM.On("GetParameter", mock.Anything, &ssm.GetParameterInput{
+		Name: aws.String("/test-prefix/dev/app/missing"),
+	}).Return(nil, &types.ParameterNotFound{})
+	mockSSM.On("GetParameter", mock.Anything, mock.Anything).Return(nil, errors.New("aws error"))
+
+	store := &SSMStore{
+		client:         mockSSM,
+		prefix:         "/test-prefix",
+		stackDelimiter: &stackDelimiter,
+		awsConfig:      &aws.Config{Region: "us-west-2"},
+	}
+
+	exists, err := store.Exists("dev", "app", "present")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = store.Exists("dev", "app", "missing")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	_, err = store.Exists("dev", "app", "broken")
+	assert.ErrorIs(t, err, ErrGetParameter)
+}
@@ -6,9 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
 )
@@ -27,6 +29,8 @@ const (
 type AzureKeyVaultClient interface {
 	SetSecret(ctx context.Context, name string, parameters azsecrets.SetSecretParameters, options *azsecrets.SetSecretOptions) (azsecrets.SetSecretResponse, error)
 	GetSecret(ctx context.Context, name string, version string, options *azsecrets.GetSecretOptions) (azsecrets.GetSecretResponse, error)
+	DeleteSecret(ctx context.Context, name string, options *azsecrets.DeleteSecretOptions) (azsecrets.DeleteSecretResponse, error)
+	NewListSecretPropertiesPager(options *azsecrets.ListSecretPropertiesOptions) *runtime.Pager[azsecrets.ListSecretPropertiesResponse]
 }
 
 // AzureKeyVaultStore is an implementation of the Store interface for Azure Key Vault.
@@ -192,3 +196,85 @@ func (s *AzureKeyVaultStore) Get(stack string, component string, key string) (in
 	}
 	return result, nil
 }
+
+// Delete removes a key from Azure Key Vault. Depending on the vault configuration, the secret is soft-deleted and
+// must be purged or recovered before a secret with the same name can be created again.
+func (s *AzureKeyVaultStore) Delete(stack string, component string, key string) error {
+	if err := validateKeyArgs(stack, component, key); err != nil {
+		return err
+	}
+
+	secretName, err := s.getKey(stack, component, key)
+	if err != nil {
+		return fmt.Errorf(errWrapFormat, ErrGetKey, err)
+	}
+
+	_, err = s.client.DeleteSecret(context.Background(), secretName, nil)
+	if err != nil {
+		var respErr *azcore.ResponseError
+		if errors.As(err, &respErr) {
+			switch respErr.StatusCode {
+			case statusCodeNotFound:
+				return fmt.Errorf(errWrapFormatWithID, ErrResourceNotFound, secretName, err)
+			case statusCodeForbidden:
+				return fmt.Errorf(errWrapFormatWithID, ErrPermissionDenied, fmt.Sprintf("secret %s", secretName), err)
+			}
+		}
+		return fmt.Errorf(errWrapFormat, ErrDeleteParameter, err)
+	}
+
+	return nil
+}
+
+// List returns the keys stored in Azure Key Vault for a stack and component.
+// Since secret names are normalized, the returned keys are the normalized key names.
+func (s *AzureKeyVaultStore) List(stack string, component string) ([]string, error) {
+	if err := validateStackAndComponent(stack, component); err != nil {
+		return nil, err
+	}
+
+	if s.stackDelimiter == nil {
+		return nil, ErrStackDelimiterNotSet
+	}
+
+	basePrefix, err := getKeyPrefix(s.prefix, *s.stackDelimiter, stack, component, AzureKeyVaultHyphen)
+	if err != nil {
+		return nil, fmt.Errorf(errWrapFormat, ErrGetKey, err)
+	}
+	namePrefix := s.normalizeSecretName(basePrefix) + AzureKeyVaultHyphen
+
+	keys := []string{}
+	pager := s.client.NewListSecretPropertiesPager(nil)
+	for pager.More() {
+		page, err := pager.NextPage(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf(errWrapFormat, ErrListParameters, err)
+		}
+
+		for _, secret := range page.Value {
+			if secret == nil || secret.ID == nil {
+				continue
+			}
+			name := secret.ID.Name()
+			if strings.HasPrefix(name, namePrefix) {
+				keys = append(keys, strings.TrimPrefix(name, namePrefix))
+			}
+		}
+	}
+
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// Exists checks if a key exists in Azure Key Vault.
+func (s *AzureKeyVaultStore) Exists(stack string, component string, key string) (bool, error) {
+	_, err := s.Get(stack, component, key)
+	if err == nil {
+		return true, nil
+	}
+
+	if errors.Is(err, ErrResourceNotFound) {
+		return false, nil
+	}
+	return false, err
+}
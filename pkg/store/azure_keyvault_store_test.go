@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
 	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
 	"github.com/stretchr/testify/assert"
 )
@@ -12,6 +13,8 @@ import (
 type mockClient struct {
 	getSecretFunc func(ctx context.Context, name string, version string, options *azsecrets.GetSecretOptions) (azsecrets.GetSecretResponse, error)
 	setSecretFunc func(ctx context.Context, name string, parameters azsecrets.SetSecretParameters, options *azsecrets.SetSecretOptions) (azsecrets.SetSecretResponse, error)
+	deleteFunc    func(ctx context.Context, name string, options *azsecrets.DeleteSecretOptions) (azsecrets.DeleteSecretResponse, error)
+	secretIDs     []string
 }
 
 func (m *mockClient) GetSecret(ctx context.Context, name string, version string, options *azsecrets.GetSecretOptions) (azsecrets.GetSecretResponse, error) {
@@ -22,6 +25,26 @@ func (m *mockClient) SetSecret(ctx context.Context, name string, parameters azse
 	return m.setSecretFunc(ctx, name, parameters, options)
 }
 
+func (m *mockClient) DeleteSecret(ctx context.Context, name string, options *azsecrets.DeleteSecretOptions) (azsecrets.DeleteSecretResponse, error) {
+	return m.deleteFunc(ctx, name, options)
+}
+
+func (m *mockClient) NewListSecretPropertiesPager(options *azsecrets.ListSecretPropertiesOptions) *runtime.Pager[azsecrets.ListSecretPropertiesResponse] {
+	return runtime.NewPager(runtime.PagingHandler[azsecrets.ListSecretPropertiesResponse]{
+		More: func(page azsecrets.ListSecretPropertiesResponse) bool {
+			return false
+		},
+		Fetcher: func(ctx context.Context, page *azsecrets.ListSecretPropertiesResponse) (azsecrets.ListSecretPropertiesResponse, error) {
+			var resp azsecrets.ListSecretPropertiesResponse
+			for _, id := range m.secretIDs {
+				secretID := azsecrets.ID(id)
+				resp.Value = append(resp.Value, &azsecrets.SecretProperties{ID: &secretID})
+			}
+			return resp, nil
+		},
+	})
+}
+
 func TestAzureKeyVaultStore_Set(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -276,3 +299,58 @@ func TestAzureKeyVaultStore_normalizeSecretName(t *testing.T) {
 func stringPtr(s string) *string {
 	return &s
 }
+
+func TestAzureKeyVaultStore_Delete(t *testing.T) {
+	client := &mockClient{
+		deleteFunc: func(ctx context.Context, name string, options *azsecrets.DeleteSecretOptions) (azsecrets.DeleteSecretResponse, error) {
+			if name == "dev-app-missing" {
+				return azsecrets.DeleteSecretResponse{}, &azcore.ResponseError{StatusCode: statusCodeNotFound}
+			}
+			return azsecrets.DeleteSecretResponse{}, nil
+		},
+	}
+	store := &AzureKeyVaultStore{
+		client:         client,
+		vaultURL:       "https://test.vault.azure.net",
+		stackDelimiter: stringPtr("-"),
+	}
+
+	assert.NoError(t, store.Delete("dev", "app", "secret"))
+	assert.ErrorIs(t, store.Delete("dev", "app", "missing"), ErrResourceNotFound)
+	assert.ErrorIs(t, store.Delete("dev", "app", ""), ErrEmptyKey)
+}
+
+func TestAzureKeyVaultStore_ListAndExists(t *testing.T) {
+	client := &mockClient{
+		secretIDs: []string{
+			"https://test.vault.azure.net/secrets/atmos-dev-app-vpc-id",
+			"https://test.vault.azure.net/secrets/atmos-dev-app-cidr",
+			"https://test.vault.azure.net/secrets/atmos-dev-other-cidr",
+		},
+		getSecretFunc: func(ctx context.Context, name string, version string, options *azsecrets.GetSecretOptions) (azsecrets.GetSecretResponse, error) {
+			if name == "atmos-dev-app-cidr" {
+				value := `"10.0.0.0/16"`
+				return azsecrets.GetSecretResponse{Secret: azsecrets.Secret{Value: &value}}, nil
+			}
+			return azsecrets.GetSecretResponse{}, &azcore.ResponseError{StatusCode: statusCodeNotFound}
+		},
+	}
+	store := &AzureKeyVaultStore{
+		client:         client,
+		vaultURL:       "https://test.vault.azure.net",
+		prefix:         "atmos",
+		stackDelimiter: stringPtr("-"),
+	}
+
+	keys, err := store.List("dev", "app")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cidr", "vpc-id"}, keys)
+
+	exists, err := store.Exists("dev", "app", "cidr")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = store.Exists("dev", "app", "missing")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
@@ -0,0 +1,352 @@
+package store
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/charmbracelet/log"
+)
+
+const (
+	// CacheStorageMemory keeps cached values in memory for the lifetime of the process.
+	CacheStorageMemory = "memory"
+	// CacheStorageDisk persists cached values on disk so that they are shared between Atmos invocations.
+	CacheStorageDisk = "disk"
+
+	defaultCacheTTL = 5 * time.Minute
+)
+
+// CacheConfig configures the read-through cache of a store.
+type CacheConfig struct {
+	// TTL is how long values read from the store are cached, e.g. `30s` or `5m`. Defaults to 5 minutes.
+	TTL string `yaml:"ttl,omitempty" json:"ttl,omitempty" mapstructure:"ttl"`
+	// NegativeTTL is how long missing keys are cached. Missing keys are not cached if it's not set.
+	NegativeTTL string `yaml:"negative_ttl,omitempty" json:"negative_ttl,omitempty" mapstructure:"negative_ttl"`
+	// Storage is either `memory` (the default) or `disk`.
+	Storage string `yaml:"storage,omitempty" json:"storage,omitempty" mapstructure:"storage"`
+	// Path is the directory of the disk cache. Defaults to `$XDG_CACHE_HOME/atmos/stores/<store name>`.
+	Path string `yaml:"path,omitempty" json:"path,omitempty" mapstructure:"path"`
+	// AllowPlaintext allows caching the values of the secret backends on disk without encryption.
+	AllowPlaintext bool `yaml:"allow_plaintext,omitempty" json:"allow_plaintext,omitempty" mapstructure:"allow_plaintext"`
+}
+
+// cacheEntry is a value, or the absence of a value, cached for a key.
+type cacheEntry struct {
+	Value     interface{} `json:"value,omitempty"`
+	NotFound  bool        `json:"not_found,omitempty"`
+	ExpiresAt time.Time   `json:"expires_at"`
+}
+
+// storeCache is the storage of a CachedStore.
+type storeCache interface {
+	get(key string) (cacheEntry, bool)
+	set(key string, entry cacheEntry) error
+	delete(key string) error
+}
+
+// CachedStore is a read-through cache around another store.
+type CachedStore struct {
+	name string
+	// backend identifies the backend configuration of the store, so that stores with the same name don't share cache entries
+	backend     string
+	store       Store
+	cache       storeCache
+	ttl         time.Duration
+	negativeTTL time.Duration
+	refresh     bool
+	now         func() time.Time
+}
+
+// Ensure CachedStore implements the store.Store interface.
+var _ Store = (*CachedStore)(nil)
+
+// memoryCaches holds the memory caches by store name and backend, so that they survive rebuilding the store registry.
+var memoryCaches sync.Map
+
+// prunedCacheDirs holds the disk cache directories whose expired entries were removed by this process.
+var prunedCacheDirs sync.Map
+
+// NewCachedStore wraps a store with a read-through cache. The backend identifies the backend configuration of the store
+// (see BackendID). If refresh is set, reads always go to the wrapped store, and the values read from it replace the cached ones.
+func NewCachedStore(name string, backend string, store Store, config CacheConfig, refresh bool) (*CachedStore, error) {
+	ttl := defaultCacheTTL
+	if config.TTL != "" {
+		var err error
+		if ttl, err = time.ParseDuration(config.TTL); err != nil {
+			return nil, fmt.Errorf(errWrapFormatWithID, ErrInvalidCacheTTL, config.TTL, err)
+		}
+	}
+
+	var negativeTTL time.Duration
+	if config.NegativeTTL != "" {
+		var err error
+		if negativeTTL, err = time.ParseDuration(config.NegativeTTL); err != nil {
+			return nil, fmt.Errorf(errWrapFormatWithID, ErrInvalidCacheTTL, config.NegativeTTL, err)
+		}
+	}
+
+	var cache storeCache
+	switch config.Storage {
+	case "", CacheStorageMemory:
+		c, _ := memoryCaches.LoadOrStore(memoryCacheKey(name, backend), &memoryCache{entries: map[string]cacheEntry{}})
+		cache = c.(*memoryCache)
+
+	case CacheStorageDisk:
+		dir := config.Path
+		if dir == "" {
+			var err error
+			if dir, err = defaultStoreCacheDir(name); err != nil {
+				return nil, err
+			}
+		}
+		// The cached values can be sensitive, so the cache is only readable by the user
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return nil, fmt.Errorf(errWrapFormatWithID, ErrCreateCacheDir, dir, err)
+		}
+		disk := &diskCache{dir: dir}
+		if _, pruned := prunedCacheDirs.LoadOrStore(dir, true); !pruned {
+			disk.prune(time.Now())
+		}
+		cache = disk
+
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCacheStorage, config.Storage)
+	}
+
+	return &CachedStore{
+		name:        name,
+		backend:     backend,
+		store:       store,
+		cache:       cache,
+		ttl:         ttl,
+		negativeTTL: negativeTTL,
+		refresh:     refresh,
+		now:         time.Now,
+	}, nil
+}
+
+// defaultStoreCacheDir returns the XDG cache directory for a store.
+func defaultStoreCacheDir(name string) (string, error) {
+	cacheHome := os.Getenv("XDG_CACHE_HOME")
+	if cacheHome == "" {
+		var err error
+		if cacheHome, err = os.UserCacheDir(); err != nil {
+			return "", fmt.Errorf(errWrapFormat, ErrCreateCacheDir, err)
+		}
+	}
+
+	dirName := strings.NewReplacer("/", "_", "\\", "_", ":", "_").Replace(name)
+	return filepath.Join(cacheHome, "atmos", "stores", dirName), nil
+}
+
+// BackendID returns an identifier of the backend configuration of a store.
+func BackendID(storeType string, options map[string]interface{}) string {
+	data, _ := json.Marshal(map[string]interface{}{"type": storeType, "options": options})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+func memoryCacheKey(name string, backend string) string {
+	return name + "\x00" + backend
+}
+
+func (s *CachedStore) cacheKey(stack string, component string, key string) string {
+	return strings.Join([]string{s.backend, stack, component, key}, "\x00")
+}
+
+// lookup returns the cached entry for a key if it has not expired.
+func (s *CachedStore) lookup(stack string, component string, key string) (cacheEntry, bool) {
+	if s.refresh {
+		return cacheEntry{}, false
+	}
+
+	entry, ok := s.cache.get(s.cacheKey(stack, component, key))
+	if !ok {
+		return cacheEntry{}, false
+	}
+	if !s.now().Before(entry.ExpiresAt) {
+		s.invalidate(stack, component, key)
+		return cacheEntry{}, false
+	}
+	return entry, true
+}
+
+func (s *CachedStore) put(stack string, component string, key string, entry cacheEntry) {
+	if err := s.cache.set(s.cacheKey(stack, component, key), entry); err != nil {
+		log.Debug("Failed to write store cache", "store", s.name, "key", key, "error", err)
+	}
+}
+
+func (s *CachedStore) invalidate(stack string, component string, key string) {
+	if err := s.cache.delete(s.cacheKey(stack, component, key)); err != nil {
+		log.Debug("Failed to invalidate store cache", "store", s.name, "key", key, "error", err)
+	}
+}
+
+func (s *CachedStore) Get(stack string, component string, key string) (interface{}, error) {
+	if entry, ok := s.lookup(stack, component, key); ok {
+		log.Debug("Store cache hit", "store", s.name, "stack", stack, "component", component, "key", key)
+		if entry.NotFound {
+			return nil, fmt.Errorf("%w: %s", ErrResourceNotFound, key)
+		}
+		return entry.Value, nil
+	}
+
+	log.Debug("Store cache miss", "store", s.name, "stack", stack, "component", component, "key", key)
+
+	value, err := s.store.Get(stack, component, key)
+	if err != nil {
+		if s.negativeTTL > 0 && errors.Is(err, ErrResourceNotFound) {
+			s.put(stack, component, key, cacheEntry{NotFound: true, ExpiresAt: s.now().Add(s.negativeTTL)})
+		}
+		return nil, err
+	}
+
+	s.put(stack, component, key, cacheEntry{Value: value, ExpiresAt: s.now().Add(s.ttl)})
+	return value, nil
+}
+
+func (s *CachedStore) Set(stack string, component string, key string, value interface{}) error {
+	if err := s.store.Set(stack, component, key, value); err != nil {
+		return err
+	}
+
+	s.invalidate(stack, component, key)
+	return nil
+}
+
+func (s *CachedStore) Delete(stack string, component string, key string) error {
+	err := s.store.Delete(stack, component, key)
+	s.invalidate(stack, component, key)
+	return err
+}
+
+// List is not cached, since listing is used to discover keys that may have been written by other processes.
+func (s *CachedStore) List(stack string, component string) ([]string, error) {
+	return s.store.List(stack, component)
+}
+
+func (s *CachedStore) Exists(stack string, component string, key string) (bool, error) {
+	if entry, ok := s.lookup(stack, component, key); ok {
+		log.Debug("Store cache hit", "store", s.name, "stack", stack, "component", component, "key", key)
+		return !entry.NotFound, nil
+	}
+
+	return s.store.Exists(stack, component, key)
+}
+
+// memoryCache stores cache entries in memory.
+type memoryCache struct {
+	mu      sync.RWMutex
+	entries map[string]cacheEntry
+}
+
+func (c *memoryCache) get(key string) (cacheEntry, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.entries[key]
+	return entry, ok
+}
+
+func (c *memoryCache) set(key string, entry cacheEntry) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = entry
+	return nil
+}
+
+func (c *memoryCache) delete(key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+	return nil
+}
+
+// diskCache stores each cache entry in a JSON file named after the hash of its key.
+type diskCache struct {
+	dir string
+}
+
+func (c *diskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (c *diskCache) get(key string) (cacheEntry, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return cacheEntry{}, false
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return cacheEntry{}, false
+	}
+	return entry, true
+}
+
+// set writes the entry to a temporary file and renames it, so that concurrent Atmos processes never read partial entries.
+func (c *diskCache) set(key string, entry cacheEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(c.dir, ".entry-*")
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	return os.Rename(f.Name(), c.path(key))
+}
+
+// prune removes the expired entries, and the entries that can't be read.
+func (c *diskCache) prune(now time.Time) {
+	files, err := filepath.Glob(filepath.Join(c.dir, "*.json"))
+	if err != nil {
+		return
+	}
+
+	for _, file := range files {
+		var entry cacheEntry
+		data, err := os.ReadFile(file)
+		if err == nil {
+			err = json.Unmarshal(data, &entry)
+		}
+		if err != nil || !now.Before(entry.ExpiresAt) {
+			if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+				log.Debug("Failed to remove expired store cache entry", "file", file, "error", err)
+			}
+		}
+	}
+}
+
+func (c *diskCache) delete(key string) error {
+	err := os.Remove(c.path(key))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
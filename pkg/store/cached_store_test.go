@@ -0,0 +1,374 @@
+package store
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockStore is a mock implementation of the Store interface.
+type MockStore struct {
+	mock.Mock
+}
+
+func (m *MockStore) Set(stack string, component string, key string, value interface{}) error {
+	args := m.Called(stack, component, key, value)
+	return args.Error(0)
+}
+
+func (m *MockStore) Get(stack string, component string, key string) (interface{}, error) {
+	args := m.Called(stack, component, key)
+	return args.Get(0), args.Error(1)
+}
+
+func (m *MockStore) Delete(stack string, component string, key string) error {
+	args := m.Called(stack, component, key)
+	return args.Error(0)
+}
+
+func (m *MockStore) List(stack string, component string) ([]string, error) {
+	args := m.Called(stack, component)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockStore) Exists(stack string, component string, key string) (bool, error) {
+	args := m.Called(stack, component, key)
+	return args.Bool(0), args.Error(1)
+}
+
+func newTestCachedStore(t *testing.T, inner Store, config CacheConfig, refresh bool) *CachedStore {
+	s, err := NewCachedStore(t.Name(), "", inner, config, refresh)
+	require.NoError(t, err)
+	t.Cleanup(func() { deleteMemoryCaches(t.Name()) })
+	return s
+}
+
+// deleteMemoryCaches deletes the memory caches of a store, for all its backends.
+func deleteMemoryCaches(name string) {
+	memoryCaches.Range(func(key, _ any) bool {
+		if strings.HasPrefix(key.(string), name+"\x00") {
+			memoryCaches.Delete(key)
+		}
+		return true
+	})
+}
+
+func TestNewCachedStore(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  CacheConfig
+		wantErr error
+	}{
+		{name: "defaults", config: CacheConfig{}},
+		{name: "memory", config: CacheConfig{TTL: "1m", NegativeTTL: "10s", Storage: CacheStorageMemory}},
+		{name: "disk", config: CacheConfig{Storage: CacheStorageDisk, Path: t.TempDir()}},
+		{name: "invalid ttl", config: CacheConfig{TTL: "soon"}, wantErr: ErrInvalidCacheTTL},
+		{name: "invalid negative ttl", config: CacheConfig{NegativeTTL: "never"}, wantErr: ErrInvalidCacheTTL},
+		{name: "invalid storage", config: CacheConfig{Storage: "s3"}, wantErr: ErrInvalidCacheStorage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewCachedStore(t.Name(), "", new(MockStore), tt.config, false)
+			deleteMemoryCaches(t.Name())
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCachedStore_Get(t *testing.T) {
+	for _, storage := range []string{CacheStorageMemory, CacheStorageDisk} {
+		t.Run(storage, func(t *testing.T) {
+			inner := new(MockStore)
+			inner.On("Get", "dev", "vpc", "cidr").Return("10.0.0.0/16", nil).Once()
+
+			s := newTestCachedStore(t, inner, CacheConfig{TTL: "1m", Storage: storage, Path: t.TempDir()}, false)
+
+			for i := 0; i < 3; i++ {
+				value, err := s.Get("dev", "vpc", "cidr")
+				require.NoError(t, err)
+				assert.Equal(t, "10.0.0.0/16", value)
+			}
+
+			inner.AssertNumberOfCalls(t, "Get", 1)
+		})
+	}
+}
+
+func TestCachedStore_GetExpired(t *testing.T) {
+	inner := new(MockStore)
+	inner.On("Get", "dev", "vpc", "cidr").Return("10.0.0.0/16", nil)
+
+	s := newTestCachedStore(t, inner, CacheConfig{TTL: "1m"}, false)
+	now := time.Now()
+	s.now = func() time.Time { return now }
+
+	_, err := s.Get("dev", "vpc", "cidr")
+	require.NoError(t, err)
+
+	now = now.Add(2 * time.Minute)
+	_, err = s.Get("dev", "vpc", "cidr")
+	require.NoError(t, err)
+
+	inner.AssertNumberOfCalls(t, "Get", 2)
+}
+
+func TestCachedStore_NegativeCaching(t *testing.T) {
+	notFound := fmt.Errorf("%w: cidr", ErrResourceNotFound)
+
+	t.Run("enabled", func(t *testing.T) {
+		inner := new(MockStore)
+		inner.On("Get", "dev", "vpc", "cidr").Return(nil, notFound).Once()
+
+		s := newTestCachedStore(t, inner, CacheConfig{NegativeTTL: "1m"}, false)
+
+		for i := 0; i < 2; i++ {
+			_, err := s.Get("dev", "vpc", "cidr")
+			assert.ErrorIs(t, err, ErrResourceNotFound)
+		}
+
+		exists, err := s.Exists("dev", "vpc", "cidr")
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		inner.AssertNumberOfCalls(t, "Get", 1)
+		inner.AssertNotCalled(t, "Exists", "dev", "vpc", "cidr")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		inner := new(MockStore)
+		inner.On("Get", "dev", "vpc", "cidr").Return(nil, notFound)
+
+		s := newTestCachedStore(t, inner, CacheConfig{}, false)
+
+		for i := 0; i < 2; i++ {
+			_, err := s.Get("dev", "vpc", "cidr")
+			assert.ErrorIs(t, err, ErrResourceNotFound)
+		}
+
+		inner.AssertNumberOfCalls(t, "Get", 2)
+	})
+
+	t.Run("other errors are not cached", func(t *testing.T) {
+		inner := new(MockStore)
+		inner.On("Get", "dev", "vpc", "cidr").Return(nil, ErrGetParameter)
+
+		s := newTestCachedStore(t, inner, CacheConfig{NegativeTTL: "1m"}, false)
+
+		for i := 0; i < 2; i++ {
+			_, err := s.Get("dev", "vpc", "cidr")
+			assert.ErrorIs(t, err, ErrGetParameter)
+		}
+
+		inner.AssertNumberOfCalls(t, "Get", 2)
+	})
+}
+
+func TestCachedStore_Refresh(t *testing.T) {
+	inner := new(MockStore)
+	inner.On("Get", "dev", "vpc", "cidr").Return("10.0.0.0/16", nil).Once()
+	inner.On("Get", "dev", "vpc", "cidr").Return("10.1.0.0/16", nil).Once()
+
+	s := newTestCachedStore(t, inner, CacheConfig{}, false)
+
+	value, err := s.Get("dev", "vpc", "cidr")
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.0/16", value)
+
+	// A refreshing store shares the cache, bypasses it on reads, and updates it with the values read.
+	refreshing, err := NewCachedStore(t.Name(), "", inner, CacheConfig{}, true)
+	require.NoError(t, err)
+
+	value, err = refreshing.Get("dev", "vpc", "cidr")
+	require.NoError(t, err)
+	assert.Equal(t, "10.1.0.0/16", value)
+
+	value, err = s.Get("dev", "vpc", "cidr")
+	require.NoError(t, err)
+	assert.Equal(t, "10.1.0.0/16", value)
+
+	inner.AssertNumberOfCalls(t, "Get", 2)
+}
+
+func TestCachedStore_WritesInvalidate(t *testing.T) {
+	dir := t.TempDir()
+
+	inner := new(MockStore)
+	inner.On("Get", "dev", "vpc", "cidr").Return("10.0.0.0/16", nil)
+	inner.On("Set", "dev", "vpc", "cidr", "10.1.0.0/16").Return(nil)
+	inner.On("Delete", "dev", "vpc", "cidr").Return(nil)
+
+	s := newTestCachedStore(t, inner, CacheConfig{Storage: CacheStorageDisk, Path: dir}, false)
+
+	_, err := s.Get("dev", "vpc", "cidr")
+	require.NoError(t, err)
+
+	entries, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	require.NoError(t, s.Set("dev", "vpc", "cidr", "10.1.0.0/16"))
+	_, err = os.Stat(entries[0])
+	assert.True(t, os.IsNotExist(err))
+
+	_, err = s.Get("dev", "vpc", "cidr")
+	require.NoError(t, err)
+	require.NoError(t, s.Delete("dev", "vpc", "cidr"))
+	_, err = s.Get("dev", "vpc", "cidr")
+	require.NoError(t, err)
+
+	inner.AssertNumberOfCalls(t, "Get", 3)
+}
+
+func TestCachedStore_ListAndExists(t *testing.T) {
+	inner := new(MockStore)
+	inner.On("List", "dev", "vpc").Return([]string{"cidr"}, nil).Twice()
+	inner.On("Exists", "dev", "vpc", "cidr").Return(true, nil).Once()
+	inner.On("Get", "dev", "vpc", "cidr").Return("10.0.0.0/16", nil).Once()
+
+	s := newTestCachedStore(t, inner, CacheConfig{}, false)
+
+	for i := 0; i < 2; i++ {
+		keys, err := s.List("dev", "vpc")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"cidr"}, keys)
+	}
+
+	exists, err := s.Exists("dev", "vpc", "cidr")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	_, err = s.Get("dev", "vpc", "cidr")
+	require.NoError(t, err)
+
+	exists, err = s.Exists("dev", "vpc", "cidr")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	inner.AssertExpectations(t)
+}
+
+func TestCachedStore_Backends(t *testing.T) {
+	dev := new(MockStore)
+	dev.On("Get", "dev", "vpc", "cidr").Return("10.0.0.0/16", nil).Once()
+	prod := new(MockStore)
+	prod.On("Get", "dev", "vpc", "cidr").Return("10.1.0.0/16", nil).Once()
+	t.Cleanup(func() { deleteMemoryCaches(t.Name()) })
+
+	// Stores with the same name and different backends don't share the cached values
+	devStore, err := NewCachedStore(t.Name(), BackendID("redis", map[string]interface{}{"url": "redis://dev:6379"}), dev, CacheConfig{}, false)
+	require.NoError(t, err)
+	prodStore, err := NewCachedStore(t.Name(), BackendID("redis", map[string]interface{}{"url": "redis://prod:6379"}), prod, CacheConfig{}, false)
+	require.NoError(t, err)
+
+	value, err := devStore.Get("dev", "vpc", "cidr")
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.0/16", value)
+
+	value, err = prodStore.Get("dev", "vpc", "cidr")
+	require.NoError(t, err)
+	assert.Equal(t, "10.1.0.0/16", value)
+
+	dev.AssertExpectations(t)
+	prod.AssertExpectations(t)
+}
+
+func TestCachedStore_DiskPermissionsAndExpiredEntries(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "cache")
+
+	inner := new(MockStore)
+	inner.On("Get", "dev", "vpc", "cidr").Return("10.0.0.0/16", nil)
+
+	s := newTestCachedStore(t, inner, CacheConfig{Storage: CacheStorageDisk, Path: dir, TTL: "1m"}, false)
+	info, err := os.Stat(dir)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o700), info.Mode().Perm())
+
+	_, err = s.Get("dev", "vpc", "cidr")
+	require.NoError(t, err)
+	entries, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	info, err = os.Stat(entries[0])
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	// The expired entries are removed when they are read
+	s.now = func() time.Time { return time.Now().Add(time.Hour) }
+	_, found := s.lookup("dev", "vpc", "cidr")
+	assert.False(t, found)
+	assert.NoFileExists(t, entries[0])
+
+	// The expired entries of all the keys are removed when the cache is opened
+	s.now = time.Now
+	_, err = s.Get("dev", "vpc", "cidr")
+	require.NoError(t, err)
+	require.FileExists(t, entries[0])
+	(&diskCache{dir: dir}).prune(time.Now().Add(time.Hour))
+	assert.NoFileExists(t, entries[0])
+}
+
+func TestDefaultStoreCacheDir(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", "/tmp/cache")
+
+	dir, err := defaultStoreCacheDir("prod/ssm")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("/tmp/cache", "atmos", "stores", "prod_ssm"), dir)
+}
+
+func TestNewStoreRegistryWithCache(t *testing.T) {
+	config := StoresConfig{
+		"redis-cached": {
+			Type:    "redis",
+			Options: map[string]interface{}{"url": "redis://localhost:6379"},
+			Cache:   &CacheConfig{TTL: "1m"},
+		},
+		"redis": {
+			Type:    "redis",
+			Options: map[string]interface{}{"url": "redis://localhost:6379"},
+		},
+	}
+	t.Cleanup(func() { deleteMemoryCaches("redis-cached") })
+
+	registry, err := NewStoreRegistryWithOptions(&config, RegistryOptions{RefreshCache: true})
+	require.NoError(t, err)
+
+	cached, ok := registry["redis-cached"].(*CachedStore)
+	require.True(t, ok)
+	assert.True(t, cached.refresh)
+	assert.IsType(t, &RedisStore{}, cached.store)
+	assert.IsType(t, &RedisStore{}, registry["redis"])
+}
+
+func TestNewStoreRegistryWithSecretDiskCache(t *testing.T) {
+	config := StoresConfig{
+		"vault": {
+			Type:    "vault",
+			Options: map[string]interface{}{"address": "http://localhost:8200", "token": "test"},
+			Cache:   &CacheConfig{Storage: CacheStorageDisk, Path: t.TempDir()},
+		},
+	}
+
+	// The values of the secret stores are not cached on disk in plaintext, unless it's allowed
+	_, err := NewStoreRegistry(&config)
+	assert.ErrorIs(t, err, ErrPlaintextSecretCache)
+
+	config["vault"].Cache.AllowPlaintext = true
+	registry, err := NewStoreRegistry(&config)
+	require.NoError(t, err)
+	assert.IsType(t, &CachedStore{}, registry["vault"])
+}
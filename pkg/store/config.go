@@ -3,8 +3,10 @@ package store
 import "github.com/mitchellh/mapstructure"
 
 type StoreConfig struct {
-	Type    string                 `yaml:"type"`
-	Options map[string]interface{} `yaml:"options"`
+	Type       string                 `yaml:"type"`
+	Options    map[string]interface{} `yaml:"options"`
+	Cache      *CacheConfig           `yaml:"cache,omitempty"`
+	Encryption *EncryptionConfig      `yaml:"encryption,omitempty"`
 }
 
 type StoresConfig = map[string]StoreConfig
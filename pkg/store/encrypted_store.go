@@ -0,0 +1,205 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+
+	"github.com/cloudposse/atmos/pkg/config/go-homedir"
+)
+
+// EncryptionConfig configures the encryption of the values of a store with age (https://age-encryption.org).
+type EncryptionConfig struct {
+	// Recipients are the age public keys that values are encrypted to.
+	Recipients []string `yaml:"recipients,omitempty" json:"recipients,omitempty" mapstructure:"recipients"`
+	// RecipientsFile is a file with one age public key per line.
+	RecipientsFile string `yaml:"recipients_file,omitempty" json:"recipients_file,omitempty" mapstructure:"recipients_file"`
+	// KeyFile is an age identity file used to decrypt values. If no recipients are configured,
+	// values are encrypted to the public keys of its identities.
+	KeyFile string `yaml:"key_file,omitempty" json:"key_file,omitempty" mapstructure:"key_file"`
+	// KeyFiles are additional age identity files used to decrypt values, e.g. the keys that were rotated out.
+	KeyFiles []string `yaml:"key_files,omitempty" json:"key_files,omitempty" mapstructure:"key_files"`
+	// AllowPlaintext returns the values that are not encrypted as they are, e.g. the values written before encryption
+	// was enabled. Otherwise, reading a value that is not encrypted is an error.
+	AllowPlaintext bool `yaml:"allow_plaintext,omitempty" json:"allow_plaintext,omitempty" mapstructure:"allow_plaintext"`
+}
+
+// EncryptedStore encrypts the values written to another store and decrypts the values read from it.
+type EncryptedStore struct {
+	store          Store
+	recipients     []age.Recipient
+	identities     []age.Identity
+	allowPlaintext bool
+}
+
+// Ensure EncryptedStore implements the store.Store interface.
+var _ Store = (*EncryptedStore)(nil)
+
+const ageArmorHeader = "-----BEGIN AGE ENCRYPTED FILE-----"
+
+// NewEncryptedStore wraps a store so that its values are encrypted at rest.
+func NewEncryptedStore(store Store, config EncryptionConfig) (*EncryptedStore, error) {
+	var identities []age.Identity
+	var primaryIdentities []age.Identity
+	for i, keyFile := range append([]string{config.KeyFile}, config.KeyFiles...) {
+		if keyFile == "" {
+			continue
+		}
+
+		ids, err := parseAgeFile(keyFile, age.ParseIdentities)
+		if err != nil {
+			return nil, fmt.Errorf(errWrapFormatWithID, ErrParseEncryptionKey, keyFile, err)
+		}
+		if i == 0 {
+			primaryIdentities = ids
+		}
+		identities = append(identities, ids...)
+	}
+
+	// The inline recipients are parsed the same way as the recipients file
+	var recipients []age.Recipient
+	for _, r := range config.Recipients {
+		rs, err := age.ParseRecipients(strings.NewReader(r))
+		if err != nil {
+			return nil, fmt.Errorf(errWrapFormatWithID, ErrParseEncryptionKey, r, err)
+		}
+		recipients = append(recipients, rs...)
+	}
+
+	if config.RecipientsFile != "" {
+		rs, err := parseAgeFile(config.RecipientsFile, age.ParseRecipients)
+		if err != nil {
+			return nil, fmt.Errorf(errWrapFormatWithID, ErrParseEncryptionKey, config.RecipientsFile, err)
+		}
+		recipients = append(recipients, rs...)
+	}
+
+	// Without explicit recipients, encrypt to the identities of the primary key file, so that it can decrypt its own values
+	if len(recipients) == 0 {
+		for _, id := range primaryIdentities {
+			if x, ok := id.(*age.X25519Identity); ok {
+				recipients = append(recipients, x.Recipient())
+			}
+		}
+	}
+
+	if len(recipients) == 0 {
+		return nil, ErrEncryptionRecipientsRequired
+	}
+
+	return &EncryptedStore{
+		store:          store,
+		recipients:     recipients,
+		identities:     identities,
+		allowPlaintext: config.AllowPlaintext,
+	}, nil
+}
+
+// parseAgeFile reads age identities or recipients from a file.
+func parseAgeFile[T any](path string, parse func(io.Reader) ([]T, error)) ([]T, error) {
+	path, err := homedir.Expand(path)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parse(f)
+}
+
+func (s *EncryptedStore) encrypt(value interface{}) (string, error) {
+	plaintext, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf(errWrapFormat, ErrSerializeJSON, err)
+	}
+
+	var buf bytes.Buffer
+	armorWriter := armor.NewWriter(&buf)
+	w, err := age.Encrypt(armorWriter, s.recipients...)
+	if err != nil {
+		return "", fmt.Errorf(errWrapFormat, ErrEncryptValue, err)
+	}
+	if _, err := w.Write(plaintext); err != nil {
+		return "", fmt.Errorf(errWrapFormat, ErrEncryptValue, err)
+	}
+	if err := w.Close(); err != nil {
+		return "", fmt.Errorf(errWrapFormat, ErrEncryptValue, err)
+	}
+	if err := armorWriter.Close(); err != nil {
+		return "", fmt.Errorf(errWrapFormat, ErrEncryptValue, err)
+	}
+
+	return buf.String(), nil
+}
+
+func (s *EncryptedStore) decrypt(ciphertext string) (interface{}, error) {
+	if len(s.identities) == 0 {
+		return nil, ErrEncryptionKeyRequired
+	}
+
+	r, err := age.Decrypt(armor.NewReader(strings.NewReader(ciphertext)), s.identities...)
+	if err != nil {
+		return nil, fmt.Errorf(errWrapFormat, ErrDecryptValue, err)
+	}
+
+	plaintext, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf(errWrapFormat, ErrDecryptValue, err)
+	}
+
+	var result interface{}
+	if err := json.Unmarshal(plaintext, &result); err != nil {
+		return nil, fmt.Errorf(errWrapFormat, ErrDecryptValue, err)
+	}
+	return result, nil
+}
+
+func (s *EncryptedStore) Set(stack string, component string, key string, value interface{}) error {
+	ciphertext, err := s.encrypt(value)
+	if err != nil {
+		return err
+	}
+
+	return s.store.Set(stack, component, key, ciphertext)
+}
+
+// Get decrypts the value read from the wrapped store. Values that are not encrypted are an error,
+// unless `allow_plaintext` is set, in which case they are returned as they are.
+func (s *EncryptedStore) Get(stack string, component string, key string) (interface{}, error) {
+	value, err := s.store.Get(stack, component, key)
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext, ok := value.(string)
+	if !ok || !strings.HasPrefix(strings.TrimSpace(ciphertext), ageArmorHeader) {
+		if s.allowPlaintext {
+			return value, nil
+		}
+		return nil, fmt.Errorf("%w: %s", ErrValueNotEncrypted, key)
+	}
+
+	return s.decrypt(ciphertext)
+}
+
+func (s *EncryptedStore) Delete(stack string, component string, key string) error {
+	return s.store.Delete(stack, component, key)
+}
+
+func (s *EncryptedStore) List(stack string, component string) ([]string, error) {
+	return s.store.List(stack, component)
+}
+
+func (s *EncryptedStore) Exists(stack string, component string, key string) (bool, error) {
+	return s.store.Exists(stack, component, key)
+}
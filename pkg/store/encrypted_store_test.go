@@ -0,0 +1,184 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeAgeKeyFile generates an age identity and writes it to a key file.
+func writeAgeKeyFile(t *testing.T) (string, *age.X25519Identity) {
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "key.txt")
+	content := "# public key: " + identity.Recipient().String() + "\n" + identity.String() + "\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path, identity
+}
+
+func newTestRedisBackedStore(t *testing.T) Store {
+	server := miniredis.RunT(t)
+	url := "redis://" + server.Addr()
+
+	s, err := NewRedisStore(RedisStoreOptions{URL: &url})
+	require.NoError(t, err)
+	return s
+}
+
+func TestNewEncryptedStore(t *testing.T) {
+	keyFile, identity := writeAgeKeyFile(t)
+
+	recipientsFile := filepath.Join(t.TempDir(), "recipients.txt")
+	require.NoError(t, os.WriteFile(recipientsFile, []byte(identity.Recipient().String()+"\n"), 0o600))
+
+	tests := []struct {
+		name    string
+		config  EncryptionConfig
+		wantErr error
+	}{
+		{name: "key file", config: EncryptionConfig{KeyFile: keyFile}},
+		{name: "recipients", config: EncryptionConfig{Recipients: []string{identity.Recipient().String()}}},
+		{name: "recipients file", config: EncryptionConfig{RecipientsFile: recipientsFile}},
+		{name: "no keys", config: EncryptionConfig{}, wantErr: ErrEncryptionRecipientsRequired},
+		{name: "invalid recipient", config: EncryptionConfig{Recipients: []string{"age1invalid"}}, wantErr: ErrParseEncryptionKey},
+		{name: "missing key file", config: EncryptionConfig{KeyFile: "/does/not/exist"}, wantErr: ErrParseEncryptionKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewEncryptedStore(new(MockStore), tt.config)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestEncryptedStore_RoundTrip(t *testing.T) {
+	keyFile, _ := writeAgeKeyFile(t)
+	inner := newTestRedisBackedStore(t)
+
+	s, err := NewEncryptedStore(inner, EncryptionConfig{KeyFile: keyFile})
+	require.NoError(t, err)
+
+	values := map[string]interface{}{
+		"string": "secret",
+		"map":    map[string]interface{}{"username": "admin", "port": float64(5432)},
+		"list":   []interface{}{"a", "b"},
+	}
+
+	for key, value := range values {
+		require.NoError(t, s.Set("dev", "db", key, value))
+
+		// The wrapped store only holds the ciphertext
+		raw, err := inner.Get("dev", "db", key)
+		require.NoError(t, err)
+		ciphertext, ok := raw.(string)
+		require.True(t, ok)
+		assert.True(t, strings.HasPrefix(ciphertext, ageArmorHeader))
+		assert.NotContains(t, ciphertext, "admin")
+
+		got, err := s.Get("dev", "db", key)
+		require.NoError(t, err)
+		assert.Equal(t, value, got)
+	}
+
+	keys, err := s.List("dev", "db")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"string", "map", "list"}, keys)
+
+	exists, err := s.Exists("dev", "db", "string")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	require.NoError(t, s.Delete("dev", "db", "string"))
+	exists, err = s.Exists("dev", "db", "string")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestEncryptedStore_KeyRotation(t *testing.T) {
+	oldKeyFile, _ := writeAgeKeyFile(t)
+	newKeyFile, _ := writeAgeKeyFile(t)
+	inner := newTestRedisBackedStore(t)
+
+	oldStore, err := NewEncryptedStore(inner, EncryptionConfig{KeyFile: oldKeyFile})
+	require.NoError(t, err)
+	require.NoError(t, oldStore.Set("dev", "db", "password", "old-secret"))
+
+	// The rotated store encrypts with the new key and still decrypts the values encrypted with the old key
+	rotatedStore, err := NewEncryptedStore(inner, EncryptionConfig{KeyFile: newKeyFile, KeyFiles: []string{oldKeyFile}})
+	require.NoError(t, err)
+
+	value, err := rotatedStore.Get("dev", "db", "password")
+	require.NoError(t, err)
+	assert.Equal(t, "old-secret", value)
+
+	require.NoError(t, rotatedStore.Set("dev", "db", "password", "new-secret"))
+
+	_, err = oldStore.Get("dev", "db", "password")
+	assert.ErrorIs(t, err, ErrDecryptValue)
+
+	newStore, err := NewEncryptedStore(inner, EncryptionConfig{KeyFile: newKeyFile})
+	require.NoError(t, err)
+	value, err = newStore.Get("dev", "db", "password")
+	require.NoError(t, err)
+	assert.Equal(t, "new-secret", value)
+}
+
+func TestEncryptedStore_Get(t *testing.T) {
+	_, identity := writeAgeKeyFile(t)
+	inner := newTestRedisBackedStore(t)
+
+	// An encrypt-only store can write values, but not decrypt them
+	s, err := NewEncryptedStore(inner, EncryptionConfig{Recipients: []string{identity.Recipient().String()}})
+	require.NoError(t, err)
+
+	require.NoError(t, s.Set("dev", "db", "password", "secret"))
+	_, err = s.Get("dev", "db", "password")
+	assert.ErrorIs(t, err, ErrEncryptionKeyRequired)
+
+	// Values that are not encrypted are rejected, unless plaintext values are allowed
+	require.NoError(t, inner.Set("dev", "db", "host", "db.example.com"))
+	_, err = s.Get("dev", "db", "host")
+	assert.ErrorIs(t, err, ErrValueNotEncrypted)
+
+	s, err = NewEncryptedStore(inner, EncryptionConfig{Recipients: []string{identity.Recipient().String()}, AllowPlaintext: true})
+	require.NoError(t, err)
+	value, err := s.Get("dev", "db", "host")
+	require.NoError(t, err)
+	assert.Equal(t, "db.example.com", value)
+
+	_, err = s.Get("dev", "db", "missing")
+	assert.ErrorIs(t, err, ErrResourceNotFound)
+}
+
+func TestNewStoreRegistryWithEncryption(t *testing.T) {
+	keyFile, _ := writeAgeKeyFile(t)
+
+	config := StoresConfig{
+		"redis-encrypted": {
+			Type:       "redis",
+			Options:    map[string]interface{}{"url": "redis://localhost:6379"},
+			Cache:      &CacheConfig{},
+			Encryption: &EncryptionConfig{KeyFile: keyFile},
+		},
+	}
+	t.Cleanup(func() { deleteMemoryCaches("redis-encrypted") })
+
+	registry, err := NewStoreRegistry(&config)
+	require.NoError(t, err)
+
+	encrypted, ok := registry["redis-encrypted"].(*EncryptedStore)
+	require.True(t, ok)
+	assert.IsType(t, &CachedStore{}, encrypted.store)
+}
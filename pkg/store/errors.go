@@ -46,10 +46,43 @@ var (
 	ErrCreateSecret      = errors.New("failed to create secret")
 	ErrAddSecretVersion  = errors.New("failed to add secret version")
 
+	// HashiCorp Vault specific errors.
+	ErrVaultAddressRequired       = errors.New("either address must be set in options or VAULT_ADDR environment variable must be set")
+	ErrVaultTokenRequired         = errors.New("either token must be set in options or VAULT_TOKEN environment variable must be set")
+	ErrVaultAppRoleCredentials    = errors.New("role_id and secret_id (or VAULT_ROLE_ID and VAULT_SECRET_ID) are required for approle auth")
+	ErrVaultUnsupportedAuthMethod = errors.New("unsupported vault auth method")
+	ErrVaultLogin                 = errors.New("failed to login to vault")
+
+	// File store specific errors.
+	ErrFileStorePathRequired  = errors.New("path is required in file store configuration")
+	ErrFileStoreInvalidFormat = errors.New("invalid file store format, must be 'yaml' or 'json'")
+	ErrFileStoreReadOnly      = errors.New("file store with sops enabled is read-only")
+	ErrFileStoreDecrypt       = errors.New("failed to decrypt sops file")
+	ErrFileStoreLock          = errors.New("failed to lock file")
+	ErrFileStoreInvalidPath   = errors.New("stack and component must not be absolute paths or contain '..' in a file store")
+	ErrWriteFile              = errors.New("failed to write file")
+
+	// Encryption specific errors.
+	ErrParseEncryptionKey           = errors.New("failed to parse age key")
+	ErrEncryptionRecipientsRequired = errors.New("store encryption requires recipients, a recipients_file or a key_file")
+	ErrEncryptionKeyRequired        = errors.New("a key_file is required to decrypt store values")
+	ErrEncryptValue                 = errors.New("failed to encrypt value")
+	ErrDecryptValue                 = errors.New("failed to decrypt value")
+	ErrValueNotEncrypted            = errors.New("store value is not encrypted")
+
+	// Cache specific errors.
+	ErrInvalidCacheTTL      = errors.New("invalid store cache ttl")
+	ErrInvalidCacheStorage  = errors.New("invalid store cache storage, must be 'memory' or 'disk'")
+	ErrCreateCacheDir       = errors.New("failed to create store cache directory")
+	ErrPlaintextSecretCache = errors.New("secret store values can't be cached on disk without encryption")
+
 	// Registry specific errors.
 	ErrParseArtifactoryOptions = errors.New("failed to parse Artifactory store options")
 	ErrParseSSMOptions         = errors.New("failed to parse SSM store options")
 	ErrParseRedisOptions       = errors.New("failed to parse Redis store options")
+	ErrParseVaultOptions       = errors.New("failed to parse Vault store options")
+	ErrParseSecretsManagerOpts = errors.New("failed to parse AWS Secrets Manager store options")
+	ErrParseFileStoreOptions   = errors.New("failed to parse file store options")
 	ErrStoreTypeNotFound       = errors.New("store type not found")
 
 	// Shared errors.
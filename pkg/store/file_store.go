@@ -0,0 +1,355 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/getsops/sops/v3/decrypt"
+	"github.com/gofrs/flock"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	fileStoreFormatYAML = "yaml"
+	fileStoreFormatJSON = "json"
+
+	// fileStoreLockFile is the lock file of a directory store, in the root of the directory.
+	fileStoreLockFile = ".atmos-store.lock"
+)
+
+// FileStore keeps values in local files, either in a single YAML/JSON file with one entry per key,
+// or in a directory tree with one YAML/JSON file per stack and component.
+type FileStore struct {
+	path           string
+	singleFile     bool
+	format         string
+	prefix         string
+	stackDelimiter *string
+	sops           bool
+}
+
+type FileStoreOptions struct {
+	Path           string  `mapstructure:"path"`
+	Prefix         *string `mapstructure:"prefix"`
+	StackDelimiter *string `mapstructure:"stack_delimiter"`
+	Format         *string `mapstructure:"format"`
+	Sops           bool    `mapstructure:"sops"`
+}
+
+// Ensure FileStore implements the store.Store interface.
+var _ Store = (*FileStore)(nil)
+
+func NewFileStore(options FileStoreOptions) (Store, error) {
+	if options.Path == "" {
+		return nil, ErrFileStorePathRequired
+	}
+
+	path, err := filepath.Abs(options.Path)
+	if err != nil {
+		return nil, fmt.Errorf(errWrapFormatWithID, ErrFileStorePathRequired, options.Path, err)
+	}
+
+	prefix := ""
+	if options.Prefix != nil {
+		prefix = *options.Prefix
+	}
+
+	stackDelimiter := "/"
+	if options.StackDelimiter != nil {
+		stackDelimiter = *options.StackDelimiter
+	}
+
+	// A path with a YAML or JSON extension is a single file, and the extension determines its format
+	singleFile := true
+	format := fileStoreFormatYAML
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+	case ".json":
+		format = fileStoreFormatJSON
+	default:
+		singleFile = false
+		if options.Format != nil {
+			format = *options.Format
+		}
+	}
+
+	if format != fileStoreFormatYAML && format != fileStoreFormatJSON {
+		return nil, fmt.Errorf("%w: %s", ErrFileStoreInvalidFormat, format)
+	}
+
+	return &FileStore{
+		path:           path,
+		singleFile:     singleFile,
+		format:         format,
+		prefix:         prefix,
+		stackDelimiter: &stackDelimiter,
+		sops:           options.Sops,
+	}, nil
+}
+
+// locate returns the file holding the keys of a stack and component, and the prefix of the entries in that file.
+func (s *FileStore) locate(stack string, component string) (string, string, error) {
+	if s.stackDelimiter == nil {
+		return "", "", ErrStackDelimiterNotSet
+	}
+
+	keyPrefix, err := getKeyPrefix(s.prefix, *s.stackDelimiter, stack, component, "/")
+	if err != nil {
+		return "", "", fmt.Errorf(errWrapFormat, ErrGetKey, err)
+	}
+	keyPrefix = strings.TrimPrefix(keyPrefix, "/")
+
+	if s.singleFile {
+		return s.path, keyPrefix, nil
+	}
+
+	// The stack and component become a path in the directory, so they must not point outside of it
+	rel := filepath.FromSlash(strings.TrimSuffix(keyPrefix, "/"))
+	if err := validateFileStorePathSegments(*s.stackDelimiter, stack, component); err != nil {
+		return "", "", err
+	}
+	if !filepath.IsLocal(rel) {
+		return "", "", fmt.Errorf("%w: %s", ErrFileStoreInvalidPath, rel)
+	}
+
+	return filepath.Join(s.path, rel+"."+s.format), "", nil
+}
+
+// validateFileStorePathSegments rejects the stacks and components that are absolute paths or contain `..` segments.
+func validateFileStorePathSegments(stackDelimiter string, stack string, component string) error {
+	for _, name := range []string{stack, component} {
+		if filepath.IsAbs(name) || strings.HasPrefix(name, "/") || strings.HasPrefix(name, `\`) {
+			return fmt.Errorf("%w: %s", ErrFileStoreInvalidPath, name)
+		}
+		segments := strings.FieldsFunc(name, func(r rune) bool {
+			return r == '/' || r == '\\' || strings.ContainsRune(stackDelimiter, r)
+		})
+		for _, segment := range segments {
+			if segment == ".." {
+				return fmt.Errorf("%w: %s", ErrFileStoreInvalidPath, name)
+			}
+		}
+	}
+	return nil
+}
+
+// lockPath returns the lock file of the store. A single file store is locked with a `.lock` file next to it,
+// and a directory store with one lock file in the root of the directory, shared by all stacks and components.
+func (s *FileStore) lockPath() string {
+	if s.singleFile {
+		return s.path + ".lock"
+	}
+	return filepath.Join(s.path, fileStoreLockFile)
+}
+
+// read returns the entries of a file. A file that does not exist has no entries.
+func (s *FileStore) read(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return map[string]interface{}{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf(errWrapFormatWithID, ErrReadFile, path, err)
+	}
+
+	if s.sops {
+		if data, err = decrypt.Data(data, s.format); err != nil {
+			return nil, fmt.Errorf(errWrapFormatWithID, ErrFileStoreDecrypt, path, err)
+		}
+	}
+
+	entries := map[string]interface{}{}
+	if s.format == fileStoreFormatJSON {
+		err = json.Unmarshal(data, &entries)
+	} else {
+		err = yaml.Unmarshal(data, &entries)
+	}
+	if err != nil {
+		return nil, fmt.Errorf(errWrapFormatWithID, ErrUnmarshalFile, path, err)
+	}
+
+	// SOPS metadata is not a key
+	if s.sops {
+		delete(entries, "sops")
+	}
+
+	return entries, nil
+}
+
+// write replaces a file with the given entries. The file is written to a temporary file first and renamed,
+// so that readers never see a partially written file.
+func (s *FileStore) write(path string, entries map[string]interface{}) error {
+	if !s.singleFile && len(entries) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf(errWrapFormatWithID, ErrWriteFile, path, err)
+		}
+		return nil
+	}
+
+	var data []byte
+	var err error
+	if s.format == fileStoreFormatJSON {
+		data, err = json.MarshalIndent(entries, "", "  ")
+	} else {
+		data, err = yaml.Marshal(entries)
+	}
+	if err != nil {
+		return fmt.Errorf(errWrapFormat, ErrMarshalValue, err)
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf(errWrapFormatWithID, ErrWriteFile, path, err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return fmt.Errorf(errWrapFormatWithID, ErrWriteFile, path, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf(errWrapFormatWithID, ErrWriteFile, path, err)
+	}
+
+	if err := os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf(errWrapFormatWithID, ErrWriteFile, path, err)
+	}
+	return nil
+}
+
+// update reads the entries of a file, applies fn to them, and writes them back. It holds the lock of the store, so that
+// concurrent Atmos processes (e.g. hooks running in parallel) don't overwrite each other's changes. Readers don't
+// need the lock, since files are replaced atomically.
+func (s *FileStore) update(path string, fn func(entries map[string]interface{}) error) error {
+	if s.sops {
+		return ErrFileStoreReadOnly
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf(errWrapFormatWithID, ErrWriteFile, path, err)
+	}
+
+	lock := flock.New(s.lockPath())
+	if err := lock.Lock(); err != nil {
+		return fmt.Errorf(errWrapFormatWithID, ErrFileStoreLock, path, err)
+	}
+	defer lock.Unlock()
+
+	entries, err := s.read(path)
+	if err != nil {
+		return err
+	}
+	if err := fn(entries); err != nil {
+		return err
+	}
+	return s.write(path, entries)
+}
+
+func (s *FileStore) Set(stack string, component string, key string, value interface{}) error {
+	if err := validateKeyArgs(stack, component, key); err != nil {
+		return err
+	}
+
+	path, keyPrefix, err := s.locate(stack, component)
+	if err != nil {
+		return err
+	}
+
+	// Values go through JSON, so that they are read back in the same shape as from the other stores
+	jsonData, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf(errWrapFormat, ErrSerializeJSON, err)
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(jsonData, &normalized); err != nil {
+		return fmt.Errorf(errWrapFormat, ErrSerializeJSON, err)
+	}
+
+	return s.update(path, func(entries map[string]interface{}) error {
+		entries[keyPrefix+key] = normalized
+		return nil
+	})
+}
+
+func (s *FileStore) Get(stack string, component string, key string) (interface{}, error) {
+	if err := validateKeyArgs(stack, component, key); err != nil {
+		return nil, err
+	}
+
+	path, keyPrefix, err := s.locate(stack, component)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.read(path)
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok := entries[keyPrefix+key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrResourceNotFound, keyPrefix+key)
+	}
+	return value, nil
+}
+
+func (s *FileStore) Delete(stack string, component string, key string) error {
+	if err := validateKeyArgs(stack, component, key); err != nil {
+		return err
+	}
+
+	path, keyPrefix, err := s.locate(stack, component)
+	if err != nil {
+		return err
+	}
+
+	return s.update(path, func(entries map[string]interface{}) error {
+		if _, ok := entries[keyPrefix+key]; !ok {
+			return fmt.Errorf("%w: %s", ErrResourceNotFound, keyPrefix+key)
+		}
+		delete(entries, keyPrefix+key)
+		return nil
+	})
+}
+
+func (s *FileStore) List(stack string, component string) ([]string, error) {
+	if err := validateStackAndComponent(stack, component); err != nil {
+		return nil, err
+	}
+
+	path, keyPrefix, err := s.locate(stack, component)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.read(path)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []string{}
+	for k := range entries {
+		if strings.HasPrefix(k, keyPrefix) {
+			keys = append(keys, strings.TrimPrefix(k, keyPrefix))
+		}
+	}
+
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (s *FileStore) Exists(stack string, component string, key string) (bool, error) {
+	_, err := s.Get(stack, component, key)
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, ErrResourceNotFound) {
+		return false, nil
+	}
+	return false, err
+}
@@ -0,0 +1,345 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"filippo.io/age"
+	"github.com/getsops/sops/v3"
+	"github.com/getsops/sops/v3/aes"
+	sopsage "github.com/getsops/sops/v3/age"
+	"github.com/getsops/sops/v3/config"
+	sopsyaml "github.com/getsops/sops/v3/stores/yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestNewFileStore(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name           string
+		options        FileStoreOptions
+		wantSingleFile bool
+		wantFormat     string
+		wantErr        error
+	}{
+		{name: "directory", options: FileStoreOptions{Path: dir}, wantFormat: "yaml"},
+		{name: "directory json", options: FileStoreOptions{Path: dir, Format: ptr("json")}, wantFormat: "json"},
+		{name: "yaml file", options: FileStoreOptions{Path: filepath.Join(dir, "store.yml")}, wantSingleFile: true, wantFormat: "yaml"},
+		{name: "json file", options: FileStoreOptions{Path: filepath.Join(dir, "store.json")}, wantSingleFile: true, wantFormat: "json"},
+		{name: "missing path", options: FileStoreOptions{}, wantErr: ErrFileStorePathRequired},
+		{name: "invalid format", options: FileStoreOptions{Path: dir, Format: ptr("toml")}, wantErr: ErrFileStoreInvalidFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewFileStore(tt.options)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			fileStore, ok := s.(*FileStore)
+			require.True(t, ok)
+			assert.Equal(t, tt.wantSingleFile, fileStore.singleFile)
+			assert.Equal(t, tt.wantFormat, fileStore.format)
+		})
+	}
+}
+
+func TestFileStore_Operations(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		options FileStoreOptions
+		// file is where the keys of the `plat-ue2-dev` stack and `vpc` component are expected to be written
+		file string
+	}{
+		{
+			name:    "directory",
+			options: FileStoreOptions{Path: filepath.Join(dir, "tree"), StackDelimiter: ptr("-")},
+			file:    filepath.Join(dir, "tree", "plat", "ue2", "dev", "vpc.yaml"),
+		},
+		{
+			name:    "directory json",
+			options: FileStoreOptions{Path: filepath.Join(dir, "tree-json"), Prefix: ptr("atmos"), StackDelimiter: ptr("-"), Format: ptr("json")},
+			file:    filepath.Join(dir, "tree-json", "atmos", "plat", "ue2", "dev", "vpc.json"),
+		},
+		{
+			name:    "yaml file",
+			options: FileStoreOptions{Path: filepath.Join(dir, "store.yaml"), StackDelimiter: ptr("-")},
+			file:    filepath.Join(dir, "store.yaml"),
+		},
+		{
+			name:    "json file",
+			options: FileStoreOptions{Path: filepath.Join(dir, "store.json"), StackDelimiter: ptr("-")},
+			file:    filepath.Join(dir, "store.json"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewFileStore(tt.options)
+			require.NoError(t, err)
+
+			require.NoError(t, s.Set("plat-ue2-dev", "vpc", "vpc_id", "vpc-123"))
+			require.NoError(t, s.Set("plat-ue2-dev", "vpc", "subnets", []string{"a", "b"}))
+			require.NoError(t, s.Set("plat-ue2-dev", "vpc", "tags", map[string]string{"env": "dev"}))
+			require.NoError(t, s.Set("plat-ue2-prod", "vpc", "vpc_id", "vpc-456"))
+			assert.FileExists(t, tt.file)
+
+			value, err := s.Get("plat-ue2-dev", "vpc", "vpc_id")
+			require.NoError(t, err)
+			assert.Equal(t, "vpc-123", value)
+
+			value, err = s.Get("plat-ue2-dev", "vpc", "subnets")
+			require.NoError(t, err)
+			assert.Equal(t, []interface{}{"a", "b"}, value)
+
+			value, err = s.Get("plat-ue2-dev", "vpc", "tags")
+			require.NoError(t, err)
+			assert.Equal(t, map[string]interface{}{"env": "dev"}, value)
+
+			value, err = s.Get("plat-ue2-prod", "vpc", "vpc_id")
+			require.NoError(t, err)
+			assert.Equal(t, "vpc-456", value)
+
+			_, err = s.Get("plat-ue2-dev", "vpc", "missing")
+			assert.ErrorIs(t, err, ErrResourceNotFound)
+
+			keys, err := s.List("plat-ue2-dev", "vpc")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"subnets", "tags", "vpc_id"}, keys)
+
+			exists, err := s.Exists("plat-ue2-dev", "vpc", "vpc_id")
+			require.NoError(t, err)
+			assert.True(t, exists)
+
+			require.NoError(t, s.Delete("plat-ue2-dev", "vpc", "vpc_id"))
+			assert.ErrorIs(t, s.Delete("plat-ue2-dev", "vpc", "vpc_id"), ErrResourceNotFound)
+
+			exists, err = s.Exists("plat-ue2-dev", "vpc", "vpc_id")
+			require.NoError(t, err)
+			assert.False(t, exists)
+
+			keys, err = s.List("plat-ue2-dev", "vpc")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"subnets", "tags"}, keys)
+
+			keys, err = s.List("plat-ue2-staging", "vpc")
+			require.NoError(t, err)
+			assert.Empty(t, keys)
+		})
+	}
+}
+
+func TestFileStore_SingleFileUsesKeyPaths(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.json")
+
+	s, err := NewFileStore(FileStoreOptions{Path: path, Prefix: ptr("atmos"), StackDelimiter: ptr("-")})
+	require.NoError(t, err)
+	require.NoError(t, s.Set("plat-ue2-dev", "eks/cluster", "name", "dev"))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var entries map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &entries))
+	assert.Equal(t, map[string]interface{}{"atmos/plat/ue2/dev/eks/cluster/name": "dev"}, entries)
+}
+
+func TestFileStore_ConcurrentWrites(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.yaml")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			// Each writer has its own store, like concurrent Atmos processes
+			s, err := NewFileStore(FileStoreOptions{Path: path})
+			assert.NoError(t, err)
+			assert.NoError(t, s.Set("dev", "app", fmt.Sprintf("key%02d", i), i))
+		}(i)
+	}
+	wg.Wait()
+
+	s, err := NewFileStore(FileStoreOptions{Path: path})
+	require.NoError(t, err)
+
+	keys, err := s.List("dev", "app")
+	require.NoError(t, err)
+	assert.Len(t, keys, 20)
+}
+
+func TestFileStore_DeleteRemovesEmptyFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	s, err := NewFileStore(FileStoreOptions{Path: dir})
+	require.NoError(t, err)
+
+	require.NoError(t, s.Set("dev", "app", "key", "value"))
+	assert.FileExists(t, filepath.Join(dir, "dev", "app.yaml"))
+
+	require.NoError(t, s.Delete("dev", "app", "key"))
+	assert.NoFileExists(t, filepath.Join(dir, "dev", "app.yaml"))
+}
+
+func TestFileStore_RejectsPathsOutsideTheDirectory(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "store")
+
+	s, err := NewFileStore(FileStoreOptions{Path: dir, StackDelimiter: ptr("-")})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		stack     string
+		component string
+	}{
+		{name: "component with parent segments", stack: "dev", component: "../../x"},
+		{name: "stack with parent segments", stack: "..-..-x", component: "app"},
+		{name: "absolute component", stack: "dev", component: "/tmp/x"},
+		{name: "component with backslashes", stack: "dev", component: `..\..\x`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, s.Set(tt.stack, tt.component, "key", "value"), ErrFileStoreInvalidPath)
+
+			_, err := s.Get(tt.stack, tt.component, "key")
+			assert.ErrorIs(t, err, ErrFileStoreInvalidPath)
+		})
+	}
+
+	// Nothing was written next to the store directory
+	entries, err := os.ReadDir(filepath.Dir(dir))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestFileStore_LockFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	s, err := NewFileStore(FileStoreOptions{Path: dir})
+	require.NoError(t, err)
+	require.NoError(t, s.Set("dev", "app", "key", "value"))
+	require.NoError(t, s.Set("prod", "eks/cluster", "key", "value"))
+
+	// A directory store has one lock file in its root
+	assert.FileExists(t, filepath.Join(dir, fileStoreLockFile))
+	assert.NoFileExists(t, filepath.Join(dir, "dev", "app.yaml.lock"))
+	assert.NoFileExists(t, filepath.Join(dir, "prod", "eks", "cluster.yaml.lock"))
+
+	// A single file store is locked with a file next to it
+	path := filepath.Join(t.TempDir(), "store.yaml")
+	s, err = NewFileStore(FileStoreOptions{Path: path})
+	require.NoError(t, err)
+	require.NoError(t, s.Set("dev", "app", "key", "value"))
+	assert.FileExists(t, path+".lock")
+}
+
+// writeSopsFile encrypts the entries with sops for the age identity and writes them to a YAML file.
+func writeSopsFile(t *testing.T, path string, identity *age.X25519Identity, entries map[string]interface{}) {
+	plain, err := yaml.Marshal(entries)
+	require.NoError(t, err)
+
+	store := sopsyaml.NewStore(&config.YAMLStoreConfig{})
+	branches, err := store.LoadPlainFile(plain)
+	require.NoError(t, err)
+
+	masterKey, err := sopsage.MasterKeyFromRecipient(identity.Recipient().String())
+	require.NoError(t, err)
+
+	tree := sops.Tree{
+		Branches: branches,
+		Metadata: sops.Metadata{
+			KeyGroups: []sops.KeyGroup{{masterKey}},
+			Version:   "3.9.4",
+		},
+	}
+
+	dataKey, errs := tree.GenerateDataKey()
+	require.Empty(t, errs)
+
+	cipher := aes.NewCipher()
+	mac, err := tree.Encrypt(dataKey, cipher)
+	require.NoError(t, err)
+	tree.Metadata.LastModified = time.Now().UTC()
+	tree.Metadata.MessageAuthenticationCode, err = cipher.Encrypt(mac, dataKey, tree.Metadata.LastModified.Format(time.RFC3339))
+	require.NoError(t, err)
+
+	encrypted, err := store.EmitEncryptedFile(tree)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, encrypted, 0o600))
+}
+
+func TestFileStore_Sops(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "secrets.yaml")
+	writeSopsFile(t, path, identity, map[string]interface{}{
+		"plat/ue2/dev/db/password": "s3cr3t",
+		"plat/ue2/dev/db/port":     5432,
+	})
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "s3cr3t")
+
+	s, err := NewFileStore(FileStoreOptions{Path: path, StackDelimiter: ptr("-"), Sops: true})
+	require.NoError(t, err)
+
+	t.Run("without key", func(t *testing.T) {
+		t.Setenv("SOPS_AGE_KEY_FILE", filepath.Join(dir, "missing.txt"))
+		t.Setenv("XDG_CONFIG_HOME", dir)
+
+		_, err := s.Get("plat-ue2-dev", "db", "password")
+		assert.ErrorIs(t, err, ErrFileStoreDecrypt)
+	})
+
+	t.Run("with key", func(t *testing.T) {
+		t.Setenv("SOPS_AGE_KEY", identity.String())
+
+		value, err := s.Get("plat-ue2-dev", "db", "password")
+		require.NoError(t, err)
+		assert.Equal(t, "s3cr3t", value)
+
+		value, err = s.Get("plat-ue2-dev", "db", "port")
+		require.NoError(t, err)
+		assert.Equal(t, 5432, value)
+
+		keys, err := s.List("plat-ue2-dev", "db")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"password", "port"}, keys)
+	})
+
+	t.Run("read only", func(t *testing.T) {
+		assert.ErrorIs(t, s.Set("plat-ue2-dev", "db", "user", "admin"), ErrFileStoreReadOnly)
+		assert.ErrorIs(t, s.Delete("plat-ue2-dev", "db", "password"), ErrFileStoreReadOnly)
+	})
+}
+
+func TestNewStoreRegistryWithFileStore(t *testing.T) {
+	config := StoresConfig{
+		"local": {
+			Type:    "file",
+			Options: map[string]interface{}{"path": t.TempDir(), "stack_delimiter": "-"},
+		},
+	}
+
+	registry, err := NewStoreRegistry(&config)
+	require.NoError(t, err)
+	assert.IsType(t, &FileStore{}, registry["local"])
+}
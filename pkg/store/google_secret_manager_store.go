@@ -3,13 +3,16 @@ package store
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	secretmanager "cloud.google.com/go/secretmanager/apiv1"
 	secretmanagerpb "cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
 	"github.com/googleapis/gax-go/v2"
+	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -25,9 +28,26 @@ type GSMClient interface {
 	CreateSecret(ctx context.Context, req *secretmanagerpb.CreateSecretRequest, opts ...gax.CallOption) (*secretmanagerpb.Secret, error)
 	AddSecretVersion(ctx context.Context, req *secretmanagerpb.AddSecretVersionRequest, opts ...gax.CallOption) (*secretmanagerpb.SecretVersion, error)
 	AccessSecretVersion(ctx context.Context, req *secretmanagerpb.AccessSecretVersionRequest, opts ...gax.CallOption) (*secretmanagerpb.AccessSecretVersionResponse, error)
+	DeleteSecret(ctx context.Context, req *secretmanagerpb.DeleteSecretRequest, opts ...gax.CallOption) error
+	ListSecrets(ctx context.Context, req *secretmanagerpb.ListSecretsRequest, opts ...gax.CallOption) GSMSecretIterator
 	Close() error
 }
 
+// GSMSecretIterator is the interface that wraps the iterator returned when listing secrets.
+type GSMSecretIterator interface {
+	Next() (*secretmanagerpb.Secret, error)
+}
+
+// gsmClient adapts the Google Secret Manager client to the GSMClient interface.
+type gsmClient struct {
+	*secretmanager.Client
+}
+
+// ListSecrets lists the secrets of a project.
+func (c *gsmClient) ListSecrets(ctx context.Context, req *secretmanagerpb.ListSecretsRequest, opts ...gax.CallOption) GSMSecretIterator {
+	return c.Client.ListSecrets(ctx, req, opts...)
+}
+
 // GSMStore is an implementation of the Store interface for Google Secret Manager.
 type GSMStore struct {
 	client         GSMClient
@@ -72,7 +92,7 @@ func NewGSMStore(options GSMStoreOptions) (Store, error) {
 	}
 
 	store := &GSMStore{
-		client:    client,
+		client:    &gsmClient{Client: client},
 		projectID: options.ProjectID,
 	}
 
@@ -279,3 +299,90 @@ func (s *GSMStore) Get(stack string, component string, key string) (any, error)
 	}
 	return unmarshalled, nil
 }
+
+// Delete removes a key and all of its versions from Google Secret Manager.
+func (s *GSMStore) Delete(stack string, component string, key string) error {
+	if err := validateKeyArgs(stack, component, key); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), gsmOperationTimeout)
+	defer cancel()
+
+	secretID, err := s.getKey(stack, component, key)
+	if err != nil {
+		return fmt.Errorf(errWrapFormat, ErrGetKey, err)
+	}
+
+	name := fmt.Sprintf("projects/%s/secrets/%s", s.projectID, secretID)
+	if err := s.client.DeleteSecret(ctx, &secretmanagerpb.DeleteSecretRequest{Name: name}); err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.NotFound:
+				return fmt.Errorf(errWrapFormatWithID, ErrResourceNotFound, secretID, err)
+			case codes.PermissionDenied:
+				return fmt.Errorf(errWrapFormatWithID, ErrPermissionDenied, fmt.Sprintf("secret %s", secretID), err)
+			}
+		}
+		return fmt.Errorf(errWrapFormat, ErrDeleteParameter, err)
+	}
+
+	return nil
+}
+
+// List returns the keys stored in Google Secret Manager for a stack and component.
+func (s *GSMStore) List(stack string, component string) ([]string, error) {
+	if err := validateStackAndComponent(stack, component); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), gsmOperationTimeout)
+	defer cancel()
+
+	// The secret ID prefix is derived from the ID of a placeholder key, since the IDs are normalized.
+	placeholderID, err := s.getKey(stack, component, gsmKeySeparator)
+	if err != nil {
+		return nil, fmt.Errorf(errWrapFormat, ErrGetKey, err)
+	}
+	idPrefix := placeholderID + gsmKeySeparator
+
+	it := s.client.ListSecrets(ctx, &secretmanagerpb.ListSecretsRequest{
+		Parent: fmt.Sprintf("projects/%s", s.projectID),
+		Filter: fmt.Sprintf("name:%s", idPrefix),
+	})
+
+	keys := []string{}
+	for {
+		secret, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			if st, ok := status.FromError(err); ok && st.Code() == codes.PermissionDenied {
+				return nil, fmt.Errorf(errWrapFormatWithID, ErrPermissionDenied, fmt.Sprintf("project %s", s.projectID), err)
+			}
+			return nil, fmt.Errorf(errWrapFormat, ErrListParameters, err)
+		}
+
+		secretID := secret.GetName()[strings.LastIndex(secret.GetName(), "/")+1:]
+		if strings.HasPrefix(secretID, idPrefix) {
+			keys = append(keys, strings.TrimPrefix(secretID, idPrefix))
+		}
+	}
+
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// Exists checks if a key exists in Google Secret Manager.
+func (s *GSMStore) Exists(stack string, component string, key string) (bool, error) {
+	_, err := s.Get(stack, component, key)
+	if err == nil {
+		return true, nil
+	}
+
+	if errors.Is(err, ErrResourceNotFound) {
+		return false, nil
+	}
+	return false, err
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/googleapis/gax-go/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/api/iterator"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -52,6 +53,34 @@ func (m *MockGSMClient) AccessSecretVersion(ctx context.Context, req *secretmana
 	return args.Get(0).(*secretmanagerpb.AccessSecretVersionResponse), args.Error(1)
 }
 
+func (m *MockGSMClient) DeleteSecret(ctx context.Context, req *secretmanagerpb.DeleteSecretRequest, opts ...gax.CallOption) error {
+	args := m.Called(mock.Anything, req)
+	return args.Error(0)
+}
+
+func (m *MockGSMClient) ListSecrets(ctx context.Context, req *secretmanagerpb.ListSecretsRequest, opts ...gax.CallOption) GSMSecretIterator {
+	args := m.Called(mock.Anything, req)
+	return args.Get(0).(GSMSecretIterator)
+}
+
+// mockGSMSecretIterator is a mock implementation of the GSMSecretIterator interface.
+type mockGSMSecretIterator struct {
+	names []string
+	err   error
+}
+
+func (it *mockGSMSecretIterator) Next() (*secretmanagerpb.Secret, error) {
+	if it.err != nil {
+		return nil, it.err
+	}
+	if len(it.names) == 0 {
+		return nil, iterator.Done
+	}
+	name := it.names[0]
+	it.names = it.names[1:]
+	return &secretmanagerpb.Secret{Name: name}, nil
+}
+
 func (m *MockGSMClient) Close() error {
 	args := m.Called()
 	return args.Error(0)
@@ -600,3 +629,72 @@ func TestGSMStore_GetKey(t *testing.T) {
 		})
 	}
 }
+
+func TestGSMStore_Delete(t *testing.T) {
+	mockClient := new(MockGSMClient)
+	mockClient.On("DeleteSecret", mock.Anything, &secretmanagerpb.DeleteSecretRequest{
+		Name: "projects/test-project/secrets/prefix_dev_app_key",
+	}).Return(nil)
+	mockClient.On("DeleteSecret", mock.Anything, &secretmanagerpb.DeleteSecretRequest{
+		Name: "projects/test-project/secrets/prefix_dev_app_missing",
+	}).Return(status.Error(codes.NotFound, "not found"))
+
+	store := newGSMStoreWithClient(mockClient, GSMStoreOptions{
+		ProjectID: "test-project",
+		Prefix:    stringPtr("prefix"),
+	})
+
+	assert.NoError(t, store.Delete("dev", "app", "key"))
+	assert.ErrorIs(t, store.Delete("dev", "app", "missing"), ErrResourceNotFound)
+	assert.ErrorIs(t, store.Delete("dev", "", "key"), ErrEmptyComponent)
+}
+
+func TestGSMStore_List(t *testing.T) {
+	mockClient := new(MockGSMClient)
+	mockClient.On("ListSecrets", mock.Anything, &secretmanagerpb.ListSecretsRequest{
+		Parent: "projects/test-project",
+		Filter: "name:prefix_dev_us2_app_",
+	}).Return(&mockGSMSecretIterator{names: []string{
+		"projects/test-project/secrets/prefix_dev_us2_app_vpc_id",
+		"projects/test-project/secrets/prefix_dev_us2_app_cidr",
+		"projects/test-project/secrets/other_prefix_dev_us2_app_cidr",
+	}})
+
+	store := newGSMStoreWithClient(mockClient, GSMStoreOptions{
+		ProjectID: "test-project",
+		Prefix:    stringPtr("prefix"),
+	})
+
+	keys, err := store.List("dev-us2", "app")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cidr", "vpc_id"}, keys)
+
+	mockClient = new(MockGSMClient)
+	mockClient.On("ListSecrets", mock.Anything, mock.Anything).
+		Return(&mockGSMSecretIterator{err: status.Error(codes.PermissionDenied, "denied")})
+	store = newGSMStoreWithClient(mockClient, GSMStoreOptions{ProjectID: "test-project"})
+
+	_, err = store.List("dev", "app")
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+}
+
+func TestGSMStore_Exists(t *testing.T) {
+	mockClient := new(MockGSMClient)
+	mockClient.On("AccessSecretVersion", mock.Anything, &secretmanagerpb.AccessSecretVersionRequest{
+		Name: "projects/test-project/secrets/dev_app_present/versions/latest",
+	}).Return(&secretmanagerpb.AccessSecretVersionResponse{
+		Payload: &secretmanagerpb.SecretPayload{Data: []byte(`"value"`)},
+	}, nil)
+	mockClient.On("AccessSecretVersion", mock.Anything, mock.Anything).
+		Return(nil, status.Error(codes.NotFound, "not found"))
+
+	store := newGSMStoreWithClient(mockClient, GSMStoreOptions{ProjectID: "test-project"})
+
+	exists, err := store.Exists("dev", "app", "present")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = store.Exists("dev", "app", "missing")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
@@ -3,8 +3,10 @@ package store
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -31,6 +33,9 @@ type RedisStoreOptions struct {
 type RedisClient interface {
 	Get(ctx context.Context, key string) *redis.StringCmd
 	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd
+	Del(ctx context.Context, keys ...string) *redis.IntCmd
+	Exists(ctx context.Context, keys ...string) *redis.IntCmd
+	Scan(ctx context.Context, cursor uint64, match string, count int64) *redis.ScanCmd
 }
 
 // Ensure RedisStore implements the store.Store interface.
@@ -109,6 +114,9 @@ func (s *RedisStore) Get(stack string, component string, key string) (interface{
 
 	ctx := context.Background()
 	jsonData, err := s.redisClient.Get(ctx, paramName).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("%w: %w: %s", ErrGetRedisKey, ErrResourceNotFound, paramName)
+	}
 	if err != nil {
 		return nil, fmt.Errorf(errFormat, ErrGetRedisKey, err)
 	}
@@ -152,3 +160,98 @@ func (s *RedisStore) Set(stack string, component string, key string, value inter
 
 	return err
 }
+
+// Delete removes a key from Redis.
+func (s *RedisStore) Delete(stack string, component string, key string) error {
+	if err := validateKeyArgs(stack, component, key); err != nil {
+		return err
+	}
+
+	paramName, err := s.getKey(stack, component, key)
+	if err != nil {
+		return fmt.Errorf(errFormat, ErrGetKey, err)
+	}
+
+	ctx := context.Background()
+	deleted, err := s.redisClient.Del(ctx, paramName).Result()
+	if err != nil {
+		return fmt.Errorf(errFormat, ErrDeleteParameter, err)
+	}
+
+	if deleted == 0 {
+		return fmt.Errorf("%w: %s", ErrResourceNotFound, paramName)
+	}
+
+	return nil
+}
+
+// List returns the keys stored in Redis for a stack and component.
+func (s *RedisStore) List(stack string, component string) ([]string, error) {
+	if err := validateStackAndComponent(stack, component); err != nil {
+		return nil, err
+	}
+
+	if s.stackDelimiter == nil {
+		return nil, ErrStackDelimiterNotSet
+	}
+
+	keyPrefix, err := getKeyPrefix(s.prefix, *s.stackDelimiter, stack, component, "/")
+	if err != nil {
+		return nil, fmt.Errorf(errFormat, ErrGetKey, err)
+	}
+
+	ctx := context.Background()
+	keys := []string{}
+	var cursor uint64
+	for {
+		page, nextCursor, err := s.redisClient.Scan(ctx, cursor, escapeRedisPattern(keyPrefix)+"*", 0).Result()
+		if err != nil {
+			return nil, fmt.Errorf(errFormat, ErrListParameters, err)
+		}
+
+		for _, k := range page {
+			keys = append(keys, strings.TrimPrefix(k, keyPrefix))
+		}
+
+		cursor = nextCursor
+		if cursor == 0 {
+			break
+		}
+	}
+
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// escapeRedisPattern escapes the glob metacharacters in a string, so that it matches itself in a SCAN pattern.
+func escapeRedisPattern(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '*', '?', '[', ']', '\\':
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Exists checks if a key exists in Redis.
+func (s *RedisStore) Exists(stack string, component string, key string) (bool, error) {
+	if err := validateKeyArgs(stack, component, key); err != nil {
+		return false, err
+	}
+
+	paramName, err := s.getKey(stack, component, key)
+	if err != nil {
+		return false, fmt.Errorf(errFormat, ErrGetKey, err)
+	}
+
+	ctx := context.Background()
+	count, err := s.redisClient.Exists(ctx, paramName).Result()
+	if err != nil {
+		return false, fmt.Errorf(errFormat, ErrGetRedisKey, err)
+	}
+
+	return count > 0, nil
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/alicebob/miniredis/v2"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -28,6 +29,21 @@ func (m *MockRedisClient) Set(ctx context.Context, key string, value interface{}
 	return cmd
 }
 
+func (m *MockRedisClient) Del(ctx context.Context, keys ...string) *redis.IntCmd {
+	args := m.Called(ctx, keys)
+	return redis.NewIntResult(int64(args.Int(0)), args.Error(1))
+}
+
+func (m *MockRedisClient) Exists(ctx context.Context, keys ...string) *redis.IntCmd {
+	args := m.Called(ctx, keys)
+	return redis.NewIntResult(int64(args.Int(0)), args.Error(1))
+}
+
+func (m *MockRedisClient) Scan(ctx context.Context, cursor uint64, match string, count int64) *redis.ScanCmd {
+	args := m.Called(ctx, cursor, match, count)
+	return redis.NewScanCmdResult(args.Get(0).([]string), uint64(args.Int(1)), args.Error(2))
+}
+
 func ptr(s string) *string {
 	return &s
 }
@@ -133,6 +149,7 @@ func TestRedisStore_Get_KeyNotFound(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, result)
 	assert.Contains(t, err.Error(), "failed to get key")
+	assert.ErrorIs(t, err, ErrResourceNotFound)
 	mockClient.AssertExpectations(t)
 }
 
@@ -336,3 +353,80 @@ func TestRedisStore_Get_GetKeyError(t *testing.T) {
 	assert.Contains(t, err.Error(), "failed to get key")
 	mockClient.AssertExpectations(t)
 }
+
+func TestRedisStore_DeleteListExists(t *testing.T) {
+	server := miniredis.RunT(t)
+
+	store, err := NewRedisStore(RedisStoreOptions{
+		Prefix:         ptr("atmos"),
+		StackDelimiter: ptr("-"),
+		URL:            ptr("redis://" + server.Addr()),
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, store.Set("dev-ue2", "vpc", "vpc_id", "vpc-123"))
+	assert.NoError(t, store.Set("dev-ue2", "vpc", "cidr", "10.0.0.0/16"))
+	assert.NoError(t, store.Set("dev-ue2", "other", "cidr", "10.1.0.0/16"))
+
+	keys, err := store.List("dev-ue2", "vpc")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cidr", "vpc_id"}, keys)
+
+	exists, err := store.Exists("dev-ue2", "vpc", "vpc_id")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	assert.NoError(t, store.Delete("dev-ue2", "vpc", "vpc_id"))
+	assert.ErrorIs(t, store.Delete("dev-ue2", "vpc", "vpc_id"), ErrResourceNotFound)
+
+	exists, err = store.Exists("dev-ue2", "vpc", "vpc_id")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	_, err = store.List("dev-ue2", "")
+	assert.ErrorIs(t, err, ErrEmptyComponent)
+}
+
+func TestRedisStore_List_EscapesPattern(t *testing.T) {
+	server := miniredis.RunT(t)
+
+	store, err := NewRedisStore(RedisStoreOptions{
+		Prefix:         ptr("atmos"),
+		StackDelimiter: ptr("-"),
+		URL:            ptr("redis://" + server.Addr()),
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, store.Set("dev", "app[1]", "key", "value"))
+	assert.NoError(t, store.Set("dev", "app1", "other", "value"))
+	assert.NoError(t, store.Set("dev", "app*", "key", "value"))
+	assert.NoError(t, store.Set("dev", "appx", "other", "value"))
+
+	keys, err := store.List("dev", "app[1]")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"key"}, keys)
+
+	keys, err = store.List("dev", "app*")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"key"}, keys)
+}
+
+func TestEscapeRedisPattern(t *testing.T) {
+	assert.Equal(t, "atmos/dev/vpc/", escapeRedisPattern("atmos/dev/vpc/"))
+	assert.Equal(t, `a\*b\?c\[d\]e\\f`, escapeRedisPattern(`a*b?c[d]e\f`))
+}
+
+func TestRedisStore_List_ScanError(t *testing.T) {
+	mockClient := new(MockRedisClient)
+	mockClient.On("Scan", mock.Anything, uint64(0), "atmos/dev/vpc/*", int64(0)).
+		Return([]string{}, 0, redis.ErrClosed)
+
+	store := &RedisStore{
+		prefix:         "atmos",
+		redisClient:    mockClient,
+		stackDelimiter: ptr("/"),
+	}
+
+	_, err := store.List("dev", "vpc")
+	assert.ErrorIs(t, err, ErrListParameters)
+}
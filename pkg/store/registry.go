@@ -6,7 +6,17 @@ import (
 
 type StoreRegistry map[string]Store
 
+// RegistryOptions controls how the stores in a registry are created.
+type RegistryOptions struct {
+	// RefreshCache bypasses the caches of the stores when reading, and refreshes them with the values read.
+	RefreshCache bool
+}
+
 func NewStoreRegistry(config *StoresConfig) (StoreRegistry, error) {
+	return NewStoreRegistryWithOptions(config, RegistryOptions{})
+}
+
+func NewStoreRegistryWithOptions(config *StoresConfig, options RegistryOptions) (StoreRegistry, error) {
 	registry := make(StoreRegistry)
 	for key, storeConfig := range *config {
 		switch storeConfig.Type {
@@ -46,6 +56,30 @@ func NewStoreRegistry(config *StoresConfig) (StoreRegistry, error) {
 			}
 			registry[key] = store
 
+		case "aws-secrets-manager":
+			var opts SecretsManagerStoreOptions
+			if err := parseOptions(storeConfig.Options, &opts); err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrParseSecretsManagerOpts, err)
+			}
+
+			store, err := NewSecretsManagerStore(opts)
+			if err != nil {
+				return nil, err
+			}
+			registry[key] = store
+
+		case "file":
+			var opts FileStoreOptions
+			if err := parseOptions(storeConfig.Options, &opts); err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrParseFileStoreOptions, err)
+			}
+
+			store, err := NewFileStore(opts)
+			if err != nil {
+				return nil, err
+			}
+			registry[key] = store
+
 		case "google-secret-manager", "gsm":
 			var opts GSMStoreOptions
 			if err := parseOptions(storeConfig.Options, &opts); err != nil {
@@ -70,10 +104,55 @@ func NewStoreRegistry(config *StoresConfig) (StoreRegistry, error) {
 			}
 			registry[key] = store
 
+		case "vault":
+			var opts VaultStoreOptions
+			if err := parseOptions(storeConfig.Options, &opts); err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrParseVaultOptions, err)
+			}
+
+			store, err := NewVaultStore(opts)
+			if err != nil {
+				return nil, err
+			}
+			registry[key] = store
+
 		default:
 			return nil, fmt.Errorf("%w: %s", ErrStoreTypeNotFound, storeConfig.Type)
 		}
+
+		if storeConfig.Cache != nil {
+			// The values of the secret backends are only cached on disk if they are encrypted, or if it's explicitly allowed
+			if storeConfig.Cache.Storage == CacheStorageDisk && storeConfig.Encryption == nil &&
+				!storeConfig.Cache.AllowPlaintext && isSecretStoreType(storeConfig.Type) {
+				return nil, fmt.Errorf("%w: configure `encryption` for the store `%s`, or set `cache.allow_plaintext: true`", ErrPlaintextSecretCache, key)
+			}
+
+			store, err := NewCachedStore(key, BackendID(storeConfig.Type, storeConfig.Options), registry[key], *storeConfig.Cache, options.RefreshCache)
+			if err != nil {
+				return nil, err
+			}
+			registry[key] = store
+		}
+
+		// Encryption wraps the cache, so that values are cached encrypted
+		if storeConfig.Encryption != nil {
+			store, err := NewEncryptedStore(registry[key], *storeConfig.Encryption)
+			if err != nil {
+				return nil, err
+			}
+			registry[key] = store
+		}
 	}
 
 	return registry, nil
 }
+
+// isSecretStoreType returns true if the stores of the type hold secrets.
+func isSecretStoreType(storeType string) bool {
+	switch storeType {
+	case "aws-secrets-manager", "aws-ssm-parameter-store", "azure-key-vault", "google-secret-manager", "gsm", "vault":
+		return true
+	default:
+		return false
+	}
+}
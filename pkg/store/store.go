@@ -6,6 +6,9 @@ import "strings"
 type Store interface {
 	Set(stack string, component string, key string, value interface{}) error
 	Get(stack string, component string, key string) (interface{}, error)
+	Delete(stack string, component string, key string) error
+	List(stack string, component string) ([]string, error)
+	Exists(stack string, component string, key string) (bool, error)
 }
 
 // StoreFactory is a function type to initialize a new store.
@@ -29,3 +32,31 @@ func getKey(prefix string, stackDelimiter string, stack string, component string
 
 	return finalKey, nil
 }
+
+// getKeyPrefix generates the prefix shared by all keys of a stack and component. The returned prefix ends with the
+// final delimiter, so that the key names can be derived by trimming it from the full keys.
+func getKeyPrefix(prefix string, stackDelimiter string, stack string, component string, finalDelimiter string) (string, error) {
+	return getKey(prefix, stackDelimiter, stack, component, "", finalDelimiter)
+}
+
+// validateStackAndComponent checks the arguments shared by all store operations.
+func validateStackAndComponent(stack string, component string) error {
+	if stack == "" {
+		return ErrEmptyStack
+	}
+	if component == "" {
+		return ErrEmptyComponent
+	}
+	return nil
+}
+
+// validateKeyArgs checks the arguments of the store operations that address a single key.
+func validateKeyArgs(stack string, component string, key string) error {
+	if err := validateStackAndComponent(stack, component); err != nil {
+		return err
+	}
+	if key == "" {
+		return ErrEmptyKey
+	}
+	return nil
+}
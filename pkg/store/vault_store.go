@@ -0,0 +1,386 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	vault "github.com/hashicorp/vault/api"
+	"github.com/hashicorp/vault/api/auth/approle"
+)
+
+const (
+	vaultOperationTimeout = 30 * time.Second
+	vaultDefaultMount     = "secret"
+	vaultDefaultAppRole   = "approle"
+	// VaultValueField is the field of the KV v2 secret data that holds the JSON-encoded value.
+	VaultValueField = "value"
+
+	vaultAuthMethodToken   = "token"
+	vaultAuthMethodAppRole = "approle"
+)
+
+// VaultKVClient interface allows us to mock the HashiCorp Vault KV v2 client.
+type VaultKVClient interface {
+	Put(ctx context.Context, secretPath string, data map[string]interface{}, opts ...vault.KVOption) (*vault.KVSecret, error)
+	Get(ctx context.Context, secretPath string) (*vault.KVSecret, error)
+	GetVersion(ctx context.Context, secretPath string, version int) (*vault.KVSecret, error)
+	DeleteMetadata(ctx context.Context, secretPath string) error
+}
+
+// VaultLogicalClient interface allows us to mock the HashiCorp Vault logical client used to list secrets,
+// which the KV v2 client does not support.
+type VaultLogicalClient interface {
+	ListWithContext(ctx context.Context, path string) (*vault.Secret, error)
+}
+
+// VaultStore is an implementation of the Store interface for the HashiCorp Vault KV v2 secrets engine.
+type VaultStore struct {
+	client         VaultKVClient
+	logical        VaultLogicalClient
+	mount          string
+	prefix         string
+	stackDelimiter *string
+}
+
+// VaultStoreOptions defines the configuration options for the HashiCorp Vault store.
+type VaultStoreOptions struct {
+	Address        *string `mapstructure:"address"`   // Falls back to VAULT_ADDR
+	Namespace      *string `mapstructure:"namespace"` // Falls back to VAULT_NAMESPACE
+	Mount          *string `mapstructure:"mount"`     // KV v2 mount path, defaults to `secret`
+	Prefix         *string `mapstructure:"prefix"`
+	StackDelimiter *string `mapstructure:"stack_delimiter"`
+	AuthMethod     *string `mapstructure:"auth_method"` // `token` (default) or `approle`
+	Token          *string `mapstructure:"token"`       // Falls back to VAULT_TOKEN
+	RoleID         *string `mapstructure:"role_id"`     // Falls back to VAULT_ROLE_ID
+	SecretID       *string `mapstructure:"secret_id"`   // Falls back to VAULT_SECRET_ID
+	AppRoleMount   *string `mapstructure:"approle_mount"`
+}
+
+// Ensure VaultStore implements the store.Store interface.
+var _ Store = (*VaultStore)(nil)
+
+// optionOrEnv returns the option value if it is set, otherwise the value of the environment variable.
+func optionOrEnv(option *string, envVar string) string {
+	if option != nil && *option != "" {
+		return *option
+	}
+	return os.Getenv(envVar)
+}
+
+// NewVaultStore initializes a new HashiCorp Vault Store.
+func NewVaultStore(options VaultStoreOptions) (Store, error) {
+	config := vault.DefaultConfig()
+	if config.Error != nil {
+		return nil, fmt.Errorf(errWrapFormat, ErrCreateClient, config.Error)
+	}
+
+	if address := optionOrEnv(options.Address, "VAULT_ADDR"); address != "" {
+		config.Address = address
+	} else {
+		return nil, ErrVaultAddressRequired
+	}
+
+	client, err := vault.NewClient(config)
+	if err != nil {
+		return nil, fmt.Errorf(errWrapFormat, ErrCreateClient, err)
+	}
+
+	if namespace := optionOrEnv(options.Namespace, "VAULT_NAMESPACE"); namespace != "" {
+		client.SetNamespace(namespace)
+	}
+
+	if err := vaultLogin(client, &options); err != nil {
+		return nil, err
+	}
+
+	mount := vaultDefaultMount
+	if options.Mount != nil && *options.Mount != "" {
+		mount = strings.Trim(*options.Mount, "/")
+	}
+
+	prefix := ""
+	if options.Prefix != nil {
+		prefix = *options.Prefix
+	}
+
+	stackDelimiter := "/"
+	if options.StackDelimiter != nil {
+		stackDelimiter = *options.StackDelimiter
+	}
+
+	return &VaultStore{
+		client:         client.KVv2(mount),
+		logical:        client.Logical(),
+		mount:          mount,
+		prefix:         prefix,
+		stackDelimiter: &stackDelimiter,
+	}, nil
+}
+
+// vaultLogin authenticates the client using the configured auth method.
+func vaultLogin(client *vault.Client, options *VaultStoreOptions) error {
+	authMethod := vaultAuthMethodToken
+	if options.AuthMethod != nil && *options.AuthMethod != "" {
+		authMethod = *options.AuthMethod
+	}
+
+	switch authMethod {
+	case vaultAuthMethodToken:
+		token := optionOrEnv(options.Token, "VAULT_TOKEN")
+		if token == "" {
+			return ErrVaultTokenRequired
+		}
+		client.SetToken(token)
+
+	case vaultAuthMethodAppRole:
+		roleID := optionOrEnv(options.RoleID, "VAULT_ROLE_ID")
+		secretID := optionOrEnv(options.SecretID, "VAULT_SECRET_ID")
+		if roleID == "" || secretID == "" {
+			return ErrVaultAppRoleCredentials
+		}
+
+		mountPath := vaultDefaultAppRole
+		if options.AppRoleMount != nil && *options.AppRoleMount != "" {
+			mountPath = *options.AppRoleMount
+		}
+
+		auth, err := approle.NewAppRoleAuth(roleID, &approle.SecretID{FromString: secretID}, approle.WithMountPath(mountPath))
+		if err != nil {
+			return fmt.Errorf(errWrapFormat, ErrVaultLogin, err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), vaultOperationTimeout)
+		defer cancel()
+
+		secret, err := client.Auth().Login(ctx, auth)
+		if err != nil {
+			return fmt.Errorf(errWrapFormat, ErrVaultLogin, err)
+		}
+		if secret == nil || secret.Auth == nil {
+			return ErrVaultLogin
+		}
+
+	default:
+		return fmt.Errorf("%w: %s", ErrVaultUnsupportedAuthMethod, authMethod)
+	}
+
+	return nil
+}
+
+func (s *VaultStore) getKey(stack string, component string, key string) (string, error) {
+	if s.stackDelimiter == nil {
+		return "", ErrStackDelimiterNotSet
+	}
+
+	baseKey, err := getKey(s.prefix, *s.stackDelimiter, stack, component, key, "/")
+	if err != nil {
+		return "", err
+	}
+
+	// Vault paths must not start or end with a slash.
+	return strings.Trim(baseKey, "/"), nil
+}
+
+// Set stores a key-value pair in HashiCorp Vault. Every write creates a new version of the secret.
+func (s *VaultStore) Set(stack string, component string, key string, value interface{}) error {
+	if stack == "" {
+		return ErrEmptyStack
+	}
+	if component == "" {
+		return ErrEmptyComponent
+	}
+	if key == "" {
+		return ErrEmptyKey
+	}
+
+	secretPath, err := s.getKey(stack, component, key)
+	if err != nil {
+		return fmt.Errorf(errWrapFormat, ErrGetKey, err)
+	}
+
+	// Convert value to JSON string like other stores.
+	jsonValue, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf(errWrapFormat, ErrSerializeJSON, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), vaultOperationTimeout)
+	defer cancel()
+
+	_, err = s.client.Put(ctx, secretPath, map[string]interface{}{
+		VaultValueField: string(jsonValue),
+	})
+	if err != nil {
+		return fmt.Errorf(errWrapFormatWithID, ErrSetParameter, secretPath, err)
+	}
+
+	return nil
+}
+
+// Get retrieves the latest version of a value by key from HashiCorp Vault.
+func (s *VaultStore) Get(stack string, component string, key string) (interface{}, error) {
+	return s.GetVersion(stack, component, key, 0)
+}
+
+// GetVersion retrieves a specific version of a value by key from HashiCorp Vault.
+// A version of 0 returns the latest version.
+func (s *VaultStore) GetVersion(stack string, component string, key string, version int) (interface{}, error) {
+	if stack == "" {
+		return nil, ErrEmptyStack
+	}
+	if component == "" {
+		return nil, ErrEmptyComponent
+	}
+	if key == "" {
+		return nil, ErrEmptyKey
+	}
+
+	secretPath, err := s.getKey(stack, component, key)
+	if err != nil {
+		return nil, fmt.Errorf(errWrapFormat, ErrGetKey, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), vaultOperationTimeout)
+	defer cancel()
+
+	var secret *vault.KVSecret
+	if version > 0 {
+		secret, err = s.client.GetVersion(ctx, secretPath, version)
+	} else {
+		secret, err = s.client.Get(ctx, secretPath)
+	}
+	if err != nil {
+		if errors.Is(err, vault.ErrSecretNotFound) {
+			return nil, fmt.Errorf(errWrapFormatWithID, ErrResourceNotFound, secretPath, err)
+		}
+		var respErr *vault.ResponseError
+		if errors.As(err, &respErr) && respErr.StatusCode == statusCodeForbidden {
+			return nil, fmt.Errorf(errWrapFormatWithID, ErrPermissionDenied, fmt.Sprintf("secret %s", secretPath), err)
+		}
+		return nil, fmt.Errorf(errWrapFormat, ErrAccessSecret, err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("%w: %s", ErrResourceNotFound, secretPath)
+	}
+
+	raw, ok := secret.Data[VaultValueField]
+	if !ok {
+		// Secrets written outside of Atmos may not use the value field, so return the whole data map.
+		return secret.Data, nil
+	}
+
+	strValue, ok := raw.(string)
+	if !ok {
+		return raw, nil
+	}
+
+	// Try to unmarshal as JSON first, fallback to string if it fails.
+	var result interface{}
+	if jsonErr := json.Unmarshal([]byte(strValue), &result); jsonErr != nil {
+		return strValue, nil
+	}
+	return result, nil
+}
+
+// Delete removes a key and all of its versions from HashiCorp Vault.
+func (s *VaultStore) Delete(stack string, component string, key string) error {
+	if err := validateKeyArgs(stack, component, key); err != nil {
+		return err
+	}
+
+	secretPath, err := s.getKey(stack, component, key)
+	if err != nil {
+		return fmt.Errorf(errWrapFormat, ErrGetKey, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), vaultOperationTimeout)
+	defer cancel()
+
+	if err := s.client.DeleteMetadata(ctx, secretPath); err != nil {
+		return fmt.Errorf(errWrapFormatWithID, ErrDeleteParameter, secretPath, err)
+	}
+
+	return nil
+}
+
+// List returns the keys stored in HashiCorp Vault for a stack and component.
+func (s *VaultStore) List(stack string, component string) ([]string, error) {
+	if err := validateStackAndComponent(stack, component); err != nil {
+		return nil, err
+	}
+
+	if s.stackDelimiter == nil {
+		return nil, ErrStackDelimiterNotSet
+	}
+
+	keyPrefix, err := getKeyPrefix(s.prefix, *s.stackDelimiter, stack, component, "/")
+	if err != nil {
+		return nil, fmt.Errorf(errWrapFormat, ErrGetKey, err)
+	}
+	keyPrefix = strings.TrimPrefix(keyPrefix, "/")
+
+	ctx, cancel := context.WithTimeout(context.Background(), vaultOperationTimeout)
+	defer cancel()
+
+	keys, err := s.listKeys(ctx, keyPrefix, "")
+	if err != nil {
+		return nil, fmt.Errorf(errWrapFormatWithID, ErrListParameters, keyPrefix, err)
+	}
+
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// listKeys recursively lists the keys under a folder of the KV v2 metadata tree.
+func (s *VaultStore) listKeys(ctx context.Context, keyPrefix string, folder string) ([]string, error) {
+	secret, err := s.logical.ListWithContext(ctx, fmt.Sprintf("%s/metadata/%s%s", s.mount, keyPrefix, folder))
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []string{}
+	if secret == nil || secret.Data == nil {
+		return keys, nil
+	}
+
+	entries, _ := secret.Data["keys"].([]interface{})
+	for _, entry := range entries {
+		name, ok := entry.(string)
+		if !ok {
+			continue
+		}
+
+		if strings.HasSuffix(name, "/") {
+			nested, err := s.listKeys(ctx, keyPrefix, folder+name)
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, nested...)
+			continue
+		}
+
+		keys = append(keys, folder+name)
+	}
+
+	return keys, nil
+}
+
+// Exists checks if a key exists in HashiCorp Vault.
+func (s *VaultStore) Exists(stack string, component string, key string) (bool, error) {
+	_, err := s.Get(stack, component, key)
+	if err == nil {
+		return true, nil
+	}
+
+	if errors.Is(err, ErrResourceNotFound) {
+		return false, nil
+	}
+	return false, err
+}
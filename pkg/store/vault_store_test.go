@@ -0,0 +1,394 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	vault "github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockVaultKVClient is a mock implementation of the VaultKVClient interface.
+type MockVaultKVClient struct {
+	mock.Mock
+}
+
+func (m *MockVaultKVClient) Put(ctx context.Context, secretPath string, data map[string]interface{}, opts ...vault.KVOption) (*vault.KVSecret, error) {
+	args := m.Called(ctx, secretPath, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*vault.KVSecret), args.Error(1)
+}
+
+func (m *MockVaultKVClient) Get(ctx context.Context, secretPath string) (*vault.KVSecret, error) {
+	args := m.Called(ctx, secretPath)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*vault.KVSecret), args.Error(1)
+}
+
+func (m *MockVaultKVClient) GetVersion(ctx context.Context, secretPath string, version int) (*vault.KVSecret, error) {
+	args := m.Called(ctx, secretPath, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*vault.KVSecret), args.Error(1)
+}
+
+func (m *MockVaultKVClient) DeleteMetadata(ctx context.Context, secretPath string) error {
+	args := m.Called(ctx, secretPath)
+	return args.Error(0)
+}
+
+// MockVaultLogicalClient is a mock implementation of the VaultLogicalClient interface.
+type MockVaultLogicalClient struct {
+	mock.Mock
+}
+
+func (m *MockVaultLogicalClient) ListWithContext(ctx context.Context, path string) (*vault.Secret, error) {
+	args := m.Called(ctx, path)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*vault.Secret), args.Error(1)
+}
+
+func newTestVaultStore(client VaultKVClient) *VaultStore {
+	stackDelimiter := "-"
+	return &VaultStore{
+		client:         client,
+		mount:          "secret",
+		prefix:         "atmos",
+		stackDelimiter: &stackDelimiter,
+	}
+}
+
+func TestVaultStore_GetKey(t *testing.T) {
+	store := newTestVaultStore(new(MockVaultKVClient))
+
+	key, err := store.getKey("plat-ue2-dev", "vpc/main", "vpc_id")
+	assert.NoError(t, err)
+	assert.Equal(t, "atmos/plat/ue2/dev/vpc/main/vpc_id", key)
+
+	store.prefix = "/nested/prefix/"
+	key, err = store.getKey("dev", "vpc", "vpc_id")
+	assert.NoError(t, err)
+	assert.Equal(t, "nested/prefix/dev/vpc/vpc_id", key)
+
+	store.stackDelimiter = nil
+	_, err = store.getKey("dev", "vpc", "vpc_id")
+	assert.ErrorIs(t, err, ErrStackDelimiterNotSet)
+}
+
+func TestVaultStore_Set(t *testing.T) {
+	tests := []struct {
+		name      string
+		stack     string
+		component string
+		key       string
+		value     interface{}
+		mockSetup func(*MockVaultKVClient)
+		wantErr   error
+	}{
+		{
+			name:      "successful_set",
+			stack:     "dev-usw2",
+			component: "app",
+			key:       "config",
+			value:     map[string]interface{}{"a": 1},
+			mockSetup: func(m *MockVaultKVClient) {
+				m.On("Put", mock.Anything, "atmos/dev/usw2/app/config", map[string]interface{}{
+					VaultValueField: `{"a":1}`,
+				}).Return(&vault.KVSecret{}, nil)
+			},
+		},
+		{
+			name:      "vault_error",
+			stack:     "dev",
+			component: "app",
+			key:       "config",
+			value:     "value",
+			mockSetup: func(m *MockVaultKVClient) {
+				m.On("Put", mock.Anything, "atmos/dev/app/config", mock.Anything).Return(nil, errors.New("vault error"))
+			},
+			wantErr: ErrSetParameter,
+		},
+		{
+			name:      "empty_stack",
+			component: "app",
+			key:       "config",
+			mockSetup: func(m *MockVaultKVClient) {},
+			wantErr:   ErrEmptyStack,
+		},
+		{
+			name:      "empty_component",
+			stack:     "dev",
+			key:       "config",
+			mockSetup: func(m *MockVaultKVClient) {},
+			wantErr:   ErrEmptyComponent,
+		},
+		{
+			name:      "empty_key",
+			stack:     "dev",
+			component: "app",
+			mockSetup: func(m *MockVaultKVClient) {},
+			wantErr:   ErrEmptyKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := new(MockVaultKVClient)
+			tt.mockSetup(client)
+			store := newTestVaultStore(client)
+
+			err := store.Set(tt.stack, tt.component, tt.key, tt.value)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			client.AssertExpectations(t)
+		})
+	}
+}
+
+func TestVaultStore_Get(t *testing.T) {
+	tests := []struct {
+		name      string
+		mockSetup func(*MockVaultKVClient)
+		want      interface{}
+		wantErr   error
+	}{
+		{
+			name: "json_value",
+			mockSetup: func(m *MockVaultKVClient) {
+				m.On("Get", mock.Anything, "atmos/dev/app/config").Return(&vault.KVSecret{
+					Data: map[string]interface{}{VaultValueField: `{"a":"b"}`},
+				}, nil)
+			},
+			want: map[string]interface{}{"a": "b"},
+		},
+		{
+			name: "raw_string_value",
+			mockSetup: func(m *MockVaultKVClient) {
+				m.On("Get", mock.Anything, "atmos/dev/app/config").Return(&vault.KVSecret{
+					Data: map[string]interface{}{VaultValueField: "not-json"},
+				}, nil)
+			},
+			want: "not-json",
+		},
+		{
+			name: "secret_without_value_field",
+			mockSetup: func(m *MockVaultKVClient) {
+				m.On("Get", mock.Anything, "atmos/dev/app/config").Return(&vault.KVSecret{
+					Data: map[string]interface{}{"username": "admin"},
+				}, nil)
+			},
+			want: map[string]interface{}{"username": "admin"},
+		},
+		{
+			name: "not_found",
+			mockSetup: func(m *MockVaultKVClient) {
+				m.On("Get", mock.Anything, "atmos/dev/app/config").Return(nil, vault.ErrSecretNotFound)
+			},
+			wantErr: ErrResourceNotFound,
+		},
+		{
+			name: "permission_denied",
+			mockSetup: func(m *MockVaultKVClient) {
+				m.On("Get", mock.Anything, "atmos/dev/app/config").Return(nil, &vault.ResponseError{StatusCode: http.StatusForbidden})
+			},
+			wantErr: ErrPermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := new(MockVaultKVClient)
+			tt.mockSetup(client)
+			store := newTestVaultStore(client)
+
+			got, err := store.Get("dev", "app", "config")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestVaultStore_GetVersion(t *testing.T) {
+	client := new(MockVaultKVClient)
+	client.On("GetVersion", mock.Anything, "atmos/dev/app/config", 2).Return(&vault.KVSecret{
+		Data: map[string]interface{}{VaultValueField: `"v2"`},
+	}, nil)
+	store := newTestVaultStore(client)
+
+	got, err := store.GetVersion("dev", "app", "config", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "v2", got)
+	client.AssertExpectations(t)
+}
+
+func TestVaultStore_Delete(t *testing.T) {
+	client := new(MockVaultKVClient)
+	client.On("DeleteMetadata", mock.Anything, "atmos/dev/app/config").Return(nil)
+	client.On("DeleteMetadata", mock.Anything, "atmos/dev/app/broken").Return(errors.New("vault error"))
+	store := newTestVaultStore(client)
+
+	assert.NoError(t, store.Delete("dev", "app", "config"))
+	assert.ErrorIs(t, store.Delete("dev", "app", "broken"), ErrDeleteParameter)
+	assert.ErrorIs(t, store.Delete("dev", "app", ""), ErrEmptyKey)
+}
+
+func TestVaultStore_List(t *testing.T) {
+	logical := new(MockVaultLogicalClient)
+	logical.On("ListWithContext", mock.Anything, "secret/metadata/atmos/dev/app/").Return(&vault.Secret{
+		Data: map[string]interface{}{"keys": []interface{}{"vpc_id", "nested/"}},
+	}, nil)
+	logical.On("ListWithContext", mock.Anything, "secret/metadata/atmos/dev/app/nested/").Return(&vault.Secret{
+		Data: map[string]interface{}{"keys": []interface{}{"cidr"}},
+	}, nil)
+	logical.On("ListWithContext", mock.Anything, "secret/metadata/atmos/dev/empty/").Return(nil, nil)
+	store := newTestVaultStore(new(MockVaultKVClient))
+	store.logical = logical
+
+	keys, err := store.List("dev", "app")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"nested/cidr", "vpc_id"}, keys)
+
+	keys, err = store.List("dev", "empty")
+	assert.NoError(t, err)
+	assert.Empty(t, keys)
+}
+
+func TestVaultStore_Exists(t *testing.T) {
+	client := new(MockVaultKVClient)
+	client.On("Get", mock.Anything, "atmos/dev/app/present").Return(&vault.KVSecret{
+		Data: map[string]interface{}{VaultValueField: `"value"`},
+	}, nil)
+	client.On("Get", mock.Anything, "atmos/dev/app/missing").Return(nil, vault.ErrSecretNotFound)
+	store := newTestVaultStore(client)
+
+	exists, err := store.Exists("dev", "app", "present")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = store.Exists("dev", "app", "missing")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestNewVaultStore(t *testing.T) {
+	t.Setenv("VAULT_ADDR", "")
+	t.Setenv("VAULT_TOKEN", "")
+	t.Setenv("VAULT_ROLE_ID", "")
+	t.Setenv("VAULT_SECRET_ID", "")
+
+	_, err := NewVaultStore(VaultStoreOptions{})
+	assert.ErrorIs(t, err, ErrVaultAddressRequired)
+
+	_, err = NewVaultStore(VaultStoreOptions{Address: ptr("http://127.0.0.1:8200")})
+	assert.ErrorIs(t, err, ErrVaultTokenRequired)
+
+	_, err = NewVaultStore(VaultStoreOptions{Address: ptr("http://127.0.0.1:8200"), AuthMethod: ptr("approle")})
+	assert.ErrorIs(t, err, ErrVaultAppRoleCredentials)
+
+	_, err = NewVaultStore(VaultStoreOptions{Address: ptr("http://127.0.0.1:8200"), AuthMethod: ptr("ldap")})
+	assert.ErrorIs(t, err, ErrVaultUnsupportedAuthMethod)
+
+	s, err := NewVaultStore(VaultStoreOptions{Address: ptr("http://127.0.0.1:8200"), Token: ptr("root")})
+	assert.NoError(t, err)
+	assert.IsType(t, &VaultStore{}, s)
+}
+
+// TestVaultStore_DevServer exercises token and AppRole auth and the KV v2 round trip against an HTTP server
+// emulating the Vault dev server API.
+func TestVaultStore_DevServer(t *testing.T) {
+	secrets := map[string]map[string]interface{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/v1/auth/approle/login":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"auth": map[string]interface{}{"client_token": "approle-token"},
+			})
+		case r.Header.Get("X-Vault-Token") != "approle-token":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+		case r.Method == http.MethodDelete:
+			delete(secrets, strings.Replace(r.URL.Path, "/metadata/", "/data/", 1))
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Query().Get("list") == "true":
+			dataPrefix := strings.TrimSuffix(strings.Replace(r.URL.Path, "/metadata/", "/data/", 1), "/") + "/"
+			keys := []string{}
+			for p := range secrets {
+				if strings.HasPrefix(p, dataPrefix) {
+					keys = append(keys, strings.TrimPrefix(p, dataPrefix))
+				}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{"keys": keys},
+			})
+		case r.Method == http.MethodPut || r.Method == http.MethodPost:
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			secrets[r.URL.Path] = body["data"].(map[string]interface{})
+			_, _ = w.Write([]byte(`{"data":{"version":1}}`))
+		default:
+			data, ok := secrets[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"errors":[]}`))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{
+					"data":     data,
+					"metadata": map[string]interface{}{"version": 1},
+				},
+			})
+		}
+	}))
+	defer server.Close()
+
+	s, err := NewVaultStore(VaultStoreOptions{
+		Address:    ptr(server.URL),
+		AuthMethod: ptr("approle"),
+		RoleID:     ptr("role"),
+		SecretID:   ptr("secret"),
+		Mount:      ptr("kv"),
+		Prefix:     ptr("atmos"),
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, s.Set("dev", "vpc", "vpc_id", "vpc-123"))
+	assert.Contains(t, secrets, "/v1/kv/data/atmos/dev/vpc/vpc_id")
+
+	got, err := s.Get("dev", "vpc", "vpc_id")
+	assert.NoError(t, err)
+	assert.Equal(t, "vpc-123", got)
+
+	_, err = s.Get("dev", "vpc", "missing")
+	assert.ErrorIs(t, err, ErrResourceNotFound)
+
+	keys, err := s.List("dev", "vpc")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"vpc_id"}, keys)
+
+	assert.NoError(t, s.Delete("dev", "vpc", "vpc_id"))
+	exists, err := s.Exists("dev", "vpc", "vpc_id")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
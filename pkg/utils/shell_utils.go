@@ -58,6 +58,12 @@ func ExecuteShellAndReturnOutput(
 
 // ShellRunner uses mvdan.cc/sh/v3's parser and interpreter to run a shell script and divert its stdout .
 func ShellRunner(command string, name string, dir string, env []string, out io.Writer) error {
+	return ShellRunnerWithContext(context.TODO(), command, name, dir, env, os.Stdin, out, os.Stderr)
+}
+
+// ShellRunnerWithContext runs a shell script like ShellRunner, with the given standard streams.
+// The script is stopped if the context is done before the script completes.
+func ShellRunnerWithContext(ctx context.Context, command string, name string, dir string, env []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
 	parser, err := syntax.NewParser().Parse(strings.NewReader(command), name)
 	if err != nil {
 		return err
@@ -68,13 +74,13 @@ func ShellRunner(command string, name string, dir string, env []string, out io.W
 	runner, err := interp.New(
 		interp.Dir(dir),
 		interp.Env(listEnviron),
-		interp.StdIO(os.Stdin, out, os.Stderr),
+		interp.StdIO(stdin, stdout, stderr),
 	)
 	if err != nil {
 		return err
 	}
 
-	return runner.Run(context.TODO(), parser)
+	return runner.Run(ctx, parser)
 }
 
 // GetNextShellLevel increments the ATMOS_SHLVL and returns the new value or an error if maximum depth is exceeded .